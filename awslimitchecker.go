@@ -2,43 +2,336 @@ package awslimitchecker
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 
 	"github.com/sebasrp/awslimitchecker/internal/services"
 )
 
 var SupportedAwsServices = map[string]func() services.Svcquota{
 	"acm":            services.NewAcmChecker,
+	"apigateway":     services.NewApigatewayChecker,
+	"appsync":        services.NewAppSyncChecker,
 	"autoscaling":    services.NewAutoscalingChecker,
+	"backup":         services.NewBackupChecker,
+	"batch":          services.NewBatchChecker,
 	"cloudformation": services.NewCloudformationChecker,
+	"cloudfront":     services.NewCloudfrontChecker,
+	"cloudtrail":     services.NewCloudtrailChecker,
+	"cloudwatch":     services.NewCloudwatchChecker,
+	"cognito":        services.NewCognitoIdpChecker,
+	"cognito-id":     services.NewCognitoIdentityChecker,
+	"config":         services.NewConfigChecker,
+	"directconnect":  services.NewDirectConnectChecker,
+	"docdb":          services.NewDocDbChecker,
 	"dynamodb":       services.NewDynamoDbChecker,
 	"ebs":            services.NewEbsChecker,
+	"ec2":            services.NewEc2Checker,
+	"efs":            services.NewEfsChecker,
 	"eks":            services.NewEksChecker,
 	"elasticache":    services.NewElastiCacheChecker,
 	"elb":            services.NewElbChecker,
+	"eventbridge":    services.NewEventbridgeChecker,
+	"fsx":            services.NewFsxChecker,
+	"glue":           services.NewGlueChecker,
 	"iam":            services.NewIamChecker,
 	"kinesis":        services.NewKinesisChecker,
+	"kms":            services.NewKmsChecker,
+	"logs":           services.NewCloudwatchLogsChecker,
+	"mq":             services.NewMqChecker,
+	"msk":            services.NewMskChecker,
+	"neptune":        services.NewNeptuneChecker,
+	"opensearch":     services.NewOpenSearchChecker,
 	"rds":            services.NewRdsChecker,
+	"redshift":       services.NewRedshiftChecker,
 	"s3":             services.NewS3Checker,
+	"secretsmanager": services.NewSecretsManagerChecker,
+	"ses":            services.NewSesChecker,
 	"sns":            services.NewSnsChecker,
+	"ssm":            services.NewSsmChecker,
+	"stepfunctions":  services.NewStepFunctionsChecker,
+	"storagegateway": services.NewStorageGatewayChecker,
+	"transitgateway": services.NewTransitGatewayChecker,
+	"vpn":            services.NewVpnChecker,
+	"wafv2":          services.NewWafv2Checker,
 }
 
 func GetUsage(awsService string, awsprofile string, region string, overrides []services.AWSQuotaOverride) (ret []services.AWSQuotaInfo) {
-	_, err := services.InitializeConfig(awsprofile, region)
+	ret, _ = GetUsageWithOptions(awsprofile, region, UsageOptions{Services: []string{awsService}, Overrides: overrides})
+	return
+}
+
+// GetUsageWithUsageMetrics behaves like GetUsage, and also reports the quotas
+// servicequotas tracks through a cloudwatch usage metric. awsService can then be
+// any servicequotas service code, not only the supported services
+func GetUsageWithUsageMetrics(awsService string, awsprofile string, region string, overrides []services.AWSQuotaOverride) (ret []services.AWSQuotaInfo) {
+	ret, _ = GetUsageWithOptions(awsprofile, region, UsageOptions{Services: []string{awsService}, Overrides: overrides, UsageMetrics: true})
+	return
+}
+
+// UsageOptions selects the checks to run and the quotas to report
+type UsageOptions struct {
+	Services         []string                    // services to check, `all` for every supported service
+	ExcludeServices  []string                    // services not to check
+	Quotas           []string                    // regular expressions, only the quotas whose name matches one of them are checked
+	ExcludeQuotas    []string                    // regular expressions, the quotas whose name matches one of them are not checked
+	OnlyOver         float64                     // only report the quotas whose usage reaches this percentage of the quota
+	UsageMetrics     bool                        // also report the quotas tracked by a cloudwatch usage metric
+	Overrides        []services.AWSQuotaOverride // overrides applied to the quotas
+	SkipUnauthorized bool                        // skip the quotas whose usage requires a denied permission
+}
+
+// GetUsageWithOptions retrieves the usage of the quotas selected by the given
+// options. Services and quotas are filtered before being checked, so that the
+// permissions of the excluded ones are not required
+func GetUsageWithOptions(awsprofile string, region string, options UsageOptions) (ret []services.AWSQuotaInfo, err error) {
+	quotaFilter, err := newQuotaFilter(options.Quotas, options.ExcludeQuotas)
+	if err != nil {
+		return
+	}
+
+	_, err = services.InitializeConfig(awsprofile, region)
 	if err != nil {
 		fmt.Printf("Unable to create AWS session, %v", err)
 		return
 	}
+	checkers := getCheckers(options.Services, options.ExcludeServices)
+	if options.UsageMetrics {
+		checkers = append(checkers, getUsageMetricCheckers(options.Services, options.ExcludeServices, checkers, quotaFilter)...)
+	}
+	// only the overrides of the checked services are validated, so that the
+	// servicequotas permissions of the excluded ones are not required
+	err = checkOverrideQuotaNames(getCheckersOverrides(options.Overrides, checkers), func(serviceCode string) map[string]services.AWSQuotaInfo {
+		return services.NewServiceChecker(serviceCode, nil, nil).GetAllAppliedQuotas()
+	})
+	if err != nil {
+		return
+	}
+	services.ResetDeniedActions()
+	services.SetSkipUnauthorized(options.SkipUnauthorized)
 
-	if awsService == "all" {
-		for _, checker := range SupportedAwsServices {
-			service := checker()
-			service.SetQuotasOverride(overrides)
-			ret = append(ret, service.GetUsage()...)
+	for _, service := range checkers {
+		// quotas not selected are removed beforehand, so that their usage is
+		// not retrieved
+		if svcChecker, ok := service.(*services.ServiceChecker); ok {
+			for quotaName := range svcChecker.SupportedQuotas {
+				if !quotaFilter(quotaName) {
+					delete(svcChecker.SupportedQuotas, quotaName)
+				}
+			}
+			if len(svcChecker.SupportedQuotas) == 0 {
+				continue
+			}
+		}
+		deniedCalls := services.GetDeniedCalls()
+		usage := service.GetUsage()
+		// service checkers skip their unauthorized quotas themselves, other
+		// checkers are skipped as a whole
+		if _, ok := service.(*services.ServiceChecker); !ok && options.SkipUnauthorized && services.GetDeniedCalls() != deniedCalls {
+			continue
+		}
+		for _, quota := range usage {
+			if quotaFilter(quota.QuotaName) {
+				ret = append(ret, quota)
+			}
 		}
-	} else if val, ok := SupportedAwsServices[awsService]; ok {
-		service := val()
-		service.SetQuotasOverride(overrides)
-		ret = service.GetUsage()
+	}
+	// overrides are applied last, to every quota whatever its value source
+	ret = services.ApplyQuotaOverrides(ret, options.Overrides, region)
+	ret = filterKnownQuotas(ret)
+	if options.OnlyOver > 0 {
+		ret = filterUsageOver(ret, options.OnlyOver)
+	}
+	return
+}
+
+// getCheckers returns the checkers of the given supported services, or of all
+// of them if `all` is part of the services, minus the excluded ones
+func getCheckers(awsServices []string, excludedServices []string) (ret []services.Svcquota) {
+	excluded := map[string]bool{}
+	for _, service := range excludedServices {
+		excluded[service] = true
+	}
+	selected := map[string]bool{}
+	for _, service := range awsServices {
+		if service == "all" {
+			for name := range SupportedAwsServices {
+				selected[name] = true
+			}
+		} else if _, ok := SupportedAwsServices[service]; ok {
+			selected[service] = true
+		}
+	}
+
+	names := []string{}
+	for name := range selected {
+		if !excluded[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ret = append(ret, SupportedAwsServices[name]())
+	}
+	return
+}
+
+// getCheckersOverrides returns the overrides whose service code is the one of a
+// service checker of the given checkers
+func getCheckersOverrides(overrides []services.AWSQuotaOverride, checkers []services.Svcquota) (ret []services.AWSQuotaOverride) {
+	serviceCodes := map[string]bool{}
+	for _, checker := range checkers {
+		if svcChecker, ok := checker.(*services.ServiceChecker); ok {
+			serviceCodes[svcChecker.ServiceCode] = true
+		}
+	}
+	for _, override := range overrides {
+		if serviceCodes[override.Service] {
+			ret = append(ret, override)
+		}
+	}
+	return
+}
+
+// newQuotaFilter returns a func telling whether a quota name matches one of the
+// quotas regular expressions, if any, and none of the excluded ones
+func newQuotaFilter(quotas []string, excludedQuotas []string) (func(string) bool, error) {
+	compile := func(expressions []string) (ret []*regexp.Regexp, err error) {
+		for _, expression := range expressions {
+			re, err := regexp.Compile(expression)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quota expression %q, %v", expression, err)
+			}
+			ret = append(ret, re)
+		}
+		return
+	}
+	included, err := compile(quotas)
+	if err != nil {
+		return nil, err
+	}
+	excluded, err := compile(excludedQuotas)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(quotaName string) bool {
+		for _, re := range excluded {
+			if re.MatchString(quotaName) {
+				return false
+			}
+		}
+		if len(included) == 0 {
+			return true
+		}
+		for _, re := range included {
+			if re.MatchString(quotaName) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// filterKnownQuotas removes the quotas whose value is neither known from aws
+// nor overridden
+func filterKnownQuotas(quotas []services.AWSQuotaInfo) (ret []services.AWSQuotaInfo) {
+	for _, quota := range quotas {
+		if quota.Source != services.QuotaSourceUnknown {
+			ret = append(ret, quota)
+		}
+	}
+	return
+}
+
+// filterUsageOver returns the quotas whose usage reaches the given percentage of
+// the quota. A used quota of 0 is always over
+func filterUsageOver(quotas []services.AWSQuotaInfo, percent float64) (ret []services.AWSQuotaInfo) {
+	for _, quota := range quotas {
+		if quota.QuotaValue == 0 {
+			if quota.UsageValue > 0 {
+				ret = append(ret, quota)
+			}
+			continue
+		}
+		if quota.UsageValue/quota.QuotaValue*100 >= percent {
+			ret = append(ret, quota)
+		}
+	}
+	return
+}
+
+// getUsageMetricCheckers returns a usage metric checker for each service code
+// covered by the given checkers, and for each requested service that is not a
+// supported one, taken as a servicequotas service code. Quotas of hand-written
+// checkers are skipped, those take precedence, and only the quotas selected by
+// the filter are queried
+func getUsageMetricCheckers(awsServices []string, excludedServices []string, checkers []services.Svcquota, quotaFilter func(string) bool) (ret []services.Svcquota) {
+	serviceCodes := map[string]bool{}
+	for _, checker := range checkers {
+		if svcChecker, ok := checker.(*services.ServiceChecker); ok {
+			serviceCodes[svcChecker.ServiceCode] = true
+		}
+	}
+	for _, service := range awsServices {
+		if _, ok := SupportedAwsServices[service]; !ok && service != "all" {
+			serviceCodes[service] = true
+		}
+	}
+	for _, service := range excludedServices {
+		if _, ok := SupportedAwsServices[service]; !ok {
+			delete(serviceCodes, service)
+		}
+	}
+
+	handledQuotas := getHandledQuotas()
+	sortedServiceCodes := []string{}
+	for serviceCode := range serviceCodes {
+		sortedServiceCodes = append(sortedServiceCodes, serviceCode)
+	}
+	sort.Strings(sortedServiceCodes)
+	for _, serviceCode := range sortedServiceCodes {
+		ret = append(ret, services.NewUsageMetricChecker(serviceCode, handledQuotas[serviceCode], quotaFilter))
+	}
+	return
+}
+
+// getHandledQuotas returns, per service code, the quotas the supported services
+// compute the usage of
+func getHandledQuotas() (ret map[string][]string) {
+	ret = map[string][]string{}
+	for _, checker := range SupportedAwsServices {
+		if svcChecker, ok := checker().(*services.ServiceChecker); ok {
+			for quotaName := range svcChecker.SupportedQuotas {
+				ret[svcChecker.ServiceCode] = append(ret[svcChecker.ServiceCode], quotaName)
+			}
+		}
+	}
+	return
+}
+
+// MissingPermission is an iam action denied during the last usage retrieval
+type MissingPermission struct {
+	Action   string   // the denied iam action, e.g. eks:ListClusters
+	Services []string // the supported services requiring the action, none if it is not part of their required permissions
+}
+
+// GetMissingPermissions returns the iam actions denied during the last usage
+// retrieval, with the supported services requiring them
+func GetMissingPermissions() []MissingPermission {
+	return getMissingPermissions(services.GetDeniedActions())
+}
+
+func getMissingPermissions(deniedActions []string) (ret []MissingPermission) {
+	requiredBy := map[string][]string{}
+	for name, checker := range SupportedAwsServices {
+		for _, permission := range checker().GetRequiredPermissions() {
+			requiredBy[permission] = append(requiredBy[permission], name)
+		}
+	}
+	for _, action := range deniedActions {
+		sort.Strings(requiredBy[action])
+		ret = append(ret, MissingPermission{Action: action, Services: requiredBy[action]})
 	}
 	return
 }
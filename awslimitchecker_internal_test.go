@@ -0,0 +1,187 @@
+package awslimitchecker
+
+import (
+	"testing"
+
+	"github.com/sebasrp/awslimitchecker/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUsageMetricCheckers(t *testing.T) {
+	SupportedAwsServices = map[string]func() services.Svcquota{
+		"transitgateway": services.NewTransitGatewayChecker,
+		"vpn":            services.NewVpnChecker,
+		"kms":            services.NewKmsChecker,
+	}
+
+	// hand-written checkers sharing a service code get a single usage metric
+	// checker, which skips the quotas they handle
+	checkers := getUsageMetricCheckers([]string{"all"}, nil, []services.Svcquota{services.NewTransitGatewayChecker(), services.NewVpnChecker()}, nil)
+	assert.Len(t, checkers, 1)
+	usageMetricChecker := checkers[0].(*services.UsageMetricChecker)
+	assert.Equal(t, "ec2", usageMetricChecker.ServiceCode)
+	assert.True(t, usageMetricChecker.HandledQuotas["Transit gateways per account"])
+	assert.True(t, usageMetricChecker.HandledQuotas["Site-to-Site VPN connections per Region"])
+	assert.False(t, usageMetricChecker.HandledQuotas["Customer Master Keys (CMKs) per Region"])
+
+	// without hand-written checker, the service is a servicequotas service code
+	checkers = getUsageMetricCheckers([]string{"lambda"}, nil, []services.Svcquota{}, nil)
+	assert.Len(t, checkers, 1)
+	usageMetricChecker = checkers[0].(*services.UsageMetricChecker)
+	assert.Equal(t, "lambda", usageMetricChecker.ServiceCode)
+	assert.Empty(t, usageMetricChecker.HandledQuotas)
+
+	assert.Empty(t, getUsageMetricCheckers([]string{"all"}, nil, []services.Svcquota{}, nil))
+
+	// several services can be requested, and excluded
+	checkers = getUsageMetricCheckers([]string{"lambda", "vpn", "sqs"}, []string{"sqs"}, []services.Svcquota{services.NewVpnChecker()}, nil)
+	assert.Len(t, checkers, 2)
+	assert.Equal(t, "ec2", checkers[0].(*services.UsageMetricChecker).ServiceCode)
+	assert.Equal(t, "lambda", checkers[1].(*services.UsageMetricChecker).ServiceCode)
+}
+
+func TestGetCheckers(t *testing.T) {
+	SupportedAwsServices = map[string]func() services.Svcquota{
+		"transitgateway": services.NewTransitGatewayChecker,
+		"vpn":            services.NewVpnChecker,
+		"kms":            services.NewKmsChecker,
+	}
+
+	assert.Len(t, getCheckers([]string{"all"}, nil), 3)
+	assert.Len(t, getCheckers([]string{"all"}, []string{"vpn", "kms"}), 1)
+	assert.Len(t, getCheckers([]string{"vpn", "kms", "vpn"}, nil), 2)
+	assert.Len(t, getCheckers([]string{"vpn", "unknown"}, nil), 1)
+	assert.Empty(t, getCheckers([]string{"vpn"}, []string{"vpn"}))
+}
+
+func TestGetCheckersOverrides(t *testing.T) {
+	SupportedAwsServices = map[string]func() services.Svcquota{
+		"vpn": services.NewVpnChecker,
+		"kms": services.NewKmsChecker,
+	}
+	overrides := []services.AWSQuotaOverride{
+		{Service: "ec2", QuotaName: "Customer gateways per Region", QuotaValue: 10},
+		{Service: "kms", QuotaName: "Aliases per KMS key", QuotaValue: 10},
+	}
+
+	assert.Equal(t, overrides, getCheckersOverrides(overrides, getCheckers([]string{"all"}, nil)))
+	assert.Equal(t, overrides[:1], getCheckersOverrides(overrides, getCheckers([]string{"all"}, []string{"kms"})))
+	assert.Empty(t, getCheckersOverrides(overrides, getCheckers([]string{"vpn"}, []string{"vpn"})))
+}
+
+func TestNewQuotaFilter(t *testing.T) {
+	filter, err := newQuotaFilter(nil, nil)
+	assert.Nil(t, err)
+	assert.True(t, filter("Transit gateways per account"))
+
+	filter, err = newQuotaFilter([]string{"(?i)^transit", "VPN"}, []string{"attachments"})
+	assert.Nil(t, err)
+	assert.True(t, filter("Transit gateways per account"))
+	assert.True(t, filter("Site-to-Site VPN connections per Region"))
+	assert.False(t, filter("Transit gateway attachments per account"))
+	assert.False(t, filter("Customer gateways per Region"))
+
+	_, err = newQuotaFilter([]string{"("}, nil)
+	assert.Error(t, err)
+	_, err = newQuotaFilter(nil, []string{"("})
+	assert.Error(t, err)
+}
+
+func TestFilterUsageOver(t *testing.T) {
+	quotas := []services.AWSQuotaInfo{
+		{QuotaName: "low", QuotaValue: 100, UsageValue: 10},
+		{QuotaName: "high", QuotaValue: 100, UsageValue: 80},
+		{QuotaName: "zero unused", QuotaValue: 0, UsageValue: 0},
+		{QuotaName: "zero used", QuotaValue: 0, UsageValue: 1},
+	}
+	actual := filterUsageOver(quotas, 80)
+	assert.Len(t, actual, 2)
+	assert.Equal(t, "high", actual[0].QuotaName)
+	assert.Equal(t, "zero used", actual[1].QuotaName)
+}
+
+func TestFilterKnownQuotas(t *testing.T) {
+	quotas := []services.AWSQuotaInfo{
+		{QuotaName: "default", Source: services.QuotaSourceDefault},
+		{QuotaName: "unknown", Source: services.QuotaSourceUnknown},
+		{QuotaName: "overridden", Source: services.QuotaSourceOverride},
+	}
+	actual := filterKnownQuotas(quotas)
+	assert.Len(t, actual, 2)
+	assert.Equal(t, "default", actual[0].QuotaName)
+	assert.Equal(t, "overridden", actual[1].QuotaName)
+}
+
+func TestGetOwnQuotas(t *testing.T) {
+	SupportedAwsServices = map[string]func() services.Svcquota{
+		"ec2": services.NewEc2Checker,
+		"vpn": services.NewVpnChecker,
+		"elb": services.NewElbChecker,
+	}
+
+	// vpn shares the ec2 service code, only its quotas are listed
+	vpn := getOwnQuotas("vpn", services.NewVpnChecker().(*services.ServiceChecker))
+	assert.True(t, vpn["Site-to-Site VPN connections per Region"])
+	assert.False(t, vpn["Versions per launch template"])
+
+	// the whole service code is listed for its own service, or when not shared
+	assert.Nil(t, getOwnQuotas("ec2", services.NewEc2Checker().(*services.ServiceChecker)))
+	assert.Nil(t, getOwnQuotas("elb", services.NewElbChecker().(*services.ServiceChecker)))
+}
+
+func TestMatchesQuotasOptions(t *testing.T) {
+	quota := services.AWSQuotaInfo{QuotaName: "Rules per VPC security group", Quotacode: "L-0EA8095F", Adjustable: true}
+
+	assert.True(t, matchesQuotasOptions(quota, QuotasOptions{}))
+	assert.True(t, matchesQuotasOptions(quota, QuotasOptions{AdjustableOnly: true}))
+	assert.True(t, matchesQuotasOptions(quota, QuotasOptions{Search: "security GROUP"}))
+	assert.True(t, matchesQuotasOptions(quota, QuotasOptions{Search: "l-0ea8"}))
+	assert.False(t, matchesQuotasOptions(quota, QuotasOptions{Search: "subnet"}))
+
+	quota.Adjustable = false
+	assert.False(t, matchesQuotasOptions(quota, QuotasOptions{AdjustableOnly: true}))
+}
+
+func TestGetUsageWithOptionsSkipsExcludedQuotas(t *testing.T) {
+	called := map[string]bool{}
+	quota := func(name string) func(services.ServiceChecker) []services.AWSQuotaInfo {
+		return func(c services.ServiceChecker) []services.AWSQuotaInfo {
+			called[name] = true
+			return []services.AWSQuotaInfo{{Service: c.ServiceCode, QuotaName: name}}
+		}
+	}
+	SupportedAwsServices = map[string]func() services.Svcquota{
+		"test": func() services.Svcquota {
+			return services.NewServiceChecker("test", map[string]func(services.ServiceChecker) []services.AWSQuotaInfo{
+				"foo quota": quota("foo quota"),
+				"bar quota": quota("bar quota"),
+			}, nil)
+		},
+	}
+	services.InitializeConfig = func(awsprofile, region string) (*services.Config, error) {
+		return &services.Config{}, nil
+	}
+
+	actual, err := GetUsageWithOptions("testProfile", "testRegion", UsageOptions{Services: []string{"test"}, Quotas: []string{"^foo"}})
+	assert.Nil(t, err)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "foo quota", actual[0].QuotaName)
+	assert.True(t, called["foo quota"])
+	assert.False(t, called["bar quota"])
+}
+
+func TestGetMissingPermissions(t *testing.T) {
+	SupportedAwsServices = map[string]func() services.Svcquota{
+		"transitgateway": services.NewTransitGatewayChecker,
+		"vpn":            services.NewVpnChecker,
+		"kms":            services.NewKmsChecker,
+	}
+
+	actual := getMissingPermissions([]string{"cloudwatch:GetMetricData", "ec2:DescribeVpnConnections", "kms:ListKeys", "servicequotas:ListServiceQuotas"})
+	assert.Equal(t, []MissingPermission{
+		{Action: "cloudwatch:GetMetricData"},
+		{Action: "ec2:DescribeVpnConnections", Services: []string{"vpn"}},
+		{Action: "kms:ListKeys", Services: []string{"kms"}},
+		{Action: "servicequotas:ListServiceQuotas", Services: []string{"kms", "transitgateway", "vpn"}},
+	}, actual)
+}
@@ -204,3 +204,58 @@ func TestGetUsageOverrideAll(t *testing.T) {
 	assert.Equal(t, float64(300), actual[0].QuotaValue)
 	assert.Equal(t, float64(300), actual[1].QuotaValue) // because both services have same name
 }
+
+func TestGetUsageWithUsageMetricsSingle(t *testing.T) {
+	awslimitchecker.SupportedAwsServices = map[string]func() services.Svcquota{
+		"foo": NewTestChecker,
+		"bar": NewTestChecker,
+	}
+	services.InitializeConfig = func(awsprofile, region string) (*services.Config, error) {
+		return &services.Config{}, nil
+	}
+	assert.Equal(t, 1, len(awslimitchecker.GetUsageWithUsageMetrics("foo", "testProfile", "testRegion", nil)))
+}
+
+func TestGetQuotasErrorInit(t *testing.T) {
+	services.InitializeConfig = func(awsprofile, region string) (*services.Config, error) {
+		return nil, errors.New("test error")
+	}
+	assert.Empty(t, awslimitchecker.GetQuotas("foo", "testProfile", "testRegion", awslimitchecker.QuotasOptions{}))
+}
+
+func TestGetUsageWithOptions(t *testing.T) {
+	awslimitchecker.SupportedAwsServices = map[string]func() services.Svcquota{
+		"foo": NewTestChecker,
+		"bar": NewTestChecker,
+		"baz": NewTestChecker,
+	}
+	services.InitializeConfig = func(awsprofile, region string) (*services.Config, error) {
+		return &services.Config{}, nil
+	}
+
+	actual, err := awslimitchecker.GetUsageWithOptions("testProfile", "testRegion", awslimitchecker.UsageOptions{
+		Services: []string{"foo", "bar", "baz"}, ExcludeServices: []string{"baz"}})
+	assert.Nil(t, err)
+	assert.Len(t, actual, 2)
+
+	actual, err = awslimitchecker.GetUsageWithOptions("testProfile", "testRegion", awslimitchecker.UsageOptions{
+		Services: []string{"all"}, ExcludeQuotas: []string{"^test"}})
+	assert.Nil(t, err)
+	assert.Empty(t, actual)
+
+	// usage is 50 out of 200
+	actual, err = awslimitchecker.GetUsageWithOptions("testProfile", "testRegion", awslimitchecker.UsageOptions{
+		Services: []string{"foo"}, OnlyOver: 25})
+	assert.Nil(t, err)
+	assert.Len(t, actual, 1)
+	actual, err = awslimitchecker.GetUsageWithOptions("testProfile", "testRegion", awslimitchecker.UsageOptions{
+		Services: []string{"foo"}, OnlyOver: 30})
+	assert.Nil(t, err)
+	assert.Empty(t, actual)
+}
+
+func TestGetUsageWithOptionsInvalidQuota(t *testing.T) {
+	_, err := awslimitchecker.GetUsageWithOptions("testProfile", "testRegion", awslimitchecker.UsageOptions{
+		Services: []string{"all"}, Quotas: []string{"("}})
+	assert.Error(t, err)
+}
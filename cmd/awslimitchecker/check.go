@@ -2,11 +2,11 @@ package main
 
 import (
 	"encoding/csv"
-	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/sebasrp/awslimitchecker"
 	"github.com/sebasrp/awslimitchecker/internal/services"
@@ -14,31 +14,62 @@ import (
 	"github.com/spf13/viper"
 )
 
+var (
+	usageMetrics     bool
+	excludeServices  []string
+	quotasFilter     []string
+	excludeQuotas    []string
+	onlyOver         float64
+	skipUnauthorized bool
+	record           bool
+)
+
 func init() {
 	rootCmd.AddCommand(check)
+
+	check.Flags().StringSliceVar(&excludeServices, "exclude-service", []string{}, "services not to check")
+	check.Flags().StringArrayVar(&quotasFilter, "quota", []string{}, "only check the quotas whose name matches the regular expression. Can be repeated")
+	check.Flags().StringArrayVar(&excludeQuotas, "exclude-quota", []string{}, "do not check the quotas whose name matches the regular expression. Can be repeated")
+	check.Flags().Float64Var(&onlyOver, "only-over", 0, "only report the quotas whose usage reaches the given percentage of the quota")
+	check.Flags().BoolVar(&record, "record", false, "save the usage in the history store, see the history command")
+	check.Flags().BoolVar(&skipUnauthorized, "skip-unauthorized", false, "skip the quotas whose usage requires a missing permission, instead of reporting them incomplete")
+
+	check.Flags().BoolVar(&usageMetrics, "usage-metrics", false, "also report the quotas tracked by a cloudwatch usage metric. Any servicequotas service code can then be checked")
+	err := viper.BindPFlag("usageMetrics", check.Flags().Lookup("usage-metrics"))
+	if err != nil {
+		fmt.Printf("error binding 'usageMetrics' flag. %v", err)
+	}
+	viper.SetDefault("usageMetrics", false)
 }
 
 var check = &cobra.Command{
-	Use:   "check",
+	Use:   "check <service>...",
 	Short: "Runc checks on selected services",
 	Long:  `Runc checks on selected services. Use all to run all checks`,
 	Args: func(cmd *cobra.Command, args []string) error {
-		var numArgs = len(args)
-		if numArgs != 1 {
-			return fmt.Errorf("check command requires to specify a single aws service or `all`. %d were provided", numArgs)
+		if len(args) == 0 {
+			return fmt.Errorf("check command requires to specify at least one aws service or `all`")
+		}
+		// with usage metrics, any servicequotas service code can be checked
+		for _, awsService := range args {
+			if !awslimitchecker.IsValidAwsService(awsService) && !viper.GetBool("usageMetrics") {
+				return fmt.Errorf("invalid aws service provided: %s", awsService)
+			}
 		}
-		if !awslimitchecker.IsValidAwsService(args[0]) {
-			return fmt.Errorf("invalid aws service provided: %s", args[0])
+		for _, awsService := range excludeServices {
+			if !awslimitchecker.IsValidAwsService(awsService) && !viper.GetBool("usageMetrics") {
+				return fmt.Errorf("invalid aws service to exclude provided: %s", awsService)
+			}
 		}
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
-		awsService := args[0]
 		awsProfile := viper.GetString("awsprofile")
 		overridesJson := viper.GetString("overridesJson")
 		region := viper.GetString("region")
 		console := viper.GetBool("console")
 		csvFlag := viper.GetBool("csv")
+		usageMetricsFlag := viper.GetBool("usageMetrics")
 
 		if awsProfile == "" {
 			fmt.Printf("Unable to retrieve awsprofile. Please provide a valid aws profile")
@@ -47,39 +78,54 @@ var check = &cobra.Command{
 			fmt.Printf("Unable to retrieve region. Please provide a valid region")
 		}
 
-		quotaOverrides := []services.AWSQuotaOverride{}
+		// overrides can be embedded in the configuration file, and completed
+		// by the ones of the overrides file
+		quotaOverrides, err := awslimitchecker.UnmarshalQuotaOverrides(viper.GetViper(), "overrides")
+		if err != nil {
+			fmt.Printf("Error reading overrides from configuration: %v\n", err)
+			return
+		}
 		if overridesJson != "" {
-			var payload map[string]map[string]float64
-			content, err := os.ReadFile(overridesJson)
+			fileOverrides, err := awslimitchecker.LoadQuotaOverrides(overridesJson)
 			if err != nil {
-				fmt.Printf("Error when opening file: %v", err)
-			}
-			err = json.Unmarshal(content, &payload)
-			if err == nil {
-				for svcName, svc := range payload {
-					for quotaName, quota := range svc {
-						quotaOverrides = append(quotaOverrides, services.AWSQuotaOverride{Service: svcName, QuotaName: quotaName, QuotaValue: quota})
-					}
-				}
-			} else {
-				fmt.Printf("Error reading override json file (%v): %v\n", overridesJson, err)
+				fmt.Printf("Error reading override file (%v): %v\n", overridesJson, err)
+				return
 			}
+			quotaOverrides = append(quotaOverrides, fileOverrides...)
+		}
+		quotaOverrides, err = awslimitchecker.ValidateQuotaOverrides(quotaOverrides, usageMetricsFlag)
+		if err != nil {
+			fmt.Println(err)
+			return
 		}
 
-		usage := awslimitchecker.GetUsage(awsService, awsProfile, region, quotaOverrides)
+		usage, err := awslimitchecker.GetUsageWithOptions(awsProfile, region, awslimitchecker.UsageOptions{
+			Services:         args,
+			ExcludeServices:  excludeServices,
+			Quotas:           quotasFilter,
+			ExcludeQuotas:    excludeQuotas,
+			OnlyOver:         onlyOver,
+			UsageMetrics:     usageMetricsFlag,
+			Overrides:        quotaOverrides,
+			SkipUnauthorized: skipUnauthorized,
+		})
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
 		sort.Slice(usage[:], func(i, j int) bool {
 			return usage[i].Service+usage[i].QuotaName < usage[j].Service+usage[j].QuotaName
 		})
 
 		if console {
-			fmt.Printf("AWS profile: %s | AWS region: %s | service: %s\n", awsProfile, region, awsService)
+			fmt.Printf("AWS profile: %s | AWS region: %s | service: %s\n", awsProfile, region, strings.Join(args, ","))
 			for _, u := range usage {
 				resourceIdString := ""
 				if u.ResourceId != "" {
 					resourceIdString = fmt.Sprintf("(%s)", u.ResourceId)
 				}
-				fmt.Printf("* [%s] %s %s %g/%g\n",
-					u.Service, u.QuotaName, resourceIdString, u.UsageValue, u.QuotaValue)
+				fmt.Printf("* [%s] %s %s %g/%g%s\n",
+					u.Service, u.QuotaName, resourceIdString, u.UsageValue, u.QuotaValue, quotaSourceNotes(u))
 			}
 		}
 
@@ -90,9 +136,16 @@ var check = &cobra.Command{
 			}
 			csvwriter := csv.NewWriter(csvfile)
 
-			_ = csvwriter.Write([]string{"region", "Service", "Name", "usage", "quota"})
+			_ = csvwriter.Write([]string{"region", "Service", "Name", "usage", "quota", "default", "applied", "service", "override", "source"})
 			for _, u := range usage {
-				row := []string{region, u.Service, u.QuotaName, strconv.FormatFloat(u.UsageValue, 'f', 2, 64), strconv.FormatFloat(u.QuotaValue, 'f', 2, 64)}
+				row := []string{region, u.Service, u.QuotaName,
+					strconv.FormatFloat(u.UsageValue, 'f', 2, 64),
+					strconv.FormatFloat(u.QuotaValue, 'f', 2, 64),
+					strconv.FormatFloat(u.DefaultValue, 'f', 2, 64),
+					strconv.FormatFloat(u.AppliedValue, 'f', 2, 64),
+					strconv.FormatFloat(u.ServiceValue, 'f', 2, 64),
+					strconv.FormatFloat(u.OverrideValue, 'f', 2, 64),
+					string(u.Source)}
 				_ = csvwriter.Write(row)
 			}
 
@@ -100,5 +153,64 @@ var check = &cobra.Command{
 
 			csvfile.Close()
 		}
+
+		if record {
+			recordUsage(region, usage)
+		}
+
+		printMissingPermissions(awslimitchecker.GetMissingPermissions())
 	},
 }
+
+// recordUsage saves the usage in the history store
+func recordUsage(region string, usage []services.AWSQuotaInfo) {
+	dir, err := getHistoryDir()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := awslimitchecker.RecordUsage(dir, region, usage); err != nil {
+		fmt.Printf("Error recording usage: %v\n", err)
+	}
+}
+
+// printMissingPermissions summarizes the iam actions denied during the run, so
+// that the role policy can be fixed
+func printMissingPermissions(missingPermissions []awslimitchecker.MissingPermission) {
+	if len(missingPermissions) == 0 {
+		return
+	}
+	fmt.Print("Missing IAM permissions:\n")
+	for _, p := range missingPermissions {
+		if len(p.Services) == 0 {
+			fmt.Printf("* %s\n", p.Action)
+		} else {
+			fmt.Printf("* %s (required by %s)\n", p.Action, strings.Join(p.Services, ", "))
+		}
+	}
+}
+
+// quotaSourceNotes describes where the quota value comes from when it is not
+// simply the default one: raised limits, overrides masking the applied value
+// and service limits disagreeing with servicequotas
+func quotaSourceNotes(u services.AWSQuotaInfo) (ret string) {
+	notes := []string{}
+	if u.AppliedValue != u.DefaultValue && u.DefaultValue != 0 {
+		notes = append(notes, fmt.Sprintf("raised from default %g", u.DefaultValue))
+	}
+	switch u.Source {
+	case services.QuotaSourceOverride:
+		notes = append(notes, fmt.Sprintf("override, applied %g", u.AppliedValue))
+	case services.QuotaSourceServiceAPI:
+		if u.AppliedValue != 0 && u.ServiceValue != u.AppliedValue {
+			notes = append(notes, fmt.Sprintf("service reports %g, servicequotas %g", u.ServiceValue, u.AppliedValue))
+		}
+	}
+	if u.Source != services.QuotaSourceServiceAPI && u.ServiceValue != 0 && u.ServiceValue != u.QuotaValue {
+		notes = append(notes, fmt.Sprintf("service reports %g", u.ServiceValue))
+	}
+	if len(notes) > 0 {
+		ret = fmt.Sprintf(" (%s)", strings.Join(notes, "; "))
+	}
+	return
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/sebasrp/awslimitchecker"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var historyOptions awslimitchecker.HistoryOptions
+
+func init() {
+	rootCmd.AddCommand(history)
+
+	history.Flags().StringArrayVar(&historyOptions.Quotas, "quota", []string{}, "only show the quotas whose name matches the regular expression. Can be repeated")
+	history.Flags().StringVar(&historyOptions.Account, "account", "", "only show the quotas of the given account")
+	history.Flags().IntVar(&historyOptions.Last, "last", 0, "only show the given number of most recent runs (default all)")
+}
+
+var history = &cobra.Command{
+	Use:   "history [service]...",
+	Short: "Shows the recorded usage over time",
+	Long:  `Shows the usage over time of the quotas recorded with check --record, and the change since the previous run. Shows every region unless --region is given`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		for _, awsService := range args {
+			if !awslimitchecker.IsValidAwsService(awsService) {
+				return fmt.Errorf("invalid aws service provided: %s", awsService)
+			}
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := getHistoryDir()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		historyOptions.Services = args
+		if cmd.Flags().Changed("region") {
+			historyOptions.Region = viper.GetString("region")
+		}
+
+		histories, err := awslimitchecker.LoadHistory(dir, historyOptions)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		if len(histories) == 0 {
+			fmt.Printf("No recorded usage found in %s\n", dir)
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, h := range histories {
+			resourceIdString := ""
+			if h.ResourceId != "" {
+				resourceIdString = fmt.Sprintf(" (%s)", h.ResourceId)
+			}
+			fmt.Fprintf(w, "* [%s] %s%s | account: %s | region: %s | delta: %+g\n",
+				h.Service, h.QuotaName, resourceIdString, h.Account, h.Region, h.UsageDelta())
+			for _, s := range h.Snapshots {
+				fmt.Fprintf(w, "  %s\t%s/%s\n",
+					s.Timestamp.Local().Format("2006-01-02 15:04:05"),
+					strconv.FormatFloat(s.Quota.UsageValue, 'f', -1, 64),
+					strconv.FormatFloat(s.Quota.QuotaValue, 'f', -1, 64))
+			}
+		}
+		w.Flush()
+	},
+}
@@ -5,21 +5,76 @@ import (
 
 	"github.com/sebasrp/awslimitchecker"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	iamFormat       string
+	iamServices     []string
+	iamVerify       bool
+	iamUsageMetrics bool
 )
 
 func init() {
 	rootCmd.AddCommand(iam)
+
+	iam.Flags().StringVar(&iamFormat, "format", "", "output a ready to apply iam policy: policy-json, terraform or cloudformation")
+	iam.Flags().StringSliceVar(&iamServices, "services", []string{}, "only include the permissions required by the given services (default all)")
+	iam.Flags().BoolVar(&iamVerify, "verify", false, "simulate the required permissions against the policies of the current principal and report the denied ones")
+	iam.Flags().BoolVar(&iamUsageMetrics, "usage-metrics", false, "include the permissions required by checks run with --usage-metrics (default from the usageMetrics configuration)")
 }
 
 var iam = &cobra.Command{
 	Use:   "iam",
 	Short: "Returns necessary iam policies to retrieve usage/limits",
 	Long:  `Returns necessary iam policies to retrieve usage/limits`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		for _, awsService := range iamServices {
+			if !awslimitchecker.IsValidAwsService(awsService) {
+				return fmt.Errorf("invalid aws service provided: %s", awsService)
+			}
+		}
+		return nil
+	},
 	Run: func(cmd *cobra.Command, args []string) {
-		iamPolicies := awslimitchecker.GetIamPolicies()
+		// the usageMetrics configuration is shared with the check command
+		usageMetrics := iamUsageMetrics || viper.GetBool("usageMetrics")
+		if iamVerify {
+			verifyIamPermissions(usageMetrics)
+			return
+		}
+		if iamFormat != "" {
+			document, err := awslimitchecker.GetIamPolicyDocument(iamServices, usageMetrics, iamFormat)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			fmt.Print(document)
+			return
+		}
+
+		iamPolicies := awslimitchecker.GetRequiredIamActions(iamServices, usageMetrics)
 		fmt.Print("Required IAM permissions to retrieve usage/limits:\n")
 		for _, p := range iamPolicies {
 			fmt.Printf("* %s\n", p)
 		}
 	},
 }
+
+// verifyIamPermissions reports the required permissions the current principal
+// is denied, before running checks
+func verifyIamPermissions(usageMetrics bool) {
+	denied, err := awslimitchecker.VerifyIamPermissions(iamServices, usageMetrics, viper.GetString("awsprofile"), viper.GetString("region"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if len(denied) == 0 {
+		fmt.Print("All required IAM permissions are allowed\n")
+		return
+	}
+	fmt.Print("Denied IAM permissions:\n")
+	for _, d := range denied {
+		fmt.Printf("* %s (%s)\n", d.Action, d.Decision)
+	}
+}
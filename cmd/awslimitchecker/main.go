@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -14,6 +15,7 @@ var (
 	region        string
 	awsprofile    string
 	overridesJson string
+	historyDir    string
 	console       bool
 	csvFlag       bool
 	verbose       bool
@@ -47,7 +49,8 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default $HOME/.awslimitchecker.yaml)")
 	rootCmd.PersistentFlags().StringVar(&awsprofile, "awsprofile", "", "aws profile to use (default `default`)")
 	rootCmd.PersistentFlags().StringVar(&region, "region", "", "region to evaluate (default `us-east-1`)")
-	rootCmd.PersistentFlags().StringVar(&overridesJson, "quota-override-json", "", "json defining the quota overrides")
+	rootCmd.PersistentFlags().StringVar(&overridesJson, "quota-override-json", "", "yaml or json file defining the quota overrides")
+	rootCmd.PersistentFlags().StringVar(&historyDir, "history-dir", "", "directory of the usage history store (default $HOME/.awslimitchecker-history)")
 	rootCmd.PersistentFlags().BoolVar(&console, "console", false, "output results to console")
 	rootCmd.PersistentFlags().BoolVar(&csvFlag, "csv", false, "output results to a csv file")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "enables verbose output")
@@ -64,6 +67,10 @@ func init() {
 	if err != nil {
 		fmt.Printf("error binding 'overridesJson' flag. %v", err)
 	}
+	err = viper.BindPFlag("historyDir", rootCmd.PersistentFlags().Lookup("history-dir"))
+	if err != nil {
+		fmt.Printf("error binding 'historyDir' flag. %v", err)
+	}
 	err = viper.BindPFlag("console", rootCmd.PersistentFlags().Lookup("console"))
 	if err != nil {
 		fmt.Printf("error binding 'console' flag. %v", err)
@@ -112,3 +119,15 @@ func initConfig() {
 		}
 	}
 }
+
+// getHistoryDir returns the directory of the usage history store
+func getHistoryDir() (string, error) {
+	if dir := viper.GetString("historyDir"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find the home directory, %v", err)
+	}
+	return filepath.Join(home, ".awslimitchecker-history"), nil
+}
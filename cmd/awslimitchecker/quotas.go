@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/sebasrp/awslimitchecker"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var quotasOptions awslimitchecker.QuotasOptions
+
+func init() {
+	rootCmd.AddCommand(quotas)
+
+	quotas.Flags().BoolVar(&quotasOptions.Defaults, "defaults", false, "list the aws default values instead of the applied ones")
+	quotas.Flags().BoolVar(&quotasOptions.AdjustableOnly, "adjustable-only", false, "only list the quotas that can be increased")
+	quotas.Flags().StringVar(&quotasOptions.Search, "search", "", "only list the quotas whose name or code contains the given text")
+}
+
+var quotas = &cobra.Command{
+	Use:   "quotas <service>",
+	Short: "Lists the quotas of a service",
+	Long:  `Lists the quotas servicequotas knows for a service, and whether their usage can be checked. Accepts a supported service or any servicequotas service code`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		var numArgs = len(args)
+		if numArgs != 1 {
+			return fmt.Errorf("quotas command requires to specify a single aws service. %d were provided", numArgs)
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		awsProfile := viper.GetString("awsprofile")
+		region := viper.GetString("region")
+
+		descriptions := awslimitchecker.GetQuotas(args[0], awsProfile, region, quotasOptions)
+		if len(descriptions) == 0 {
+			fmt.Printf("No quotas found for %s\n", args[0])
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		// with --defaults, the listed values are the default ones
+		if quotasOptions.Defaults {
+			fmt.Fprintln(w, "NAME\tCODE\tUNIT\tDEFAULT\tADJUSTABLE\tUSAGE")
+		} else {
+			fmt.Fprintln(w, "NAME\tCODE\tUNIT\tAPPLIED\tDEFAULT\tADJUSTABLE\tUSAGE")
+		}
+		for _, d := range descriptions {
+			values := strconv.FormatFloat(d.Quota.QuotaValue, 'f', -1, 64)
+			if !quotasOptions.Defaults {
+				defaultValue := "-"
+				if d.HasDefault {
+					defaultValue = strconv.FormatFloat(d.DefaultValue, 'f', -1, 64)
+				}
+				values += "\t" + defaultValue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%t\n",
+				d.Quota.QuotaName,
+				d.Quota.Quotacode,
+				d.Quota.Unit,
+				values,
+				d.Quota.Adjustable,
+				d.UsageSupported)
+		}
+		w.Flush()
+	},
+}
@@ -0,0 +1,229 @@
+package awslimitchecker
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/sebasrp/awslimitchecker/internal/services"
+)
+
+// historyFileExtension is the extension of the json-lines files of the history
+// store, one file per account and region
+const historyFileExtension = ".jsonl"
+
+// QuotaSnapshot is the usage of a quota recorded by a run
+type QuotaSnapshot struct {
+	Timestamp time.Time             `json:"timestamp"`
+	Account   string                `json:"account"`
+	Region    string                `json:"region"`
+	Quota     services.AWSQuotaInfo `json:"quota"`
+}
+
+// QuotaHistory is the usage over time of a quota of an account and region
+type QuotaHistory struct {
+	Account    string
+	Region     string
+	Service    string
+	QuotaName  string
+	ResourceId string
+	Snapshots  []QuotaSnapshot // oldest first
+}
+
+// UsageDelta returns the usage change since the previous run, 0 if the quota
+// was recorded once
+func (h QuotaHistory) UsageDelta() float64 {
+	if len(h.Snapshots) < 2 {
+		return 0
+	}
+	return h.Snapshots[len(h.Snapshots)-1].Quota.UsageValue - h.Snapshots[len(h.Snapshots)-2].Quota.UsageValue
+}
+
+// HistoryOptions selects the quotas of the history to report
+type HistoryOptions struct {
+	Services []string // supported services to report, all of them if empty or `all`
+	Quotas   []string // regular expressions, only the quotas whose name matches one of them are reported
+	Account  string   // only report the quotas of this account
+	Region   string   // only report the quotas of this region
+	Last     int      // only keep the given number of most recent runs per quota, all of them if 0
+}
+
+// RecordUsage saves the given usage, retrieved for the given region, in the
+// history store of the given directory
+func RecordUsage(dir string, region string, usage []services.AWSQuotaInfo) error {
+	account, err := services.GetAccountId()
+	if err != nil {
+		return err
+	}
+	snapshots := []QuotaSnapshot{}
+	timestamp := time.Now().UTC()
+	for _, quota := range usage {
+		snapshots = append(snapshots, QuotaSnapshot{Timestamp: timestamp, Account: account, Region: region, Quota: quota})
+	}
+	return recordSnapshots(dir, snapshots)
+}
+
+// recordSnapshots appends the snapshots to the file of their account and region
+func recordSnapshots(dir string, snapshots []QuotaSnapshot) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("unable to create history directory %s, %v", dir, err)
+	}
+	files := map[string]*os.File{}
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	for _, snapshot := range snapshots {
+		name := filepath.Join(dir, snapshot.Account+"-"+snapshot.Region+historyFileExtension)
+		f, ok := files[name]
+		if !ok {
+			var err error
+			f, err = os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+			if err != nil {
+				return fmt.Errorf("unable to open history file %s, %v", name, err)
+			}
+			files[name] = f
+		}
+		line, err := json.Marshal(snapshot)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("unable to write history file %s, %v", name, err)
+		}
+	}
+	return nil
+}
+
+// LoadHistory reads the history store of the given directory, and returns the
+// usage over time of the quotas selected by the options
+func LoadHistory(dir string, options HistoryOptions) (ret []QuotaHistory, err error) {
+	quotaFilter, err := newQuotaFilter(options.Quotas, nil)
+	if err != nil {
+		return
+	}
+	serviceFilter, err := newHistoryServiceFilter(options.Services)
+	if err != nil {
+		return
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+historyFileExtension))
+	if err != nil {
+		return
+	}
+	histories := map[string]*QuotaHistory{}
+	for _, file := range files {
+		snapshots, err := readSnapshots(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, snapshot := range snapshots {
+			if (options.Account != "" && snapshot.Account != options.Account) ||
+				(options.Region != "" && snapshot.Region != options.Region) ||
+				!serviceFilter(snapshot.Quota) ||
+				!quotaFilter(snapshot.Quota.QuotaName) {
+				continue
+			}
+			key := fmt.Sprintf("%s|%s|%s|%s|%s", snapshot.Account, snapshot.Region,
+				snapshot.Quota.Service, snapshot.Quota.QuotaName, snapshot.Quota.ResourceId)
+			history, ok := histories[key]
+			if !ok {
+				history = &QuotaHistory{
+					Account:    snapshot.Account,
+					Region:     snapshot.Region,
+					Service:    snapshot.Quota.Service,
+					QuotaName:  snapshot.Quota.QuotaName,
+					ResourceId: snapshot.Quota.ResourceId,
+				}
+				histories[key] = history
+			}
+			history.Snapshots = append(history.Snapshots, snapshot)
+		}
+	}
+
+	for _, history := range histories {
+		sort.SliceStable(history.Snapshots, func(i, j int) bool {
+			return history.Snapshots[i].Timestamp.Before(history.Snapshots[j].Timestamp)
+		})
+		if options.Last > 0 && len(history.Snapshots) > options.Last {
+			history.Snapshots = history.Snapshots[len(history.Snapshots)-options.Last:]
+		}
+		ret = append(ret, *history)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		a, b := ret[i], ret[j]
+		if a.Account != b.Account {
+			return a.Account < b.Account
+		}
+		if a.Region != b.Region {
+			return a.Region < b.Region
+		}
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		if a.QuotaName != b.QuotaName {
+			return a.QuotaName < b.QuotaName
+		}
+		return a.ResourceId < b.ResourceId
+	})
+	return
+}
+
+// newHistoryServiceFilter returns a func telling whether a recorded quota is one
+// of the given supported services. Snapshots hold service codes, shared by
+// several supported services (e.g. ebs and vpn for ec2), so quotas are matched
+// on the service code and the quotas the service checks
+func newHistoryServiceFilter(awsServices []string) (func(services.AWSQuotaInfo) bool, error) {
+	selected := map[string]map[string]bool{}
+	for _, awsService := range awsServices {
+		if awsService == "all" {
+			return func(services.AWSQuotaInfo) bool { return true }, nil
+		}
+		checker, ok := SupportedAwsServices[awsService]
+		if !ok {
+			return nil, fmt.Errorf("invalid aws service provided: %s", awsService)
+		}
+		if svcChecker, ok := checker().(*services.ServiceChecker); ok {
+			if selected[svcChecker.ServiceCode] == nil {
+				selected[svcChecker.ServiceCode] = map[string]bool{}
+			}
+			for quotaName := range svcChecker.SupportedQuotas {
+				selected[svcChecker.ServiceCode][quotaName] = true
+			}
+		}
+	}
+	return func(quota services.AWSQuotaInfo) bool {
+		return len(awsServices) == 0 || selected[quota.Service][quota.QuotaName]
+	}, nil
+}
+
+// readSnapshots reads the snapshots of a json-lines history file
+func readSnapshots(file string) (ret []QuotaSnapshot, err error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open history file %s, %v", file, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		snapshot := QuotaSnapshot{}
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			return nil, fmt.Errorf("invalid history file %s, line %d: %v", file, line, err)
+		}
+		ret = append(ret, snapshot)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read history file %s, %v", file, err)
+	}
+	return
+}
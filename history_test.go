@@ -0,0 +1,151 @@
+package awslimitchecker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sebasrp/awslimitchecker/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setHistoryTestServices() {
+	SupportedAwsServices = map[string]func() services.Svcquota{
+		"elb":            services.NewElbChecker,
+		"eks":            services.NewEksChecker,
+		"kinesis":        services.NewKinesisChecker,
+		"transitgateway": services.NewTransitGatewayChecker,
+		"vpn":            services.NewVpnChecker,
+	}
+}
+
+func newTestSnapshot(timestamp time.Time, account string, region string, quotaName string, usage float64) QuotaSnapshot {
+	return QuotaSnapshot{
+		Timestamp: timestamp,
+		Account:   account,
+		Region:    region,
+		Quota:     services.AWSQuotaInfo{Service: "kinesis", QuotaName: quotaName, UsageValue: usage, QuotaValue: 100},
+	}
+}
+
+func TestRecordSnapshotsAndLoadHistory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "history")
+	first := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+
+	require.Nil(t, recordSnapshots(dir, []QuotaSnapshot{
+		newTestSnapshot(first, "123456789012", "eu-west-1", "Shards per Region", 10),
+		newTestSnapshot(first, "123456789012", "us-east-1", "Shards per Region", 5),
+	}))
+	require.Nil(t, recordSnapshots(dir, []QuotaSnapshot{
+		newTestSnapshot(second, "123456789012", "eu-west-1", "Shards per Region", 15),
+		newTestSnapshot(second, "123456789012", "eu-west-1", "On-demand Data Streams per account", 2),
+	}))
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	assert.Len(t, files, 2)
+
+	actual, err := LoadHistory(dir, HistoryOptions{})
+	require.Nil(t, err)
+	require.Len(t, actual, 3)
+
+	assert.Equal(t, "eu-west-1", actual[0].Region)
+	assert.Equal(t, "On-demand Data Streams per account", actual[0].QuotaName)
+	assert.Equal(t, float64(0), actual[0].UsageDelta())
+
+	assert.Equal(t, "eu-west-1", actual[1].Region)
+	assert.Equal(t, "Shards per Region", actual[1].QuotaName)
+	require.Len(t, actual[1].Snapshots, 2)
+	assert.True(t, actual[1].Snapshots[0].Timestamp.Equal(first))
+	assert.Equal(t, float64(100), actual[1].Snapshots[1].Quota.QuotaValue)
+	assert.Equal(t, float64(5), actual[1].UsageDelta())
+
+	assert.Equal(t, "us-east-1", actual[2].Region)
+}
+
+func TestLoadHistoryOptions(t *testing.T) {
+	setHistoryTestServices()
+	dir := t.TempDir()
+	first := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := []QuotaSnapshot{}
+	for i := 0; i < 5; i++ {
+		snapshots = append(snapshots,
+			newTestSnapshot(first.Add(time.Duration(i)*time.Hour), "123456789012", "eu-west-1", "Shards per Region", float64(i)),
+			newTestSnapshot(first.Add(time.Duration(i)*time.Hour), "210987654321", "eu-west-1", "Shards per Region", float64(i)))
+	}
+	require.Nil(t, recordSnapshots(dir, snapshots))
+
+	actual, err := LoadHistory(dir, HistoryOptions{Account: "123456789012", Last: 2})
+	require.Nil(t, err)
+	require.Len(t, actual, 1)
+	assert.Len(t, actual[0].Snapshots, 2)
+	assert.Equal(t, float64(4), actual[0].Snapshots[1].Quota.UsageValue)
+	assert.Equal(t, float64(1), actual[0].UsageDelta())
+
+	actual, err = LoadHistory(dir, HistoryOptions{Services: []string{"eks"}})
+	require.Nil(t, err)
+	assert.Empty(t, actual)
+
+	actual, err = LoadHistory(dir, HistoryOptions{Services: []string{"kinesis"}})
+	require.Nil(t, err)
+	assert.Len(t, actual, 2)
+
+	actual, err = LoadHistory(dir, HistoryOptions{Quotas: []string{"(?i)streams"}})
+	require.Nil(t, err)
+	assert.Empty(t, actual)
+
+	actual, err = LoadHistory(dir, HistoryOptions{Region: "eu-west-1", Quotas: []string{"Shards"}})
+	require.Nil(t, err)
+	assert.Len(t, actual, 2)
+}
+
+func TestLoadHistoryErrors(t *testing.T) {
+	_, err := LoadHistory(t.TempDir(), HistoryOptions{Quotas: []string{"("}})
+	assert.Error(t, err)
+
+	dir := t.TempDir()
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "123456789012-eu-west-1.jsonl"), []byte("{invalid\n"), 0600))
+	_, err = LoadHistory(dir, HistoryOptions{})
+	assert.Error(t, err)
+}
+
+func TestLoadHistoryServiceCodes(t *testing.T) {
+	setHistoryTestServices()
+	dir := t.TempDir()
+	timestamp := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Nil(t, recordSnapshots(dir, []QuotaSnapshot{
+		{Timestamp: timestamp, Account: "123456789012", Region: "eu-west-1",
+			Quota: services.AWSQuotaInfo{Service: "elasticloadbalancing", QuotaName: "Classic Load Balancers per Region"}},
+		{Timestamp: timestamp, Account: "123456789012", Region: "eu-west-1",
+			Quota: services.AWSQuotaInfo{Service: "ec2", QuotaName: "Attachments per transit gateway"}},
+		{Timestamp: timestamp, Account: "123456789012", Region: "eu-west-1",
+			Quota: services.AWSQuotaInfo{Service: "ec2", QuotaName: "Virtual private gateways per Region"}},
+	}))
+
+	// services are selected by their supported name, recorded by service code
+	actual, err := LoadHistory(dir, HistoryOptions{Services: []string{"elb"}})
+	require.Nil(t, err)
+	require.Len(t, actual, 1)
+	assert.Equal(t, "elasticloadbalancing", actual[0].Service)
+
+	// services sharing a service code only select their own quotas
+	actual, err = LoadHistory(dir, HistoryOptions{Services: []string{"transitgateway"}})
+	require.Nil(t, err)
+	require.Len(t, actual, 1)
+	assert.Equal(t, "Attachments per transit gateway", actual[0].QuotaName)
+
+	actual, err = LoadHistory(dir, HistoryOptions{Services: []string{"all"}})
+	require.Nil(t, err)
+	assert.Len(t, actual, 3)
+
+	_, err = LoadHistory(dir, HistoryOptions{Services: []string{"elasticloadbalancing"}})
+	assert.Error(t, err)
+}
+
+func TestLoadHistoryEmpty(t *testing.T) {
+	actual, err := LoadHistory(filepath.Join(t.TempDir(), "missing"), HistoryOptions{})
+	assert.Nil(t, err)
+	assert.Empty(t, actual)
+}
@@ -0,0 +1,140 @@
+package awslimitchecker
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/sebasrp/awslimitchecker/internal/services"
+)
+
+// formats of the iam policies GetIamPolicyDocument generates
+const (
+	IamPolicyFormatJson           = "policy-json"
+	IamPolicyFormatTerraform      = "terraform"
+	IamPolicyFormatCloudformation = "cloudformation"
+)
+
+const iamPolicySid = "AwsLimitChecker"
+
+type iamPolicyDocument struct {
+	Version   string
+	Statement []iamPolicyStatement
+}
+
+type iamPolicyStatement struct {
+	Sid      string
+	Effect   string
+	Action   []string
+	Resource string
+}
+
+var terraformIamPolicyTemplate = template.Must(template.New("terraform").Parse(`data "aws_iam_policy_document" "awslimitchecker" {
+  statement {
+    sid    = "{{.Sid}}"
+    effect = "Allow"
+    actions = [
+{{- range .Actions}}
+      "{{.}}",
+{{- end}}
+    ]
+    resources = ["*"]
+  }
+}
+
+resource "aws_iam_policy" "awslimitchecker" {
+  name        = "awslimitchecker"
+  description = "Permissions required by awslimitchecker"
+  policy      = data.aws_iam_policy_document.awslimitchecker.json
+}
+`))
+
+var cloudformationIamPolicyTemplate = template.Must(template.New("cloudformation").Parse(`AWSTemplateFormatVersion: "2010-09-09"
+Description: Permissions required by awslimitchecker
+Resources:
+  AwsLimitCheckerPolicy:
+    Type: AWS::IAM::ManagedPolicy
+    Properties:
+      ManagedPolicyName: awslimitchecker
+      PolicyDocument:
+        Version: "2012-10-17"
+        Statement:
+          - Sid: {{.Sid}}
+            Effect: Allow
+            Action:
+{{- range .Actions}}
+              - {{.}}
+{{- end}}
+            Resource: "*"
+`))
+
+// GetRequiredIamActions returns the iam actions required to check the given
+// services, or all the supported ones if none is given, deduplicated and sorted.
+// With usageMetrics, the actions of the usage metric checks are included
+func GetRequiredIamActions(awsServices []string, usageMetrics bool) (ret []string) {
+	if len(awsServices) == 0 {
+		awsServices = []string{"all"}
+	}
+	checkers := getCheckers(awsServices, nil)
+	if usageMetrics {
+		checkers = append(checkers, getUsageMetricCheckers(awsServices, nil, checkers, nil)...)
+	}
+	actions := map[string]bool{}
+	for _, checker := range checkers {
+		for _, action := range checker.GetRequiredPermissions() {
+			actions[action] = true
+		}
+	}
+	for action := range actions {
+		ret = append(ret, action)
+	}
+	sort.Strings(ret)
+	return
+}
+
+// GetIamPolicyDocument returns a least privilege iam policy allowing to check
+// the given services, or all the supported ones if none is given, in the given
+// format: an iam policy document, terraform or cloudformation
+func GetIamPolicyDocument(awsServices []string, usageMetrics bool, format string) (string, error) {
+	actions := GetRequiredIamActions(awsServices, usageMetrics)
+	switch format {
+	case IamPolicyFormatJson:
+		document := iamPolicyDocument{
+			Version:   "2012-10-17",
+			Statement: []iamPolicyStatement{{Sid: iamPolicySid, Effect: "Allow", Action: actions, Resource: "*"}},
+		}
+		content, err := json.MarshalIndent(document, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(content) + "\n", nil
+	case IamPolicyFormatTerraform, IamPolicyFormatCloudformation:
+		tmpl := terraformIamPolicyTemplate
+		if format == IamPolicyFormatCloudformation {
+			tmpl = cloudformationIamPolicyTemplate
+		}
+		var content strings.Builder
+		err := tmpl.Execute(&content, struct {
+			Sid     string
+			Actions []string
+		}{iamPolicySid, actions})
+		return content.String(), err
+	default:
+		return "", fmt.Errorf("unknown iam policy format %q, valid formats are %s, %s and %s",
+			format, IamPolicyFormatJson, IamPolicyFormatTerraform, IamPolicyFormatCloudformation)
+	}
+}
+
+// VerifyIamPermissions simulates the iam actions required by the given services
+// (all of them if none is given) against the policies of the principal of the
+// given profile, and returns the denied ones. It requires the
+// iam:SimulatePrincipalPolicy permission, and iam:GetRole for assumed roles
+func VerifyIamPermissions(awsServices []string, usageMetrics bool, awsprofile string, region string) (ret []services.DeniedIamAction, err error) {
+	_, err = services.InitializeConfig(awsprofile, region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create AWS session, %v", err)
+	}
+	return services.SimulateIamActions(GetRequiredIamActions(awsServices, usageMetrics))
+}
@@ -0,0 +1,111 @@
+package awslimitchecker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sebasrp/awslimitchecker"
+	"github.com/sebasrp/awslimitchecker/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setIamTestServices() {
+	awslimitchecker.SupportedAwsServices = map[string]func() services.Svcquota{
+		"transitgateway": services.NewTransitGatewayChecker,
+		"vpn":            services.NewVpnChecker,
+		"kms":            services.NewKmsChecker,
+	}
+}
+
+func TestGetRequiredIamActions(t *testing.T) {
+	setIamTestServices()
+
+	actual := awslimitchecker.GetRequiredIamActions([]string{"kms"}, false)
+	assert.Equal(t, []string{
+		"kms:DescribeKey",
+		"kms:ListAliases",
+		"kms:ListGrants",
+		"kms:ListKeys",
+		"servicequotas:ListAWSDefaultServiceQuotas",
+		"servicequotas:ListServiceQuotas",
+	}, actual)
+
+	// servicequotas actions are listed once, whatever the number of services
+	all := awslimitchecker.GetRequiredIamActions(nil, false)
+	count := 0
+	for _, action := range all {
+		if action == "servicequotas:ListServiceQuotas" {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+	assert.IsIncreasing(t, all)
+	assert.Equal(t, all, awslimitchecker.GetRequiredIamActions([]string{"all"}, false))
+}
+
+func TestGetRequiredIamActionsUsageMetrics(t *testing.T) {
+	setIamTestServices()
+
+	assert.NotContains(t, awslimitchecker.GetRequiredIamActions([]string{"kms"}, false), "cloudwatch:GetMetricData")
+	assert.Contains(t, awslimitchecker.GetRequiredIamActions([]string{"kms"}, true), "cloudwatch:GetMetricData")
+}
+
+func TestGetIamPolicyDocumentJson(t *testing.T) {
+	setIamTestServices()
+
+	actual, err := awslimitchecker.GetIamPolicyDocument([]string{"kms"}, false, awslimitchecker.IamPolicyFormatJson)
+	require.Nil(t, err)
+
+	var document struct {
+		Version   string
+		Statement []struct {
+			Effect   string
+			Action   []string
+			Resource string
+		}
+	}
+	require.Nil(t, json.Unmarshal([]byte(actual), &document))
+	assert.Equal(t, "2012-10-17", document.Version)
+	require.Len(t, document.Statement, 1)
+	assert.Equal(t, "Allow", document.Statement[0].Effect)
+	assert.Equal(t, "*", document.Statement[0].Resource)
+	assert.Equal(t, awslimitchecker.GetRequiredIamActions([]string{"kms"}, false), document.Statement[0].Action)
+}
+
+func TestGetIamPolicyDocumentTerraform(t *testing.T) {
+	setIamTestServices()
+
+	actual, err := awslimitchecker.GetIamPolicyDocument([]string{"kms"}, false, awslimitchecker.IamPolicyFormatTerraform)
+	require.Nil(t, err)
+	assert.Contains(t, actual, `data "aws_iam_policy_document" "awslimitchecker" {`)
+	assert.Contains(t, actual, "      \"kms:ListKeys\",\n")
+	assert.Contains(t, actual, `resource "aws_iam_policy" "awslimitchecker" {`)
+}
+
+func TestGetIamPolicyDocumentCloudformation(t *testing.T) {
+	setIamTestServices()
+
+	actual, err := awslimitchecker.GetIamPolicyDocument([]string{"kms"}, false, awslimitchecker.IamPolicyFormatCloudformation)
+	require.Nil(t, err)
+	assert.Contains(t, actual, "Type: AWS::IAM::ManagedPolicy")
+	assert.Contains(t, actual, "              - kms:ListKeys\n")
+	assert.Contains(t, actual, "              - servicequotas:ListServiceQuotas\n")
+}
+
+func TestGetIamPolicyDocumentUnknownFormat(t *testing.T) {
+	_, err := awslimitchecker.GetIamPolicyDocument(nil, false, "xml")
+	assert.Error(t, err)
+}
+
+func TestVerifyIamPermissionsErrorInit(t *testing.T) {
+	setIamTestServices()
+	services.InitializeConfig = func(awsprofile, region string) (*services.Config, error) {
+		return &services.Config{}, errors.New("test error")
+	}
+
+	actual, err := awslimitchecker.VerifyIamPermissions([]string{"kms"}, false, "testProfile", "testRegion")
+	assert.Error(t, err)
+	assert.Empty(t, actual)
+}
@@ -22,7 +22,7 @@ func NewAcmChecker() Svcquota {
 
 func (c ServiceChecker) getAcmCertificatesUsage() (ret []AWSQuotaInfo) {
 	ret = []AWSQuotaInfo{}
-	quotaInfo := c.GetAllAppliedQuotas()["ACM certificates"]
+	quotaInfo := c.getAppliedQuotaOrDefault("ACM certificates", quotaDefault{})
 
 	certificates := []*acm.CertificateSummary{}
 	err := conf.Acm.ListCertificatesPages(&acm.ListCertificatesInput{}, func(p *acm.ListCertificatesOutput, lastPage bool) bool {
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+)
+
+type ApigatewayClientInterface interface {
+	GetRestApisPages(input *apigateway.GetRestApisInput, fn func(*apigateway.GetRestApisOutput, bool) bool) error
+	GetDomainNamesPages(input *apigateway.GetDomainNamesInput, fn func(*apigateway.GetDomainNamesOutput, bool) bool) error
+	GetApiKeysPages(input *apigateway.GetApiKeysInput, fn func(*apigateway.GetApiKeysOutput, bool) bool) error
+	GetUsagePlansPages(input *apigateway.GetUsagePlansInput, fn func(*apigateway.GetUsagePlansOutput, bool) bool) error
+	GetVpcLinksPages(input *apigateway.GetVpcLinksInput, fn func(*apigateway.GetVpcLinksOutput, bool) bool) error
+	GetResourcesPages(input *apigateway.GetResourcesInput, fn func(*apigateway.GetResourcesOutput, bool) bool) error
+	GetStages(input *apigateway.GetStagesInput) (*apigateway.GetStagesOutput, error)
+	GetAuthorizers(input *apigateway.GetAuthorizersInput) (*apigateway.GetAuthorizersOutput, error)
+}
+
+type Apigatewayv2ClientInterface interface {
+	GetApis(input *apigatewayv2.GetApisInput) (*apigatewayv2.GetApisOutput, error)
+}
+
+var apigatewayDefaultQuotas = map[string]quotaDefault{
+	"REST APIs per Region":                       {Value: 600},
+	"HTTP and WebSocket APIs per Region":         {Value: 600},
+	"Custom domain names per account per Region": {Value: 120},
+	"API keys per account per Region":            {Value: 10000},
+	"Usage plans per account per Region":         {Value: 300},
+	"VPC links per account per Region":           {Value: 20},
+	"Resources per API":                          {Value: 300},
+	"Stages per API":                             {Value: 10},
+	"Authorizers per API":                        {Value: 10},
+}
+
+func NewApigatewayChecker() Svcquota {
+	serviceCode := "apigateway"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"REST APIs per Region":                       ServiceChecker.getApigatewayRestApisUsage,
+		"HTTP and WebSocket APIs per Region":         ServiceChecker.getApigatewayV2ApisUsage,
+		"Custom domain names per account per Region": ServiceChecker.getApigatewayDomainNamesUsage,
+		"API keys per account per Region":            ServiceChecker.getApigatewayApiKeysUsage,
+		"Usage plans per account per Region":         ServiceChecker.getApigatewayUsagePlansUsage,
+		"VPC links per account per Region":           ServiceChecker.getApigatewayVpcLinksUsage,
+		"Resources per API":                          ServiceChecker.getApigatewayResourcesPerApiUsage,
+		"Stages per API":                             ServiceChecker.getApigatewayStagesPerApiUsage,
+		"Authorizers per API":                        ServiceChecker.getApigatewayAuthorizersPerApiUsage,
+	}
+	// api gateway uses http verbs as iam actions, read-only access is GET
+	requiredPermissions := []string{"apigateway:GET"}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func getApigatewayRestApis() (ret []*apigateway.RestApi, err error) {
+	ret = []*apigateway.RestApi{}
+	err = conf.Apigateway.GetRestApisPages(&apigateway.GetRestApisInput{}, func(p *apigateway.GetRestApisOutput, lastPage bool) bool {
+		ret = append(ret, p.Items...)
+		return true // continue paging
+	})
+	return
+}
+
+func apigatewayRestApiResourceId(api *apigateway.RestApi) string {
+	return fmt.Sprintf("AWS::ApiGateway::RestApi::%s", aws.StringValue(api.Id))
+}
+
+func (c ServiceChecker) getApigatewayRestApisUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	apis, err := getApigatewayRestApis()
+	if err != nil {
+		fmt.Printf("failed to retrieve api gateway rest apis, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("REST APIs per Region", apigatewayDefaultQuotas["REST APIs per Region"])
+	quotaInfo.UsageValue = float64(len(apis))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getApigatewayV2ApisUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	apis := 0
+	input := &apigatewayv2.GetApisInput{}
+	for {
+		result, err := conf.Apigatewayv2.GetApis(input)
+		if err != nil {
+			fmt.Printf("failed to retrieve api gateway http and websocket apis, %v", err)
+			return
+		}
+		apis += len(result.Items)
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("HTTP and WebSocket APIs per Region", apigatewayDefaultQuotas["HTTP and WebSocket APIs per Region"])
+	quotaInfo.UsageValue = float64(apis)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getApigatewayDomainNamesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	domainNames := []*apigateway.DomainName{}
+	err := conf.Apigateway.GetDomainNamesPages(&apigateway.GetDomainNamesInput{}, func(p *apigateway.GetDomainNamesOutput, lastPage bool) bool {
+		domainNames = append(domainNames, p.Items...)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve api gateway domain names, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Custom domain names per account per Region", apigatewayDefaultQuotas["Custom domain names per account per Region"])
+	quotaInfo.UsageValue = float64(len(domainNames))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getApigatewayApiKeysUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	apiKeys := []*apigateway.ApiKey{}
+	err := conf.Apigateway.GetApiKeysPages(&apigateway.GetApiKeysInput{}, func(p *apigateway.GetApiKeysOutput, lastPage bool) bool {
+		apiKeys = append(apiKeys, p.Items...)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve api gateway api keys, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("API keys per account per Region", apigatewayDefaultQuotas["API keys per account per Region"])
+	quotaInfo.UsageValue = float64(len(apiKeys))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getApigatewayUsagePlansUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	usagePlans := []*apigateway.UsagePlan{}
+	err := conf.Apigateway.GetUsagePlansPages(&apigateway.GetUsagePlansInput{}, func(p *apigateway.GetUsagePlansOutput, lastPage bool) bool {
+		usagePlans = append(usagePlans, p.Items...)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve api gateway usage plans, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Usage plans per account per Region", apigatewayDefaultQuotas["Usage plans per account per Region"])
+	quotaInfo.UsageValue = float64(len(usagePlans))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getApigatewayVpcLinksUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	vpcLinks := []*apigateway.UpdateVpcLinkOutput{}
+	err := conf.Apigateway.GetVpcLinksPages(&apigateway.GetVpcLinksInput{}, func(p *apigateway.GetVpcLinksOutput, lastPage bool) bool {
+		vpcLinks = append(vpcLinks, p.Items...)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve api gateway vpc links, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("VPC links per account per Region", apigatewayDefaultQuotas["VPC links per account per Region"])
+	quotaInfo.UsageValue = float64(len(vpcLinks))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getApigatewayResourcesPerApiUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	apis, err := getApigatewayRestApis()
+	if err != nil {
+		fmt.Printf("failed to retrieve api gateway rest apis, %v", err)
+		return
+	}
+
+	for _, api := range apis {
+		quotaInfo := c.getAppliedQuotaOrDefault("Resources per API", apigatewayDefaultQuotas["Resources per API"])
+		resources := 0
+		errResources := conf.Apigateway.GetResourcesPages(&apigateway.GetResourcesInput{RestApiId: api.Id}, func(p *apigateway.GetResourcesOutput, lastPage bool) bool {
+			resources += len(p.Items)
+			return true // continue paging
+		})
+		if errResources != nil {
+			fmt.Printf("failed to retrieve resources for rest api %s, %v", aws.StringValue(api.Id), errResources)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(resources)
+		quotaInfo.ResourceId = apigatewayRestApiResourceId(api)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getApigatewayStagesPerApiUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	apis, err := getApigatewayRestApis()
+	if err != nil {
+		fmt.Printf("failed to retrieve api gateway rest apis, %v", err)
+		return
+	}
+
+	for _, api := range apis {
+		quotaInfo := c.getAppliedQuotaOrDefault("Stages per API", apigatewayDefaultQuotas["Stages per API"])
+		result, errStages := conf.Apigateway.GetStages(&apigateway.GetStagesInput{RestApiId: api.Id})
+		if errStages != nil {
+			fmt.Printf("failed to retrieve stages for rest api %s, %v", aws.StringValue(api.Id), errStages)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(len(result.Item))
+		quotaInfo.ResourceId = apigatewayRestApiResourceId(api)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getApigatewayAuthorizersPerApiUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	apis, err := getApigatewayRestApis()
+	if err != nil {
+		fmt.Printf("failed to retrieve api gateway rest apis, %v", err)
+		return
+	}
+
+	for _, api := range apis {
+		quotaInfo := c.getAppliedQuotaOrDefault("Authorizers per API", apigatewayDefaultQuotas["Authorizers per API"])
+		authorizers := 0
+		input := &apigateway.GetAuthorizersInput{RestApiId: api.Id}
+		var errAuthorizers error
+		for {
+			result, errGet := conf.Apigateway.GetAuthorizers(input)
+			if errGet != nil {
+				errAuthorizers = errGet
+				break
+			}
+			authorizers += len(result.Items)
+			if result.Position == nil {
+				break
+			}
+			input.Position = result.Position
+		}
+		if errAuthorizers != nil {
+			fmt.Printf("failed to retrieve authorizers for rest api %s, %v", aws.StringValue(api.Id), errAuthorizers)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(authorizers)
+		quotaInfo.ResourceId = apigatewayRestApiResourceId(api)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
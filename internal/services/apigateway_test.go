@@ -0,0 +1,195 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedApigatewayClient struct {
+	ApigatewayClientInterface
+	GetRestApisPagesResp     apigateway.GetRestApisOutput
+	GetRestApisPagesError    error
+	GetDomainNamesPagesResp  apigateway.GetDomainNamesOutput
+	GetDomainNamesPagesError error
+	GetApiKeysPagesResp      apigateway.GetApiKeysOutput
+	GetApiKeysPagesError     error
+	GetUsagePlansPagesResp   apigateway.GetUsagePlansOutput
+	GetUsagePlansPagesError  error
+	GetVpcLinksPagesResp     apigateway.GetVpcLinksOutput
+	GetVpcLinksPagesError    error
+	GetResourcesPagesResp    apigateway.GetResourcesOutput
+	GetResourcesPagesError   error
+	GetStagesResp            apigateway.GetStagesOutput
+	GetStagesError           error
+	GetAuthorizersResp       apigateway.GetAuthorizersOutput
+	GetAuthorizersError      error
+}
+
+func (m mockedApigatewayClient) GetRestApisPages(input *apigateway.GetRestApisInput, fn func(*apigateway.GetRestApisOutput, bool) bool) error {
+	return mockPages(m.GetRestApisPagesResp, m.GetRestApisPagesError, fn)
+}
+
+func (m mockedApigatewayClient) GetDomainNamesPages(input *apigateway.GetDomainNamesInput, fn func(*apigateway.GetDomainNamesOutput, bool) bool) error {
+	return mockPages(m.GetDomainNamesPagesResp, m.GetDomainNamesPagesError, fn)
+}
+
+func (m mockedApigatewayClient) GetApiKeysPages(input *apigateway.GetApiKeysInput, fn func(*apigateway.GetApiKeysOutput, bool) bool) error {
+	return mockPages(m.GetApiKeysPagesResp, m.GetApiKeysPagesError, fn)
+}
+
+func (m mockedApigatewayClient) GetUsagePlansPages(input *apigateway.GetUsagePlansInput, fn func(*apigateway.GetUsagePlansOutput, bool) bool) error {
+	return mockPages(m.GetUsagePlansPagesResp, m.GetUsagePlansPagesError, fn)
+}
+
+func (m mockedApigatewayClient) GetVpcLinksPages(input *apigateway.GetVpcLinksInput, fn func(*apigateway.GetVpcLinksOutput, bool) bool) error {
+	return mockPages(m.GetVpcLinksPagesResp, m.GetVpcLinksPagesError, fn)
+}
+
+func (m mockedApigatewayClient) GetResourcesPages(input *apigateway.GetResourcesInput, fn func(*apigateway.GetResourcesOutput, bool) bool) error {
+	return mockPages(m.GetResourcesPagesResp, m.GetResourcesPagesError, fn)
+}
+
+func (m mockedApigatewayClient) GetStages(input *apigateway.GetStagesInput) (*apigateway.GetStagesOutput, error) {
+	return &m.GetStagesResp, m.GetStagesError
+}
+
+func (m mockedApigatewayClient) GetAuthorizers(input *apigateway.GetAuthorizersInput) (*apigateway.GetAuthorizersOutput, error) {
+	return &m.GetAuthorizersResp, m.GetAuthorizersError
+}
+
+type mockedApigatewayv2Client struct {
+	Apigatewayv2ClientInterface
+	GetApisResp  apigatewayv2.GetApisOutput
+	GetApisError error
+}
+
+func (m mockedApigatewayv2Client) GetApis(input *apigatewayv2.GetApisInput) (*apigatewayv2.GetApisOutput, error) {
+	return &m.GetApisResp, m.GetApisError
+}
+
+var mockedRestApis = apigateway.GetRestApisOutput{
+	Items: []*apigateway.RestApi{{Id: aws.String("foo")}, {Id: aws.String("bar")}},
+}
+
+func TestNewApigatewayCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewApigatewayChecker())
+}
+
+func TestGetApigatewayRegionalUsage(t *testing.T) {
+	conf.Apigateway = mockedApigatewayClient{
+		GetRestApisPagesResp:    mockedRestApis,
+		GetDomainNamesPagesResp: apigateway.GetDomainNamesOutput{Items: []*apigateway.DomainName{{}}},
+		GetApiKeysPagesResp:     apigateway.GetApiKeysOutput{Items: []*apigateway.ApiKey{{}, {}, {}}},
+		GetUsagePlansPagesResp:  apigateway.GetUsagePlansOutput{Items: []*apigateway.UsagePlan{{}}},
+		GetVpcLinksPagesResp:    apigateway.GetVpcLinksOutput{Items: []*apigateway.UpdateVpcLinkOutput{{}, {}}},
+	}
+	svcChecker := newTestServiceChecker(NewApigatewayChecker, NewQuota("apigateway", "REST APIs per Region", float64(1000), false))
+
+	restApis := svcChecker.getApigatewayRestApisUsage()
+	assert.Len(t, restApis, 1)
+	assert.Equal(t, "apigateway", restApis[0].Service)
+	assert.Equal(t, float64(1000), restApis[0].QuotaValue)
+	assert.Equal(t, float64(2), restApis[0].UsageValue)
+
+	domainNames := svcChecker.getApigatewayDomainNamesUsage()
+	assert.Len(t, domainNames, 1)
+	assert.Equal(t, float64(120), domainNames[0].QuotaValue)
+	assert.Equal(t, float64(1), domainNames[0].UsageValue)
+
+	apiKeys := svcChecker.getApigatewayApiKeysUsage()
+	assert.Len(t, apiKeys, 1)
+	assert.Equal(t, float64(3), apiKeys[0].UsageValue)
+
+	usagePlans := svcChecker.getApigatewayUsagePlansUsage()
+	assert.Len(t, usagePlans, 1)
+	assert.Equal(t, float64(1), usagePlans[0].UsageValue)
+
+	vpcLinks := svcChecker.getApigatewayVpcLinksUsage()
+	assert.Len(t, vpcLinks, 1)
+	assert.Equal(t, float64(2), vpcLinks[0].UsageValue)
+}
+
+func TestGetApigatewayRegionalUsageError(t *testing.T) {
+	conf.Apigateway = mockedApigatewayClient{
+		GetRestApisPagesError:    errors.New("test error"),
+		GetDomainNamesPagesError: errors.New("test error"),
+		GetApiKeysPagesError:     errors.New("test error"),
+		GetUsagePlansPagesError:  errors.New("test error"),
+		GetVpcLinksPagesError:    errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewApigatewayChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getApigatewayRestApisUsage())
+	assert.Equal(t, expected, svcChecker.getApigatewayDomainNamesUsage())
+	assert.Equal(t, expected, svcChecker.getApigatewayApiKeysUsage())
+	assert.Equal(t, expected, svcChecker.getApigatewayUsagePlansUsage())
+	assert.Equal(t, expected, svcChecker.getApigatewayVpcLinksUsage())
+	assert.Equal(t, expected, svcChecker.getApigatewayResourcesPerApiUsage())
+	assert.Equal(t, expected, svcChecker.getApigatewayStagesPerApiUsage())
+	assert.Equal(t, expected, svcChecker.getApigatewayAuthorizersPerApiUsage())
+}
+
+func TestGetApigatewayV2ApisUsage(t *testing.T) {
+	conf.Apigatewayv2 = mockedApigatewayv2Client{
+		GetApisResp: apigatewayv2.GetApisOutput{Items: []*apigatewayv2.Api{{}, {}}},
+	}
+	svcChecker := newTestServiceChecker(NewApigatewayChecker)
+	actual := svcChecker.getApigatewayV2ApisUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, float64(600), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+}
+
+func TestGetApigatewayV2ApisUsageError(t *testing.T) {
+	conf.Apigatewayv2 = mockedApigatewayv2Client{GetApisError: errors.New("test error")}
+
+	svcChecker := newTestServiceChecker(NewApigatewayChecker)
+	assert.Equal(t, []AWSQuotaInfo{}, svcChecker.getApigatewayV2ApisUsage())
+}
+
+func TestGetApigatewayPerApiUsage(t *testing.T) {
+	conf.Apigateway = mockedApigatewayClient{
+		GetRestApisPagesResp:  mockedRestApis,
+		GetResourcesPagesResp: apigateway.GetResourcesOutput{Items: []*apigateway.Resource{{}, {}, {}}},
+		GetStagesResp:         apigateway.GetStagesOutput{Item: []*apigateway.Stage{{}, {}}},
+		GetAuthorizersResp:    apigateway.GetAuthorizersOutput{Items: []*apigateway.Authorizer{{}}},
+	}
+	svcChecker := newTestServiceChecker(NewApigatewayChecker)
+
+	resources := svcChecker.getApigatewayResourcesPerApiUsage()
+	assert.Len(t, resources, 2)
+	assert.Equal(t, "AWS::ApiGateway::RestApi::foo", resources[0].ResourceId)
+	assert.Equal(t, float64(300), resources[0].QuotaValue)
+	assert.Equal(t, float64(3), resources[0].UsageValue)
+
+	stages := svcChecker.getApigatewayStagesPerApiUsage()
+	assert.Len(t, stages, 2)
+	assert.Equal(t, "AWS::ApiGateway::RestApi::bar", stages[1].ResourceId)
+	assert.Equal(t, float64(2), stages[1].UsageValue)
+
+	authorizers := svcChecker.getApigatewayAuthorizersPerApiUsage()
+	assert.Len(t, authorizers, 2)
+	assert.Equal(t, float64(1), authorizers[0].UsageValue)
+}
+
+func TestGetApigatewayPerApiUsageError(t *testing.T) {
+	conf.Apigateway = mockedApigatewayClient{
+		GetRestApisPagesResp:   mockedRestApis,
+		GetResourcesPagesError: errors.New("test error"),
+		GetStagesError:         errors.New("test error"),
+		GetAuthorizersError:    errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewApigatewayChecker)
+
+	assert.Len(t, svcChecker.getApigatewayResourcesPerApiUsage(), 0)
+	assert.Len(t, svcChecker.getApigatewayStagesPerApiUsage(), 0)
+	assert.Len(t, svcChecker.getApigatewayAuthorizersPerApiUsage(), 0)
+}
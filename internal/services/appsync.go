@@ -0,0 +1,44 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/appsync"
+)
+
+type AppSyncClientInterface interface {
+	ListGraphqlApis(input *appsync.ListGraphqlApisInput) (*appsync.ListGraphqlApisOutput, error)
+}
+
+func NewAppSyncChecker() Svcquota {
+	serviceCode := "appsync"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"GraphQL APIs per Region": ServiceChecker.getAppSyncGraphqlApisUsage,
+	}
+	requiredPermissions := []string{"appsync:ListGraphqlApis"}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func (c ServiceChecker) getAppSyncGraphqlApisUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	apis := 0
+	input := &appsync.ListGraphqlApisInput{}
+	for {
+		result, err := conf.AppSync.ListGraphqlApis(input)
+		if err != nil {
+			fmt.Printf("failed to retrieve appsync graphql apis, %v", err)
+			return
+		}
+		apis += len(result.GraphqlApis)
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("GraphQL APIs per Region", quotaDefault{Value: 25})
+	quotaInfo.UsageValue = float64(apis)
+	ret = append(ret, quotaInfo)
+	return
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/appsync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedAppSyncClient struct {
+	AppSyncClientInterface
+	ListGraphqlApisResp  appsync.ListGraphqlApisOutput
+	ListGraphqlApisError error
+}
+
+func (m mockedAppSyncClient) ListGraphqlApis(input *appsync.ListGraphqlApisInput) (*appsync.ListGraphqlApisOutput, error) {
+	return &m.ListGraphqlApisResp, m.ListGraphqlApisError
+}
+
+func TestNewAppSyncCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewAppSyncChecker())
+}
+
+func TestGetAppSyncGraphqlApisUsage(t *testing.T) {
+	mockedOutput := appsync.ListGraphqlApisOutput{
+		GraphqlApis: []*appsync.GraphqlApi{{ApiId: aws.String("foo")}, {ApiId: aws.String("bar")}},
+	}
+	conf.AppSync = mockedAppSyncClient{ListGraphqlApisResp: mockedOutput}
+	svcChecker := newTestServiceChecker(NewAppSyncChecker, NewQuota("appsync", "GraphQL APIs per Region", float64(50), false))
+	actual := svcChecker.getAppSyncGraphqlApisUsage()
+
+	assert.Len(t, actual, 1)
+	quota := actual[0]
+	assert.Equal(t, "appsync", quota.Service)
+	assert.Equal(t, float64(50), quota.QuotaValue)
+	assert.Equal(t, float64(2), quota.UsageValue)
+}
+
+func TestGetAppSyncGraphqlApisUsageError(t *testing.T) {
+	conf.AppSync = mockedAppSyncClient{ListGraphqlApisError: errors.New("test error")}
+
+	svcChecker := newTestServiceChecker(NewAppSyncChecker)
+	actual := svcChecker.getAppSyncGraphqlApisUsage()
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, actual)
+}
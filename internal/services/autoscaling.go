@@ -3,20 +3,50 @@ package services
 import (
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 )
 
 type AutoscalingClientInterface interface {
 	DescribeAccountLimits(input *autoscaling.DescribeAccountLimitsInput) (*autoscaling.DescribeAccountLimitsOutput, error)
+	DescribeAutoScalingGroupsPages(input *autoscaling.DescribeAutoScalingGroupsInput, fn func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool) error
+	DescribePoliciesPages(input *autoscaling.DescribePoliciesInput, fn func(*autoscaling.DescribePoliciesOutput, bool) bool) error
+	DescribeScheduledActionsPages(input *autoscaling.DescribeScheduledActionsInput, fn func(*autoscaling.DescribeScheduledActionsOutput, bool) bool) error
+	DescribeLifecycleHooks(input *autoscaling.DescribeLifecycleHooksInput) (*autoscaling.DescribeLifecycleHooksOutput, error)
+	DescribeNotificationConfigurationsPages(input *autoscaling.DescribeNotificationConfigurationsInput, fn func(*autoscaling.DescribeNotificationConfigurationsOutput, bool) bool) error
+}
+
+var autoscalingDefaultQuotas = map[string]quotaDefault{
+	"Scaling policies per Auto Scaling group":       {Value: 50},
+	"Scheduled actions per Auto Scaling group":      {Value: 125},
+	"Lifecycle hooks per Auto Scaling group":        {Value: 50},
+	"Classic Load Balancers per Auto Scaling group": {Value: 50},
+	"Target groups per Auto Scaling group":          {Value: 50},
+	"SNS topics per Auto Scaling group":             {Value: 10},
+	"Auto Scaling groups per region":                {Value: 500, Code: "L-CDE20ADC"},
+	"Launch configurations per region":              {Value: 200, Code: "L-6B80B8FA"},
 }
 
 func NewAutoscalingChecker() Svcquota {
 	serviceCode := "autoscaling"
 	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
-		"Auto Scaling groups per region":   ServiceChecker.getAutoscalingGroupsUsage,
-		"Launch configurations per region": ServiceChecker.getAutoscalingLaunchConfigsUsage,
+		"Auto Scaling groups per region":                ServiceChecker.getAutoscalingGroupsUsage,
+		"Launch configurations per region":              ServiceChecker.getAutoscalingLaunchConfigsUsage,
+		"Scaling policies per Auto Scaling group":       ServiceChecker.getAutoscalingPoliciesPerGroupUsage,
+		"Scheduled actions per Auto Scaling group":      ServiceChecker.getAutoscalingScheduledActionsPerGroupUsage,
+		"Lifecycle hooks per Auto Scaling group":        ServiceChecker.getAutoscalingLifecycleHooksPerGroupUsage,
+		"Classic Load Balancers per Auto Scaling group": ServiceChecker.getAutoscalingLoadBalancersPerGroupUsage,
+		"Target groups per Auto Scaling group":          ServiceChecker.getAutoscalingTargetGroupsPerGroupUsage,
+		"SNS topics per Auto Scaling group":             ServiceChecker.getAutoscalingNotificationsPerGroupUsage,
+	}
+	requiredPermissions := []string{
+		"autoscaling:DescribeAccountLimits",
+		"autoscaling:DescribeAutoScalingGroups",
+		"autoscaling:DescribePolicies",
+		"autoscaling:DescribeScheduledActions",
+		"autoscaling:DescribeLifecycleHooks",
+		"autoscaling:DescribeNotificationConfigurations",
 	}
-	requiredPermissions := []string{"autoscaling:DescribeAccountLimits"}
 
 	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
 }
@@ -24,21 +54,15 @@ func NewAutoscalingChecker() Svcquota {
 func (c ServiceChecker) getAutoscalingGroupsUsage() (ret []AWSQuotaInfo) {
 	ret = []AWSQuotaInfo{}
 	result, err := conf.Autoscaling.DescribeAccountLimits(nil)
-	quotaInfo := AWSQuotaInfo{
-		Service:   c.ServiceCode,
-		QuotaName: "Auto Scaling groups per region",
-		Region:    c.Region,
-		Quotacode: "",
-		Unit:      "",
-		Global:    true,
-	}
 	if err != nil {
 		fmt.Printf("Unable to retrieve Autoscaling limits, %v", err)
 		return
 	}
+	quotaInfo := c.getAppliedQuotaOrDefault("Auto Scaling groups per region", autoscalingDefaultQuotas["Auto Scaling groups per region"])
 
-	quotaInfo.QuotaValue = float64(*result.MaxNumberOfAutoScalingGroups)
-	quotaInfo.UsageValue = float64(*result.NumberOfAutoScalingGroups)
+	// the account limits are the source of truth (overwrites servicequotas')
+	quotaInfo = withServiceValue(quotaInfo, float64(aws.Int64Value(result.MaxNumberOfAutoScalingGroups)))
+	quotaInfo.UsageValue = float64(aws.Int64Value(result.NumberOfAutoScalingGroups))
 
 	ret = append(ret, quotaInfo)
 	return
@@ -47,22 +71,156 @@ func (c ServiceChecker) getAutoscalingGroupsUsage() (ret []AWSQuotaInfo) {
 func (c ServiceChecker) getAutoscalingLaunchConfigsUsage() (ret []AWSQuotaInfo) {
 	ret = []AWSQuotaInfo{}
 	result, err := conf.Autoscaling.DescribeAccountLimits(nil)
-	quotaInfo := AWSQuotaInfo{
-		Service:   c.ServiceCode,
-		QuotaName: "Launch configurations per region",
-		Region:    c.Region,
-		Quotacode: "",
-		Unit:      "",
-		Global:    true,
-	}
 	if err != nil {
 		fmt.Printf("Unable to retrieve Autoscaling limits, %v", err)
 		return
 	}
+	quotaInfo := c.getAppliedQuotaOrDefault("Launch configurations per region", autoscalingDefaultQuotas["Launch configurations per region"])
 
-	quotaInfo.QuotaValue = float64(*result.MaxNumberOfLaunchConfigurations)
-	quotaInfo.UsageValue = float64(*result.NumberOfLaunchConfigurations)
+	// the account limits are the source of truth (overwrites servicequotas')
+	quotaInfo = withServiceValue(quotaInfo, float64(aws.Int64Value(result.MaxNumberOfLaunchConfigurations)))
+	quotaInfo.UsageValue = float64(aws.Int64Value(result.NumberOfLaunchConfigurations))
 
 	ret = append(ret, quotaInfo)
 	return
 }
+
+var autoscalingGroups []*autoscaling.Group = []*autoscaling.Group{}
+
+func getAutoscalingGroups() (ret []*autoscaling.Group, err error) {
+	ret = autoscalingGroups
+	if len(autoscalingGroups) != 0 {
+		return
+	}
+
+	err = conf.Autoscaling.DescribeAutoScalingGroupsPages(&autoscaling.DescribeAutoScalingGroupsInput{}, func(p *autoscaling.DescribeAutoScalingGroupsOutput, lastPage bool) bool {
+		autoscalingGroups = append(autoscalingGroups, p.AutoScalingGroups...)
+		return true // continue paging
+	})
+	if err != nil {
+		autoscalingGroups = []*autoscaling.Group{}
+		return autoscalingGroups, err
+	}
+	return autoscalingGroups, nil
+}
+
+func autoscalingGroupResourceId(groupName string) string {
+	return fmt.Sprintf("AWS::AutoScaling::AutoScalingGroup::%s", groupName)
+}
+
+// getAutoscalingPerGroupUsage returns one quota per autoscaling group, its
+// usage being computed by the given func
+func (c ServiceChecker) getAutoscalingPerGroupUsage(quotaName string, usage func(*autoscaling.Group) float64) (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	groups, err := getAutoscalingGroups()
+	if err != nil {
+		fmt.Printf("failed to retrieve autoscaling groups, %v", err)
+		return
+	}
+
+	for _, g := range groups {
+		quotaInfo := c.getAppliedQuotaOrDefault(quotaName, autoscalingDefaultQuotas[quotaName])
+		quotaInfo.UsageValue = usage(g)
+		quotaInfo.ResourceId = autoscalingGroupResourceId(aws.StringValue(g.AutoScalingGroupName))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getAutoscalingPoliciesPerGroupUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	policiesPerGroup := map[string]int{}
+	err := conf.Autoscaling.DescribePoliciesPages(&autoscaling.DescribePoliciesInput{}, func(p *autoscaling.DescribePoliciesOutput, lastPage bool) bool {
+		for _, policy := range p.ScalingPolicies {
+			policiesPerGroup[aws.StringValue(policy.AutoScalingGroupName)]++
+		}
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve autoscaling policies, %v", err)
+		return
+	}
+
+	return c.getAutoscalingPerGroupUsage("Scaling policies per Auto Scaling group", func(g *autoscaling.Group) float64 {
+		return float64(policiesPerGroup[aws.StringValue(g.AutoScalingGroupName)])
+	})
+}
+
+func (c ServiceChecker) getAutoscalingScheduledActionsPerGroupUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	actionsPerGroup := map[string]int{}
+	err := conf.Autoscaling.DescribeScheduledActionsPages(&autoscaling.DescribeScheduledActionsInput{}, func(p *autoscaling.DescribeScheduledActionsOutput, lastPage bool) bool {
+		for _, action := range p.ScheduledUpdateGroupActions {
+			actionsPerGroup[aws.StringValue(action.AutoScalingGroupName)]++
+		}
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve autoscaling scheduled actions, %v", err)
+		return
+	}
+
+	return c.getAutoscalingPerGroupUsage("Scheduled actions per Auto Scaling group", func(g *autoscaling.Group) float64 {
+		return float64(actionsPerGroup[aws.StringValue(g.AutoScalingGroupName)])
+	})
+}
+
+func (c ServiceChecker) getAutoscalingLifecycleHooksPerGroupUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	groups, err := getAutoscalingGroups()
+	if err != nil {
+		fmt.Printf("failed to retrieve autoscaling groups, %v", err)
+		return
+	}
+
+	for _, g := range groups {
+		quotaInfo := c.getAppliedQuotaOrDefault("Lifecycle hooks per Auto Scaling group", autoscalingDefaultQuotas["Lifecycle hooks per Auto Scaling group"])
+		result, err := conf.Autoscaling.DescribeLifecycleHooks(&autoscaling.DescribeLifecycleHooksInput{AutoScalingGroupName: g.AutoScalingGroupName})
+		if err != nil {
+			fmt.Printf("failed to retrieve lifecycle hooks for autoscaling group %s, %v", aws.StringValue(g.AutoScalingGroupName), err)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(len(result.LifecycleHooks))
+		quotaInfo.ResourceId = autoscalingGroupResourceId(aws.StringValue(g.AutoScalingGroupName))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getAutoscalingLoadBalancersPerGroupUsage() (ret []AWSQuotaInfo) {
+	return c.getAutoscalingPerGroupUsage("Classic Load Balancers per Auto Scaling group", func(g *autoscaling.Group) float64 {
+		return float64(len(g.LoadBalancerNames))
+	})
+}
+
+func (c ServiceChecker) getAutoscalingTargetGroupsPerGroupUsage() (ret []AWSQuotaInfo) {
+	return c.getAutoscalingPerGroupUsage("Target groups per Auto Scaling group", func(g *autoscaling.Group) float64 {
+		return float64(len(g.TargetGroupARNs))
+	})
+}
+
+func (c ServiceChecker) getAutoscalingNotificationsPerGroupUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	// a group can have multiple notification types per topic, so we only count
+	// distinct topics
+	topicsPerGroup := map[string]map[string]bool{}
+	err := conf.Autoscaling.DescribeNotificationConfigurationsPages(&autoscaling.DescribeNotificationConfigurationsInput{}, func(p *autoscaling.DescribeNotificationConfigurationsOutput, lastPage bool) bool {
+		for _, n := range p.NotificationConfigurations {
+			groupName := aws.StringValue(n.AutoScalingGroupName)
+			if _, ok := topicsPerGroup[groupName]; !ok {
+				topicsPerGroup[groupName] = map[string]bool{}
+			}
+			topicsPerGroup[groupName][aws.StringValue(n.TopicARN)] = true
+		}
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve autoscaling notification configurations, %v", err)
+		return
+	}
+
+	return c.getAutoscalingPerGroupUsage("SNS topics per Auto Scaling group", func(g *autoscaling.Group) float64 {
+		return float64(len(topicsPerGroup[aws.StringValue(g.AutoScalingGroupName)]))
+	})
+}
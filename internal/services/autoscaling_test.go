@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -20,6 +21,55 @@ func (m mockedAutoscalingDescribeAccountLimitsMsg) DescribeAccountLimits(input *
 	return &m.Resp, m.Error
 }
 
+type mockedAutoscalingClient struct {
+	AutoscalingClientInterface
+	DescribeAutoScalingGroupsPagesResp           autoscaling.DescribeAutoScalingGroupsOutput
+	DescribeAutoScalingGroupsPagesError          error
+	DescribePoliciesPagesResp                    autoscaling.DescribePoliciesOutput
+	DescribePoliciesPagesError                   error
+	DescribeScheduledActionsPagesResp            autoscaling.DescribeScheduledActionsOutput
+	DescribeScheduledActionsPagesError           error
+	DescribeLifecycleHooksResp                   autoscaling.DescribeLifecycleHooksOutput
+	DescribeLifecycleHooksError                  error
+	DescribeNotificationConfigurationsPagesResp  autoscaling.DescribeNotificationConfigurationsOutput
+	DescribeNotificationConfigurationsPagesError error
+}
+
+func (m mockedAutoscalingClient) DescribeAutoScalingGroupsPages(input *autoscaling.DescribeAutoScalingGroupsInput, fn func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool) error {
+	fn(&m.DescribeAutoScalingGroupsPagesResp, false)
+	return m.DescribeAutoScalingGroupsPagesError
+}
+
+func (m mockedAutoscalingClient) DescribePoliciesPages(input *autoscaling.DescribePoliciesInput, fn func(*autoscaling.DescribePoliciesOutput, bool) bool) error {
+	fn(&m.DescribePoliciesPagesResp, false)
+	return m.DescribePoliciesPagesError
+}
+
+func (m mockedAutoscalingClient) DescribeScheduledActionsPages(input *autoscaling.DescribeScheduledActionsInput, fn func(*autoscaling.DescribeScheduledActionsOutput, bool) bool) error {
+	fn(&m.DescribeScheduledActionsPagesResp, false)
+	return m.DescribeScheduledActionsPagesError
+}
+
+func (m mockedAutoscalingClient) DescribeLifecycleHooks(input *autoscaling.DescribeLifecycleHooksInput) (*autoscaling.DescribeLifecycleHooksOutput, error) {
+	return &m.DescribeLifecycleHooksResp, m.DescribeLifecycleHooksError
+}
+
+func (m mockedAutoscalingClient) DescribeNotificationConfigurationsPages(input *autoscaling.DescribeNotificationConfigurationsInput, fn func(*autoscaling.DescribeNotificationConfigurationsOutput, bool) bool) error {
+	fn(&m.DescribeNotificationConfigurationsPagesResp, false)
+	return m.DescribeNotificationConfigurationsPagesError
+}
+
+var mockedAutoscalingGroups = autoscaling.DescribeAutoScalingGroupsOutput{
+	AutoScalingGroups: []*autoscaling.Group{
+		{
+			AutoScalingGroupName: aws.String("foo"),
+			LoadBalancerNames:    []*string{aws.String("lb1"), aws.String("lb2")},
+			TargetGroupARNs:      []*string{aws.String("tg1")},
+		},
+		{AutoScalingGroupName: aws.String("bar")},
+	},
+}
+
 func TestNewAutoscalingCheckerImpl(t *testing.T) {
 	require.Implements(t, (*Svcquota)(nil), NewAutoscalingChecker())
 }
@@ -30,6 +80,17 @@ func TestGetAutoscalingGroupsUsage(t *testing.T) {
 		NumberOfAutoScalingGroups:    aws.Int64(1),
 	}
 	conf.Autoscaling = mockedAutoscalingDescribeAccountLimitsMsg{Resp: mockedOutput, Error: nil}
+	resetServiceQuotas()
+	conf.ServiceQuotas = mockedScvQuotaClient{
+		ListServiceQuotasOutputResp: servicequotas.ListServiceQuotasOutput{
+			Quotas: []*servicequotas.ServiceQuota{{
+				ServiceCode: aws.String("autoscaling"),
+				QuotaName:   aws.String("Auto Scaling groups per region"),
+				QuotaCode:   aws.String("L-CDE20ADC"),
+				Value:       aws.Float64(5),
+			}},
+		},
+	}
 
 	autoscalingChecker := NewAutoscalingChecker()
 	svcChecker := autoscalingChecker.(*ServiceChecker)
@@ -38,6 +99,8 @@ func TestGetAutoscalingGroupsUsage(t *testing.T) {
 	assert.Len(t, actual, 1)
 	quota := actual[0]
 	assert.Equal(t, "autoscaling", quota.Service)
+	assert.Equal(t, "L-CDE20ADC", quota.Quotacode)
+	assert.False(t, quota.Global)
 	assert.Equal(t, float64(10), quota.QuotaValue)
 	assert.Equal(t, float64(1), quota.UsageValue)
 }
@@ -63,6 +126,7 @@ func TestGetAutoscalingLaunchConfigsUsage(t *testing.T) {
 		NumberOfLaunchConfigurations:    aws.Int64(1),
 	}
 	conf.Autoscaling = mockedAutoscalingDescribeAccountLimitsMsg{Resp: mockedOutput, Error: nil}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
 
 	autoscalingChecker := NewAutoscalingChecker()
 	svcChecker := autoscalingChecker.(*ServiceChecker)
@@ -81,6 +145,7 @@ func TestGetAutoscalingLaunchConfigsUsageError(t *testing.T) {
 		NumberOfLaunchConfigurations:    aws.Int64(1),
 	}
 	conf.Autoscaling = mockedAutoscalingDescribeAccountLimitsMsg{Resp: mockedOutput, Error: errors.New("test error")}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
 
 	autoscalingChecker := NewAutoscalingChecker()
 	svcChecker := autoscalingChecker.(*ServiceChecker)
@@ -89,3 +154,190 @@ func TestGetAutoscalingLaunchConfigsUsageError(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestGetAutoscalingPoliciesPerGroupUsage(t *testing.T) {
+	conf.Autoscaling = mockedAutoscalingClient{
+		DescribeAutoScalingGroupsPagesResp: mockedAutoscalingGroups,
+		DescribePoliciesPagesResp: autoscaling.DescribePoliciesOutput{
+			ScalingPolicies: []*autoscaling.ScalingPolicy{
+				{AutoScalingGroupName: aws.String("foo"), PolicyName: aws.String("p1")},
+				{AutoScalingGroupName: aws.String("foo"), PolicyName: aws.String("p2")},
+				{AutoScalingGroupName: aws.String("bar"), PolicyName: aws.String("p3")},
+			},
+		},
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{NewQuota("autoscaling", "Scaling policies per Auto Scaling group", float64(20), false)},
+		nil)
+
+	autoscalingChecker := NewAutoscalingChecker()
+	svcChecker := autoscalingChecker.(*ServiceChecker)
+	actual := svcChecker.getAutoscalingPoliciesPerGroupUsage()
+
+	assert.Len(t, actual, 2)
+	assert.Equal(t, "AWS::AutoScaling::AutoScalingGroup::foo", actual[0].ResourceId)
+	assert.Equal(t, float64(20), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+	assert.Equal(t, "AWS::AutoScaling::AutoScalingGroup::bar", actual[1].ResourceId)
+	assert.Equal(t, float64(1), actual[1].UsageValue)
+	t.Cleanup(func() { autoscalingGroups = []*autoscaling.Group{} })
+}
+
+func TestGetAutoscalingPoliciesPerGroupUsageError(t *testing.T) {
+	conf.Autoscaling = mockedAutoscalingClient{
+		DescribeAutoScalingGroupsPagesResp: mockedAutoscalingGroups,
+		DescribePoliciesPagesError:         errors.New("test error"),
+	}
+
+	autoscalingChecker := NewAutoscalingChecker()
+	svcChecker := autoscalingChecker.(*ServiceChecker)
+	actual := svcChecker.getAutoscalingPoliciesPerGroupUsage()
+
+	assert.Equal(t, []AWSQuotaInfo{}, actual)
+	t.Cleanup(func() { autoscalingGroups = []*autoscaling.Group{} })
+}
+
+func TestGetAutoscalingPerGroupUsageErrorGroups(t *testing.T) {
+	conf.Autoscaling = mockedAutoscalingClient{
+		DescribeAutoScalingGroupsPagesResp:  mockedAutoscalingGroups,
+		DescribeAutoScalingGroupsPagesError: errors.New("test error"),
+	}
+
+	autoscalingChecker := NewAutoscalingChecker()
+	svcChecker := autoscalingChecker.(*ServiceChecker)
+	actual := svcChecker.getAutoscalingLoadBalancersPerGroupUsage()
+
+	assert.Equal(t, []AWSQuotaInfo{}, actual)
+	assert.Len(t, autoscalingGroups, 0)
+}
+
+func TestGetAutoscalingScheduledActionsPerGroupUsage(t *testing.T) {
+	conf.Autoscaling = mockedAutoscalingClient{
+		DescribeAutoScalingGroupsPagesResp: mockedAutoscalingGroups,
+		DescribeScheduledActionsPagesResp: autoscaling.DescribeScheduledActionsOutput{
+			ScheduledUpdateGroupActions: []*autoscaling.ScheduledUpdateGroupAction{
+				{AutoScalingGroupName: aws.String("bar")},
+			},
+		},
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	autoscalingChecker := NewAutoscalingChecker()
+	svcChecker := autoscalingChecker.(*ServiceChecker)
+	actual := svcChecker.getAutoscalingScheduledActionsPerGroupUsage()
+
+	assert.Len(t, actual, 2)
+	// not returned by servicequotas, so we fall back to the documented default
+	assert.Equal(t, float64(125), actual[0].QuotaValue)
+	assert.Equal(t, float64(0), actual[0].UsageValue)
+	assert.Equal(t, float64(1), actual[1].UsageValue)
+	t.Cleanup(func() { autoscalingGroups = []*autoscaling.Group{} })
+}
+
+func TestGetAutoscalingLifecycleHooksPerGroupUsage(t *testing.T) {
+	conf.Autoscaling = mockedAutoscalingClient{
+		DescribeAutoScalingGroupsPagesResp: mockedAutoscalingGroups,
+		DescribeLifecycleHooksResp: autoscaling.DescribeLifecycleHooksOutput{
+			LifecycleHooks: []*autoscaling.LifecycleHook{{LifecycleHookName: aws.String("hook")}},
+		},
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{NewQuota("autoscaling", "Lifecycle hooks per Auto Scaling group", float64(50), false)},
+		nil)
+
+	autoscalingChecker := NewAutoscalingChecker()
+	svcChecker := autoscalingChecker.(*ServiceChecker)
+	actual := svcChecker.getAutoscalingLifecycleHooksPerGroupUsage()
+
+	assert.Len(t, actual, 2)
+	assert.Equal(t, float64(1), actual[0].UsageValue)
+	assert.Equal(t, float64(50), actual[0].QuotaValue)
+	t.Cleanup(func() { autoscalingGroups = []*autoscaling.Group{} })
+}
+
+func TestGetAutoscalingLifecycleHooksPerGroupUsageError(t *testing.T) {
+	conf.Autoscaling = mockedAutoscalingClient{
+		DescribeAutoScalingGroupsPagesResp: mockedAutoscalingGroups,
+		DescribeLifecycleHooksError:        errors.New("test error"),
+	}
+
+	autoscalingChecker := NewAutoscalingChecker()
+	svcChecker := autoscalingChecker.(*ServiceChecker)
+	actual := svcChecker.getAutoscalingLifecycleHooksPerGroupUsage()
+
+	assert.Len(t, actual, 0)
+	t.Cleanup(func() { autoscalingGroups = []*autoscaling.Group{} })
+}
+
+func TestGetAutoscalingLoadBalancersPerGroupUsage(t *testing.T) {
+	conf.Autoscaling = mockedAutoscalingClient{DescribeAutoScalingGroupsPagesResp: mockedAutoscalingGroups}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	autoscalingChecker := NewAutoscalingChecker()
+	svcChecker := autoscalingChecker.(*ServiceChecker)
+	lbs := svcChecker.getAutoscalingLoadBalancersPerGroupUsage()
+	tgs := svcChecker.getAutoscalingTargetGroupsPerGroupUsage()
+
+	assert.Len(t, lbs, 2)
+	assert.Equal(t, float64(2), lbs[0].UsageValue)
+	assert.Equal(t, float64(0), lbs[1].UsageValue)
+	assert.Len(t, tgs, 2)
+	assert.Equal(t, float64(1), tgs[0].UsageValue)
+	assert.Equal(t, float64(0), tgs[1].UsageValue)
+	t.Cleanup(func() { autoscalingGroups = []*autoscaling.Group{} })
+}
+
+func TestGetAutoscalingNotificationsPerGroupUsage(t *testing.T) {
+	conf.Autoscaling = mockedAutoscalingClient{
+		DescribeAutoScalingGroupsPagesResp: mockedAutoscalingGroups,
+		DescribeNotificationConfigurationsPagesResp: autoscaling.DescribeNotificationConfigurationsOutput{
+			NotificationConfigurations: []*autoscaling.NotificationConfiguration{
+				{AutoScalingGroupName: aws.String("foo"), TopicARN: aws.String("topic1"), NotificationType: aws.String("launch")},
+				{AutoScalingGroupName: aws.String("foo"), TopicARN: aws.String("topic1"), NotificationType: aws.String("terminate")},
+				{AutoScalingGroupName: aws.String("foo"), TopicARN: aws.String("topic2"), NotificationType: aws.String("launch")},
+			},
+		},
+	}
+
+	autoscalingChecker := NewAutoscalingChecker()
+	svcChecker := autoscalingChecker.(*ServiceChecker)
+	actual := svcChecker.getAutoscalingNotificationsPerGroupUsage()
+
+	assert.Len(t, actual, 2)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+	assert.Equal(t, float64(0), actual[1].UsageValue)
+	t.Cleanup(func() { autoscalingGroups = []*autoscaling.Group{} })
+}
+
+func TestGetAutoscalingNotificationsPerGroupUsageError(t *testing.T) {
+	conf.Autoscaling = mockedAutoscalingClient{
+		DescribeAutoScalingGroupsPagesResp:           mockedAutoscalingGroups,
+		DescribeNotificationConfigurationsPagesError: errors.New("test error"),
+	}
+
+	autoscalingChecker := NewAutoscalingChecker()
+	svcChecker := autoscalingChecker.(*ServiceChecker)
+	actual := svcChecker.getAutoscalingNotificationsPerGroupUsage()
+
+	assert.Equal(t, []AWSQuotaInfo{}, actual)
+	t.Cleanup(func() { autoscalingGroups = []*autoscaling.Group{} })
+}
+
+func TestGetAutoscalingLaunchConfigsUsageOverride(t *testing.T) {
+	mockedOutput := autoscaling.DescribeAccountLimitsOutput{
+		MaxNumberOfLaunchConfigurations: aws.Int64(10),
+		NumberOfLaunchConfigurations:    aws.Int64(1),
+	}
+	conf.Autoscaling = mockedAutoscalingDescribeAccountLimitsMsg{Resp: mockedOutput, Error: nil}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	autoscalingChecker := NewAutoscalingChecker()
+	svcChecker := autoscalingChecker.(*ServiceChecker)
+	actual := ApplyQuotaOverrides(svcChecker.getAutoscalingLaunchConfigsUsage(), []AWSQuotaOverride{
+		{Service: "autoscaling", QuotaName: "Launch configurations per region", QuotaValue: float64(20)}}, "")
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, float64(20), actual[0].QuotaValue)
+	assert.Equal(t, float64(10), actual[0].ServiceValue)
+	assert.Equal(t, QuotaSourceOverride, actual[0].Source)
+}
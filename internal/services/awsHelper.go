@@ -7,41 +7,112 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go/service/appsync"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/backup"
+	"github.com/aws/aws-sdk-go/service/batch"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cognitoidentity"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/aws/aws-sdk-go/service/directconnect"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/efs"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/elasticache"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/fsx"
+	"github.com/aws/aws-sdk-go/service/glue"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kafka"
 	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/mq"
+	"github.com/aws/aws-sdk-go/service/opensearchservice"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/redshift"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/aws/aws-sdk-go/service/sfn"
 	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/storagegateway"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/wafv2"
 )
 
 var conf *Config = &Config{}
 
+// globalRegion is the region global services are queried through, regardless
+// of the region the tool runs against
+const globalRegion = "us-east-1"
+
+// serviceRegionOverrides maps the services that must always be queried through
+// a given region to that region
+var serviceRegionOverrides = map[string]string{
+	"cloudfront":       globalRegion,
+	"wafv2-cloudfront": globalRegion, // CLOUDFRONT scope of wafv2
+}
+
 type Config struct {
-	Session        *session.Session
-	Acm            AcmClientInterface
-	Autoscaling    AutoscalingClientInterface
-	Cloudformation CloudformationClientInterface
-	DynamoDb       DynamodbClientInterface
-	Ec2            Ec2ClientInterface
-	Eks            EksClientInterface
-	ElastiCache    ElastiCacheClientInterface
-	Elb            ElbClientInterface   // for classic load balancers
-	Elbv2          Elbv2ClientInterface // for ALB, NLB load balancers
-	Iam            IamClientInterface
-	Kinesis        KinesisClientInterface
-	Rds            RdsClientInterface
-	S3             S3ClientInterface
-	ServiceQuotas  SvcQuotaClientInterface
-	Sns            SnsClientInterface
+	Session             *session.Session
+	Acm                 AcmClientInterface
+	Apigateway          ApigatewayClientInterface   // for REST apis
+	Apigatewayv2        Apigatewayv2ClientInterface // for HTTP and WebSocket apis
+	AppSync             AppSyncClientInterface
+	Autoscaling         AutoscalingClientInterface
+	Backup              BackupClientInterface
+	Batch               BatchClientInterface
+	Cloudformation      CloudformationClientInterface
+	Cloudfront          CloudfrontClientInterface // always in us-east-1
+	Cloudtrail          CloudtrailClientInterface
+	Cloudwatch          CloudwatchClientInterface
+	CloudwatchLogs      CloudwatchLogsClientInterface
+	CognitoIdentity     CognitoIdentityClientInterface // for identity pools
+	CognitoIdp          CognitoIdpClientInterface      // for user pools
+	ConfigService       ConfigServiceClientInterface
+	DirectConnect       DirectConnectClientInterface
+	DynamoDb            DynamodbClientInterface
+	Ec2                 Ec2ClientInterface
+	Efs                 EfsClientInterface
+	Eks                 EksClientInterface
+	ElastiCache         ElastiCacheClientInterface
+	Elb                 ElbClientInterface   // for classic load balancers
+	Elbv2               Elbv2ClientInterface // for ALB, NLB load balancers
+	Eventbridge         EventbridgeClientInterface
+	Fsx                 FsxClientInterface
+	Glue                GlueClientInterface
+	Iam                 IamClientInterface
+	Kafka               KafkaClientInterface // for msk
+	Kinesis             KinesisClientInterface
+	Kms                 KmsClientInterface
+	Mq                  MqClientInterface
+	OpenSearch          OpenSearchClientInterface
+	Rds                 RdsClientInterface // also used for documentdb and neptune
+	Redshift            RedshiftClientInterface
+	S3                  S3ClientInterface
+	SecretsManager      SecretsManagerClientInterface
+	ServiceQuotas       SvcQuotaClientInterface
+	ServiceQuotasGlobal SvcQuotaClientInterface // for global services, always in us-east-1
+	Ses                 SesClientInterface
+	Sfn                 SfnClientInterface
+	Sns                 SnsClientInterface
+	Ssm                 SsmClientInterface
+	StorageGateway      StorageGatewayClientInterface
+	Sts                 StsClientInterface
+	Wafv2               Wafv2ClientInterface // for the REGIONAL scope
+	Wafv2Cloudfront     Wafv2ClientInterface // for the CLOUDFRONT scope, always in us-east-1
 }
 
 var InitializeConfig = initializeConfig
@@ -52,23 +123,57 @@ func initializeConfig(awsprofile string, region string) (*Config, error) {
 		return &Config{}, fmt.Errorf("unable to create a session to aws with error: %v", err)
 	}
 
+	// the new session may run against another account or region
+	accountId = ""
+	resetServiceQuotas()
 	conf = &Config{
-		Session:        &sess,
-		Acm:            acm.New(&sess),
-		Autoscaling:    autoscaling.New(&sess),
-		Cloudformation: cloudformation.New(&sess),
-		DynamoDb:       dynamodb.New(&sess),
-		Ec2:            ec2.New(&sess),
-		Eks:            eks.New(&sess),
-		ElastiCache:    elasticache.New(&sess),
-		Elb:            elb.New(&sess),   // for classic load balancers
-		Elbv2:          elbv2.New(&sess), // for ALB and NLB load balancers
-		Iam:            iam.New(&sess),
-		Kinesis:        kinesis.New(&sess),
-		Rds:            rds.New(&sess),
-		S3:             s3.New(&sess),
-		ServiceQuotas:  servicequotas.New(&sess),
-		Sns:            sns.New(&sess),
+		Session:             &sess,
+		Acm:                 acm.New(&sess),
+		Apigateway:          apigateway.New(&sess),   // for REST apis
+		Apigatewayv2:        apigatewayv2.New(&sess), // for HTTP and WebSocket apis
+		AppSync:             appsync.New(&sess),
+		Autoscaling:         autoscaling.New(&sess),
+		Backup:              backup.New(&sess),
+		Batch:               batch.New(&sess),
+		Cloudformation:      cloudformation.New(&sess),
+		Cloudfront:          cloudfront.New(sessionForService(&sess, "cloudfront")),
+		Cloudtrail:          cloudtrail.New(&sess),
+		Cloudwatch:          cloudwatch.New(&sess),
+		CloudwatchLogs:      cloudwatchlogs.New(&sess),
+		CognitoIdentity:     cognitoidentity.New(&sess),         // for identity pools
+		CognitoIdp:          cognitoidentityprovider.New(&sess), // for user pools
+		ConfigService:       configservice.New(&sess),
+		DirectConnect:       directconnect.New(&sess),
+		DynamoDb:            dynamodb.New(&sess),
+		Ec2:                 ec2.New(&sess),
+		Efs:                 efs.New(&sess),
+		Eks:                 eks.New(&sess),
+		ElastiCache:         elasticache.New(&sess),
+		Elb:                 elb.New(&sess),   // for classic load balancers
+		Elbv2:               elbv2.New(&sess), // for ALB and NLB load balancers
+		Eventbridge:         eventbridge.New(&sess),
+		Fsx:                 fsx.New(&sess),
+		Glue:                glue.New(&sess),
+		Iam:                 iam.New(&sess),
+		Kafka:               kafka.New(&sess), // for msk
+		Kinesis:             kinesis.New(&sess),
+		Kms:                 kms.New(&sess),
+		Mq:                  mq.New(&sess),
+		OpenSearch:          opensearchservice.New(&sess),
+		Rds:                 rds.New(&sess), // also used for documentdb and neptune
+		Redshift:            redshift.New(&sess),
+		S3:                  s3.New(&sess),
+		SecretsManager:      secretsmanager.New(&sess),
+		ServiceQuotas:       servicequotas.New(&sess),
+		ServiceQuotasGlobal: servicequotas.New(sessionInRegion(&sess, globalRegion)), // for global services
+		Ses:                 ses.New(&sess),
+		Sfn:                 sfn.New(&sess),
+		Sns:                 sns.New(&sess),
+		Ssm:                 ssm.New(&sess),
+		StorageGateway:      storagegateway.New(&sess),
+		Sts:                 sts.New(&sess),
+		Wafv2:               wafv2.New(&sess), // for the REGIONAL scope
+		Wafv2Cloudfront:     wafv2.New(sessionForService(&sess, "wafv2-cloudfront")),
 	}
 
 	return conf, nil
@@ -81,6 +186,36 @@ func createAwsSession(awsprofile string, region string) (session.Session, error)
 	)
 	if err != nil {
 		fmt.Printf("Unable to create AWS session, %v", err)
+	} else {
+		addAccessDeniedHandlers(&sess.Handlers)
 	}
 	return *sess, err
 }
+
+// sessionForService returns the session to use for the given service: a copy
+// of sess in the overridden region if the service has one, sess otherwise
+func sessionForService(sess *session.Session, service string) *session.Session {
+	region, ok := serviceRegionOverrides[service]
+	if !ok {
+		return sess
+	}
+	return sessionInRegion(sess, region)
+}
+
+// sessionInRegion returns sess if it is in the given region, a copy of sess in
+// that region otherwise
+func sessionInRegion(sess *session.Session, region string) *session.Session {
+	if aws.StringValue(sess.Config.Region) == region {
+		return sess
+	}
+	return sess.Copy(&aws.Config{Region: aws.String(region)})
+}
+
+// getServiceQuotasClient returns the servicequotas client holding the quotas of
+// the given service code. Global services only expose theirs in us-east-1
+func getServiceQuotasClient(serviceCode string) SvcQuotaClientInterface {
+	if _, ok := serviceRegionOverrides[serviceCode]; ok {
+		return conf.ServiceQuotasGlobal
+	}
+	return conf.ServiceQuotas
+}
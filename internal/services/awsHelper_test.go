@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionForService(t *testing.T) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String("eu-west-1")})
+	require.Nil(t, err)
+
+	// services without override use the given session
+	assert.Same(t, sess, sessionForService(sess, "ec2"))
+
+	// global services are always queried through us-east-1
+	cloudfrontSess := sessionForService(sess, "cloudfront")
+	assert.Equal(t, "us-east-1", aws.StringValue(cloudfrontSess.Config.Region))
+	assert.Equal(t, "us-east-1", aws.StringValue(sessionForService(sess, "wafv2-cloudfront").Config.Region))
+	assert.Equal(t, "eu-west-1", aws.StringValue(sess.Config.Region))
+}
+
+func TestSessionInRegion(t *testing.T) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String("us-east-1")})
+	require.Nil(t, err)
+
+	assert.Same(t, sess, sessionInRegion(sess, "us-east-1"))
+	assert.Equal(t, "eu-west-1", aws.StringValue(sessionInRegion(sess, "eu-west-1").Config.Region))
+	assert.Equal(t, "us-east-1", aws.StringValue(sess.Config.Region))
+}
+
+func TestInitializeConfigServiceQuotasGlobal(t *testing.T) {
+	previous := conf
+	t.Cleanup(func() { conf = previous })
+
+	actual, err := initializeConfig("default", "eu-west-1")
+	require.Nil(t, err)
+	assert.Equal(t, "eu-west-1", aws.StringValue(actual.ServiceQuotas.(*servicequotas.ServiceQuotas).Client.Config.Region))
+	assert.Equal(t, globalRegion, aws.StringValue(actual.ServiceQuotasGlobal.(*servicequotas.ServiceQuotas).Client.Config.Region))
+}
+
+func TestInitializeConfigResetsAccountId(t *testing.T) {
+	previous := conf
+	t.Cleanup(func() {
+		conf = previous
+		accountId = ""
+	})
+
+	_, err := initializeConfig("default", "eu-west-1")
+	require.Nil(t, err)
+	conf.Sts = mockedStsClient{GetCallerIdentityResp: sts.GetCallerIdentityOutput{Account: aws.String("111111111111")}}
+	actual, err := GetAccountId()
+	require.Nil(t, err)
+	assert.Equal(t, "111111111111", actual)
+
+	// switching profile queries the account of the new session
+	_, err = initializeConfig("other", "eu-west-1")
+	require.Nil(t, err)
+	conf.Sts = mockedStsClient{GetCallerIdentityResp: sts.GetCallerIdentityOutput{Account: aws.String("222222222222")}}
+	actual, err = GetAccountId()
+	require.Nil(t, err)
+	assert.Equal(t, "222222222222", actual)
+}
+
+func TestGetServiceQuotasClient(t *testing.T) {
+	regional := NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{NewQuota("ec2", "regional", float64(1), false)}, nil)
+	global := NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{NewQuota("cloudfront", "global", float64(1), true)}, nil)
+	conf.ServiceQuotas = regional
+	conf.ServiceQuotasGlobal = global
+
+	assert.Equal(t, regional, getServiceQuotasClient("ec2"))
+	assert.Equal(t, global, getServiceQuotasClient("cloudfront"))
+}
@@ -1,21 +1,55 @@
 package services
 
+import "time"
+
 type AWSQuotaInfo struct {
-	Service    string  // service the quota applies to
-	Region     string  // the region this quota applies to
-	ResourceId string  // if there can be multiple usages for one quota, aws id (Cloudformation format)
-	QuotaName  string  // the name of the quota
-	Quotacode  string  // servicequota code
-	QuotaValue float64 // the quota value
-	UsageValue float64 // the usage value
-	Unit       string  // unit of the quota/usage
-	Global     bool    // whether the quota is global or not
+	Service       string               // service the quota applies to
+	Region        string               // the region this quota applies to
+	ResourceId    string               // if there can be multiple usages for one quota, aws id (Cloudformation format)
+	QuotaName     string               // the name of the quota
+	Quotacode     string               // servicequota code
+	QuotaValue    float64              // the quota value
+	UsageValue    float64              // the usage value
+	Unit          string               // unit of the quota/usage
+	Global        bool                 // whether the quota is global or not
+	Adjustable    bool                 // whether an increase of the quota can be requested
+	Period        time.Duration        // the period of a rate quota, 0 for other quotas
+	UsageMetric   *AWSQuotaUsageMetric // cloudwatch metric tracking the usage, if servicequotas provides one
+	DefaultValue  float64              // the aws default value of the quota
+	AppliedValue  float64              // the value applied to the account, the default one if never raised
+	ServiceValue  float64              // the value reported by the service api, for services exposing their limits
+	OverrideValue float64              // the value provided by the user, if overridden
+	Source        QuotaSource          // where the quota value comes from
+}
+
+// QuotaSource tells where the value of a quota comes from
+type QuotaSource string
+
+const (
+	QuotaSourceDefault    QuotaSource = "default"     // aws default value, servicequotas' or documented
+	QuotaSourceApplied    QuotaSource = "applied"     // value applied to the account in servicequotas
+	QuotaSourceServiceAPI QuotaSource = "service-api" // value reported by the service api
+	QuotaSourceOverride   QuotaSource = "override"    // value provided by the user
+	QuotaSourceUnknown    QuotaSource = "unknown"     // no value known, the quota is dropped unless overridden
+)
+
+type AWSQuotaUsageMetric struct {
+	Namespace  string            // cloudwatch namespace of the metric, usually AWS/Usage
+	Name       string            // the name of the metric
+	Dimensions map[string]string // dimensions identifying the metric
+	Statistic  string            // statistic recommended by servicequotas to compute the usage
 }
 
+// AWSQuotaOverride replaces the value of the quotas it matches. Only Service
+// is required, every other empty criteria matches any quota
 type AWSQuotaOverride struct {
-	Service    string  // service the quota applies to
-	QuotaName  string  // the name of the quota
-	QuotaValue float64 // the quota value
+	Service    string  `json:"service" mapstructure:"service"`       // service code the quota applies to
+	QuotaName  string  `json:"quotaName" mapstructure:"quotaName"`   // the name of the quota
+	QuotaCode  string  `json:"quotaCode" mapstructure:"quotaCode"`   // servicequota code of the quota
+	Region     string  `json:"region" mapstructure:"region"`         // region the override applies to
+	Account    string  `json:"account" mapstructure:"account"`       // account id the override applies to
+	ResourceId string  `json:"resourceId" mapstructure:"resourceId"` // glob matching the ids of the resources the override applies to
+	QuotaValue float64 `json:"value" mapstructure:"value"`           // the quota value
 }
 
 type Svcquota interface {
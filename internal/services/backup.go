@@ -0,0 +1,104 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/backup"
+)
+
+type BackupClientInterface interface {
+	ListBackupVaultsPages(input *backup.ListBackupVaultsInput, fn func(*backup.ListBackupVaultsOutput, bool) bool) error
+	ListBackupPlansPages(input *backup.ListBackupPlansInput, fn func(*backup.ListBackupPlansOutput, bool) bool) error
+	ListBackupSelectionsPages(input *backup.ListBackupSelectionsInput, fn func(*backup.ListBackupSelectionsOutput, bool) bool) error
+}
+
+var backupDefaultQuotas = map[string]quotaDefault{
+	"Backup vaults per account":         {Value: 100},
+	"Backup plans per account":          {Value: 100},
+	"Backup selections per backup plan": {Value: 50},
+}
+
+func NewBackupChecker() Svcquota {
+	serviceCode := "backup"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Backup vaults per account":         ServiceChecker.getBackupVaultsUsage,
+		"Backup plans per account":          ServiceChecker.getBackupPlansUsage,
+		"Backup selections per backup plan": ServiceChecker.getBackupSelectionsPerPlanUsage,
+	}
+	requiredPermissions := []string{
+		"backup:ListBackupVaults",
+		"backup:ListBackupPlans",
+		"backup:ListBackupSelections",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func getBackupPlans() (ret []*backup.PlansListMember, err error) {
+	ret = []*backup.PlansListMember{}
+	err = conf.Backup.ListBackupPlansPages(&backup.ListBackupPlansInput{}, func(p *backup.ListBackupPlansOutput, lastPage bool) bool {
+		ret = append(ret, p.BackupPlansList...)
+		return true // continue paging
+	})
+	return
+}
+
+func (c ServiceChecker) getBackupVaultsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	vaults := 0
+	err := conf.Backup.ListBackupVaultsPages(&backup.ListBackupVaultsInput{}, func(p *backup.ListBackupVaultsOutput, lastPage bool) bool {
+		vaults += len(p.BackupVaultList)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve backup vaults, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Backup vaults per account", backupDefaultQuotas["Backup vaults per account"])
+	quotaInfo.UsageValue = float64(vaults)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getBackupPlansUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	plans, err := getBackupPlans()
+	if err != nil {
+		fmt.Printf("failed to retrieve backup plans, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Backup plans per account", backupDefaultQuotas["Backup plans per account"])
+	quotaInfo.UsageValue = float64(len(plans))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getBackupSelectionsPerPlanUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	plans, err := getBackupPlans()
+	if err != nil {
+		fmt.Printf("failed to retrieve backup plans, %v", err)
+		return
+	}
+
+	for _, plan := range plans {
+		quotaInfo := c.getAppliedQuotaOrDefault("Backup selections per backup plan", backupDefaultQuotas["Backup selections per backup plan"])
+		selections := 0
+		errSelections := conf.Backup.ListBackupSelectionsPages(&backup.ListBackupSelectionsInput{BackupPlanId: plan.BackupPlanId}, func(p *backup.ListBackupSelectionsOutput, lastPage bool) bool {
+			selections += len(p.BackupSelectionsList)
+			return true // continue paging
+		})
+		if errSelections != nil {
+			fmt.Printf("failed to retrieve selections for backup plan %s, %v", aws.StringValue(plan.BackupPlanName), errSelections)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(selections)
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::Backup::BackupPlan::%s", aws.StringValue(plan.BackupPlanName))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
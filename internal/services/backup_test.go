@@ -0,0 +1,98 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/backup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedBackupClient struct {
+	BackupClientInterface
+	ListBackupVaultsPagesResp      backup.ListBackupVaultsOutput
+	ListBackupVaultsPagesError     error
+	ListBackupPlansPagesResp       backup.ListBackupPlansOutput
+	ListBackupPlansPagesError      error
+	ListBackupSelectionsPagesResp  backup.ListBackupSelectionsOutput
+	ListBackupSelectionsPagesError error
+}
+
+func (m mockedBackupClient) ListBackupVaultsPages(input *backup.ListBackupVaultsInput, fn func(*backup.ListBackupVaultsOutput, bool) bool) error {
+	return mockPages(m.ListBackupVaultsPagesResp, m.ListBackupVaultsPagesError, fn)
+}
+
+func (m mockedBackupClient) ListBackupPlansPages(input *backup.ListBackupPlansInput, fn func(*backup.ListBackupPlansOutput, bool) bool) error {
+	return mockPages(m.ListBackupPlansPagesResp, m.ListBackupPlansPagesError, fn)
+}
+
+func (m mockedBackupClient) ListBackupSelectionsPages(input *backup.ListBackupSelectionsInput, fn func(*backup.ListBackupSelectionsOutput, bool) bool) error {
+	return mockPages(m.ListBackupSelectionsPagesResp, m.ListBackupSelectionsPagesError, fn)
+}
+
+func TestNewBackupCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewBackupChecker())
+}
+
+func TestGetBackupUsage(t *testing.T) {
+	conf.Backup = mockedBackupClient{
+		ListBackupVaultsPagesResp: backup.ListBackupVaultsOutput{
+			BackupVaultList: []*backup.VaultListMember{{}, {}, {}},
+		},
+		ListBackupPlansPagesResp: backup.ListBackupPlansOutput{
+			BackupPlansList: []*backup.PlansListMember{
+				{BackupPlanId: aws.String("id1"), BackupPlanName: aws.String("daily")},
+				{BackupPlanId: aws.String("id2"), BackupPlanName: aws.String("weekly")},
+			},
+		},
+		ListBackupSelectionsPagesResp: backup.ListBackupSelectionsOutput{
+			BackupSelectionsList: []*backup.SelectionsListMember{{}, {}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewBackupChecker, NewQuota("backup", "Backup vaults per account", float64(200), false))
+
+	vaults := svcChecker.getBackupVaultsUsage()
+	assert.Len(t, vaults, 1)
+	assert.Equal(t, "backup", vaults[0].Service)
+	assert.Equal(t, float64(200), vaults[0].QuotaValue)
+	assert.Equal(t, float64(3), vaults[0].UsageValue)
+
+	plans := svcChecker.getBackupPlansUsage()
+	assert.Len(t, plans, 1)
+	assert.Equal(t, float64(100), plans[0].QuotaValue)
+	assert.Equal(t, float64(2), plans[0].UsageValue)
+
+	selections := svcChecker.getBackupSelectionsPerPlanUsage()
+	assert.Len(t, selections, 2)
+	assert.Equal(t, "AWS::Backup::BackupPlan::daily", selections[0].ResourceId)
+	assert.Equal(t, float64(50), selections[0].QuotaValue)
+	assert.Equal(t, float64(2), selections[0].UsageValue)
+	assert.Equal(t, "AWS::Backup::BackupPlan::weekly", selections[1].ResourceId)
+}
+
+func TestGetBackupUsageError(t *testing.T) {
+	conf.Backup = mockedBackupClient{
+		ListBackupVaultsPagesError: errors.New("test error"),
+		ListBackupPlansPagesError:  errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewBackupChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getBackupVaultsUsage())
+	assert.Equal(t, expected, svcChecker.getBackupPlansUsage())
+	assert.Equal(t, expected, svcChecker.getBackupSelectionsPerPlanUsage())
+}
+
+func TestGetBackupSelectionsPerPlanUsageError(t *testing.T) {
+	conf.Backup = mockedBackupClient{
+		ListBackupPlansPagesResp: backup.ListBackupPlansOutput{
+			BackupPlansList: []*backup.PlansListMember{{BackupPlanId: aws.String("id1"), BackupPlanName: aws.String("daily")}},
+		},
+		ListBackupSelectionsPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewBackupChecker)
+
+	assert.Equal(t, []AWSQuotaInfo{}, svcChecker.getBackupSelectionsPerPlanUsage())
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/batch"
+)
+
+type BatchClientInterface interface {
+	DescribeComputeEnvironmentsPages(input *batch.DescribeComputeEnvironmentsInput, fn func(*batch.DescribeComputeEnvironmentsOutput, bool) bool) error
+	DescribeJobQueuesPages(input *batch.DescribeJobQueuesInput, fn func(*batch.DescribeJobQueuesOutput, bool) bool) error
+}
+
+var batchDefaultQuotas = map[string]quotaDefault{
+	"Compute environments": {Value: 50},
+	"Job queues":           {Value: 50},
+}
+
+func NewBatchChecker() Svcquota {
+	serviceCode := "batch"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Compute environments": ServiceChecker.getBatchComputeEnvironmentsUsage,
+		"Job queues":           ServiceChecker.getBatchJobQueuesUsage,
+	}
+	requiredPermissions := []string{
+		"batch:DescribeComputeEnvironments",
+		"batch:DescribeJobQueues",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func (c ServiceChecker) getBatchComputeEnvironmentsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	computeEnvironments := 0
+	err := conf.Batch.DescribeComputeEnvironmentsPages(&batch.DescribeComputeEnvironmentsInput{}, func(p *batch.DescribeComputeEnvironmentsOutput, lastPage bool) bool {
+		computeEnvironments += len(p.ComputeEnvironments)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve batch compute environments, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Compute environments", batchDefaultQuotas["Compute environments"])
+	quotaInfo.UsageValue = float64(computeEnvironments)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getBatchJobQueuesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	jobQueues := 0
+	err := conf.Batch.DescribeJobQueuesPages(&batch.DescribeJobQueuesInput{}, func(p *batch.DescribeJobQueuesOutput, lastPage bool) bool {
+		jobQueues += len(p.JobQueues)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve batch job queues, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Job queues", batchDefaultQuotas["Job queues"])
+	quotaInfo.UsageValue = float64(jobQueues)
+	ret = append(ret, quotaInfo)
+	return
+}
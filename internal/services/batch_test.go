@@ -0,0 +1,65 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/batch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedBatchClient struct {
+	BatchClientInterface
+	DescribeComputeEnvironmentsPagesResp  batch.DescribeComputeEnvironmentsOutput
+	DescribeComputeEnvironmentsPagesError error
+	DescribeJobQueuesPagesResp            batch.DescribeJobQueuesOutput
+	DescribeJobQueuesPagesError           error
+}
+
+func (m mockedBatchClient) DescribeComputeEnvironmentsPages(input *batch.DescribeComputeEnvironmentsInput, fn func(*batch.DescribeComputeEnvironmentsOutput, bool) bool) error {
+	return mockPages(m.DescribeComputeEnvironmentsPagesResp, m.DescribeComputeEnvironmentsPagesError, fn)
+}
+
+func (m mockedBatchClient) DescribeJobQueuesPages(input *batch.DescribeJobQueuesInput, fn func(*batch.DescribeJobQueuesOutput, bool) bool) error {
+	return mockPages(m.DescribeJobQueuesPagesResp, m.DescribeJobQueuesPagesError, fn)
+}
+
+func TestNewBatchCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewBatchChecker())
+}
+
+func TestGetBatchUsage(t *testing.T) {
+	conf.Batch = mockedBatchClient{
+		DescribeComputeEnvironmentsPagesResp: batch.DescribeComputeEnvironmentsOutput{
+			ComputeEnvironments: []*batch.ComputeEnvironmentDetail{{}, {}, {}},
+		},
+		DescribeJobQueuesPagesResp: batch.DescribeJobQueuesOutput{
+			JobQueues: []*batch.JobQueueDetail{{}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewBatchChecker, NewQuota("batch", "Compute environments", float64(100), false))
+
+	computeEnvironments := svcChecker.getBatchComputeEnvironmentsUsage()
+	assert.Len(t, computeEnvironments, 1)
+	assert.Equal(t, "batch", computeEnvironments[0].Service)
+	assert.Equal(t, float64(100), computeEnvironments[0].QuotaValue)
+	assert.Equal(t, float64(3), computeEnvironments[0].UsageValue)
+
+	jobQueues := svcChecker.getBatchJobQueuesUsage()
+	assert.Len(t, jobQueues, 1)
+	assert.Equal(t, float64(50), jobQueues[0].QuotaValue)
+	assert.Equal(t, float64(1), jobQueues[0].UsageValue)
+}
+
+func TestGetBatchUsageError(t *testing.T) {
+	conf.Batch = mockedBatchClient{
+		DescribeComputeEnvironmentsPagesError: errors.New("test error"),
+		DescribeJobQueuesPagesError:           errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewBatchChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getBatchComputeEnvironmentsUsage())
+	assert.Equal(t, expected, svcChecker.getBatchJobQueuesUsage())
+}
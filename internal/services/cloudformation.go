@@ -23,7 +23,7 @@ func NewCloudformationChecker() Svcquota {
 
 func (c ServiceChecker) getCloudformationStackUsage() (ret []AWSQuotaInfo) {
 	ret = []AWSQuotaInfo{}
-	quotaInfo := c.GetAllAppliedQuotas()["Stack count"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Stack count", quotaDefault{})
 
 	stacks := []*cloudformation.StackSummary{}
 
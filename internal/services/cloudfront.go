@@ -0,0 +1,199 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+)
+
+type CloudfrontClientInterface interface {
+	ListDistributionsPages(input *cloudfront.ListDistributionsInput, fn func(*cloudfront.ListDistributionsOutput, bool) bool) error
+	ListCachePolicies(input *cloudfront.ListCachePoliciesInput) (*cloudfront.ListCachePoliciesOutput, error)
+	ListOriginRequestPolicies(input *cloudfront.ListOriginRequestPoliciesInput) (*cloudfront.ListOriginRequestPoliciesOutput, error)
+	ListFunctions(input *cloudfront.ListFunctionsInput) (*cloudfront.ListFunctionsOutput, error)
+}
+
+var cloudfrontDefaultQuotas = map[string]quotaDefault{
+	"Web distributions per AWS account":                {Value: 200},
+	"Cache policies per AWS account":                   {Value: 20},
+	"Origin request policies per AWS account":          {Value: 20},
+	"CloudFront Functions per AWS account":             {Value: 100},
+	"Alternate domain names (CNAMEs) per distribution": {Value: 100},
+	"Origins per distribution":                         {Value: 25},
+}
+
+func NewCloudfrontChecker() Svcquota {
+	serviceCode := "cloudfront"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Web distributions per AWS account":                ServiceChecker.getCloudfrontDistributionsUsage,
+		"Cache policies per AWS account":                   ServiceChecker.getCloudfrontCachePoliciesUsage,
+		"Origin request policies per AWS account":          ServiceChecker.getCloudfrontOriginRequestPoliciesUsage,
+		"CloudFront Functions per AWS account":             ServiceChecker.getCloudfrontFunctionsUsage,
+		"Alternate domain names (CNAMEs) per distribution": ServiceChecker.getCloudfrontAliasesPerDistributionUsage,
+		"Origins per distribution":                         ServiceChecker.getCloudfrontOriginsPerDistributionUsage,
+	}
+	requiredPermissions := []string{
+		"cloudfront:ListDistributions",
+		"cloudfront:ListCachePolicies",
+		"cloudfront:ListOriginRequestPolicies",
+		"cloudfront:ListFunctions",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+// getCloudfrontQuota returns the given quota, flagged as global: cloudfront is
+// a global service, always queried through us-east-1
+func (c ServiceChecker) getCloudfrontQuota(quotaName string) AWSQuotaInfo {
+	quotaInfo := c.getAppliedQuotaOrDefault(quotaName, cloudfrontDefaultQuotas[quotaName])
+	quotaInfo.Global = true
+	return quotaInfo
+}
+
+func getCloudfrontDistributions() (ret []*cloudfront.DistributionSummary, err error) {
+	ret = []*cloudfront.DistributionSummary{}
+	err = conf.Cloudfront.ListDistributionsPages(&cloudfront.ListDistributionsInput{}, func(p *cloudfront.ListDistributionsOutput, lastPage bool) bool {
+		if p.DistributionList != nil {
+			ret = append(ret, p.DistributionList.Items...)
+		}
+		return true // continue paging
+	})
+	return
+}
+
+func cloudfrontDistributionResourceId(distribution *cloudfront.DistributionSummary) string {
+	return fmt.Sprintf("AWS::CloudFront::Distribution::%s", aws.StringValue(distribution.Id))
+}
+
+func (c ServiceChecker) getCloudfrontDistributionsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	distributions, err := getCloudfrontDistributions()
+	if err != nil {
+		fmt.Printf("failed to retrieve cloudfront distributions, %v", err)
+		return
+	}
+
+	quotaInfo := c.getCloudfrontQuota("Web distributions per AWS account")
+	quotaInfo.UsageValue = float64(len(distributions))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getCloudfrontCachePoliciesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	policies := 0
+	// managed policies do not count against the quota
+	input := &cloudfront.ListCachePoliciesInput{Type: aws.String(cloudfront.CachePolicyTypeCustom)}
+	for {
+		result, err := conf.Cloudfront.ListCachePolicies(input)
+		if err != nil {
+			fmt.Printf("failed to retrieve cloudfront cache policies, %v", err)
+			return
+		}
+		if result.CachePolicyList == nil {
+			break
+		}
+		policies += len(result.CachePolicyList.Items)
+		if result.CachePolicyList.NextMarker == nil {
+			break
+		}
+		input.Marker = result.CachePolicyList.NextMarker
+	}
+
+	quotaInfo := c.getCloudfrontQuota("Cache policies per AWS account")
+	quotaInfo.UsageValue = float64(policies)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getCloudfrontOriginRequestPoliciesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	policies := 0
+	// managed policies do not count against the quota
+	input := &cloudfront.ListOriginRequestPoliciesInput{Type: aws.String(cloudfront.OriginRequestPolicyTypeCustom)}
+	for {
+		result, err := conf.Cloudfront.ListOriginRequestPolicies(input)
+		if err != nil {
+			fmt.Printf("failed to retrieve cloudfront origin request policies, %v", err)
+			return
+		}
+		if result.OriginRequestPolicyList == nil {
+			break
+		}
+		policies += len(result.OriginRequestPolicyList.Items)
+		if result.OriginRequestPolicyList.NextMarker == nil {
+			break
+		}
+		input.Marker = result.OriginRequestPolicyList.NextMarker
+	}
+
+	quotaInfo := c.getCloudfrontQuota("Origin request policies per AWS account")
+	quotaInfo.UsageValue = float64(policies)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getCloudfrontFunctionsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	functions := 0
+	input := &cloudfront.ListFunctionsInput{}
+	for {
+		result, err := conf.Cloudfront.ListFunctions(input)
+		if err != nil {
+			fmt.Printf("failed to retrieve cloudfront functions, %v", err)
+			return
+		}
+		if result.FunctionList == nil {
+			break
+		}
+		functions += len(result.FunctionList.Items)
+		if result.FunctionList.NextMarker == nil {
+			break
+		}
+		input.Marker = result.FunctionList.NextMarker
+	}
+
+	quotaInfo := c.getCloudfrontQuota("CloudFront Functions per AWS account")
+	quotaInfo.UsageValue = float64(functions)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getCloudfrontAliasesPerDistributionUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	distributions, err := getCloudfrontDistributions()
+	if err != nil {
+		fmt.Printf("failed to retrieve cloudfront distributions, %v", err)
+		return
+	}
+
+	for _, d := range distributions {
+		quotaInfo := c.getCloudfrontQuota("Alternate domain names (CNAMEs) per distribution")
+		if d.Aliases != nil {
+			quotaInfo.UsageValue = float64(aws.Int64Value(d.Aliases.Quantity))
+		}
+		quotaInfo.ResourceId = cloudfrontDistributionResourceId(d)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getCloudfrontOriginsPerDistributionUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	distributions, err := getCloudfrontDistributions()
+	if err != nil {
+		fmt.Printf("failed to retrieve cloudfront distributions, %v", err)
+		return
+	}
+
+	for _, d := range distributions {
+		quotaInfo := c.getCloudfrontQuota("Origins per distribution")
+		if d.Origins != nil {
+			quotaInfo.UsageValue = float64(aws.Int64Value(d.Origins.Quantity))
+		}
+		quotaInfo.ResourceId = cloudfrontDistributionResourceId(d)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
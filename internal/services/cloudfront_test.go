@@ -0,0 +1,143 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedCloudfrontClient struct {
+	CloudfrontClientInterface
+	ListDistributionsPagesResp     cloudfront.ListDistributionsOutput
+	ListDistributionsPagesError    error
+	ListCachePoliciesResp          cloudfront.ListCachePoliciesOutput
+	ListCachePoliciesError         error
+	ListOriginRequestPoliciesResp  cloudfront.ListOriginRequestPoliciesOutput
+	ListOriginRequestPoliciesError error
+	ListFunctionsResp              cloudfront.ListFunctionsOutput
+	ListFunctionsError             error
+}
+
+func (m mockedCloudfrontClient) ListDistributionsPages(input *cloudfront.ListDistributionsInput, fn func(*cloudfront.ListDistributionsOutput, bool) bool) error {
+	return mockPages(m.ListDistributionsPagesResp, m.ListDistributionsPagesError, fn)
+}
+
+func (m mockedCloudfrontClient) ListCachePolicies(input *cloudfront.ListCachePoliciesInput) (*cloudfront.ListCachePoliciesOutput, error) {
+	return &m.ListCachePoliciesResp, m.ListCachePoliciesError
+}
+
+func (m mockedCloudfrontClient) ListOriginRequestPolicies(input *cloudfront.ListOriginRequestPoliciesInput) (*cloudfront.ListOriginRequestPoliciesOutput, error) {
+	return &m.ListOriginRequestPoliciesResp, m.ListOriginRequestPoliciesError
+}
+
+func (m mockedCloudfrontClient) ListFunctions(input *cloudfront.ListFunctionsInput) (*cloudfront.ListFunctionsOutput, error) {
+	return &m.ListFunctionsResp, m.ListFunctionsError
+}
+
+func TestNewCloudfrontCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewCloudfrontChecker())
+}
+
+func TestGetCloudfrontUsage(t *testing.T) {
+	conf.Cloudfront = mockedCloudfrontClient{
+		ListDistributionsPagesResp: cloudfront.ListDistributionsOutput{
+			DistributionList: &cloudfront.DistributionList{
+				Items: []*cloudfront.DistributionSummary{
+					{
+						Id:      aws.String("E1"),
+						Aliases: &cloudfront.Aliases{Quantity: aws.Int64(3)},
+						Origins: &cloudfront.Origins{Quantity: aws.Int64(2)},
+					},
+					{
+						Id:      aws.String("E2"),
+						Aliases: &cloudfront.Aliases{Quantity: aws.Int64(0)},
+						Origins: &cloudfront.Origins{Quantity: aws.Int64(1)},
+					},
+				},
+			},
+		},
+		ListCachePoliciesResp: cloudfront.ListCachePoliciesOutput{
+			CachePolicyList: &cloudfront.CachePolicyList{
+				Items: []*cloudfront.CachePolicySummary{{}, {}},
+			},
+		},
+		ListOriginRequestPoliciesResp: cloudfront.ListOriginRequestPoliciesOutput{
+			OriginRequestPolicyList: &cloudfront.OriginRequestPolicyList{
+				Items: []*cloudfront.OriginRequestPolicySummary{{}},
+			},
+		},
+		ListFunctionsResp: cloudfront.ListFunctionsOutput{
+			FunctionList: &cloudfront.FunctionList{
+				Items: []*cloudfront.FunctionSummary{{}, {}, {}, {}},
+			},
+		},
+	}
+	// cloudfront quotas are only returned by servicequotas in us-east-1
+	conf.ServiceQuotasGlobal = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{NewQuota("cloudfront", "Web distributions per AWS account", float64(500), true)},
+		nil)
+
+	svcChecker := newTestServiceChecker(NewCloudfrontChecker)
+
+	distributions := svcChecker.getCloudfrontDistributionsUsage()
+	assert.Len(t, distributions, 1)
+	assert.Equal(t, "cloudfront", distributions[0].Service)
+	assert.Equal(t, float64(500), distributions[0].QuotaValue)
+	assert.Equal(t, float64(2), distributions[0].UsageValue)
+	assert.True(t, distributions[0].Global)
+
+	cachePolicies := svcChecker.getCloudfrontCachePoliciesUsage()
+	assert.Len(t, cachePolicies, 1)
+	assert.Equal(t, float64(20), cachePolicies[0].QuotaValue)
+	assert.Equal(t, float64(2), cachePolicies[0].UsageValue)
+	assert.True(t, cachePolicies[0].Global)
+
+	originRequestPolicies := svcChecker.getCloudfrontOriginRequestPoliciesUsage()
+	assert.Len(t, originRequestPolicies, 1)
+	assert.Equal(t, float64(1), originRequestPolicies[0].UsageValue)
+
+	functions := svcChecker.getCloudfrontFunctionsUsage()
+	assert.Len(t, functions, 1)
+	assert.Equal(t, float64(100), functions[0].QuotaValue)
+	assert.Equal(t, float64(4), functions[0].UsageValue)
+
+	aliases := svcChecker.getCloudfrontAliasesPerDistributionUsage()
+	assert.Len(t, aliases, 2)
+	assert.Equal(t, "AWS::CloudFront::Distribution::E1", aliases[0].ResourceId)
+	assert.Equal(t, float64(100), aliases[0].QuotaValue)
+	assert.Equal(t, float64(3), aliases[0].UsageValue)
+	assert.Equal(t, float64(0), aliases[1].UsageValue)
+
+	origins := svcChecker.getCloudfrontOriginsPerDistributionUsage()
+	assert.Len(t, origins, 2)
+	assert.Equal(t, "AWS::CloudFront::Distribution::E2", origins[1].ResourceId)
+	assert.Equal(t, float64(25), origins[1].QuotaValue)
+	assert.Equal(t, float64(2), origins[0].UsageValue)
+	assert.Equal(t, float64(1), origins[1].UsageValue)
+	assert.True(t, origins[1].Global)
+}
+
+func TestGetCloudfrontUsageError(t *testing.T) {
+	conf.Cloudfront = mockedCloudfrontClient{
+		ListDistributionsPagesError:    errors.New("test error"),
+		ListCachePoliciesError:         errors.New("test error"),
+		ListOriginRequestPoliciesError: errors.New("test error"),
+		ListFunctionsError:             errors.New("test error"),
+	}
+	conf.ServiceQuotasGlobal = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	svcChecker := newTestServiceChecker(NewCloudfrontChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getCloudfrontDistributionsUsage())
+	assert.Equal(t, expected, svcChecker.getCloudfrontCachePoliciesUsage())
+	assert.Equal(t, expected, svcChecker.getCloudfrontOriginRequestPoliciesUsage())
+	assert.Equal(t, expected, svcChecker.getCloudfrontFunctionsUsage())
+	assert.Equal(t, expected, svcChecker.getCloudfrontAliasesPerDistributionUsage())
+	assert.Equal(t, expected, svcChecker.getCloudfrontOriginsPerDistributionUsage())
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+)
+
+type CloudtrailClientInterface interface {
+	DescribeTrails(input *cloudtrail.DescribeTrailsInput) (*cloudtrail.DescribeTrailsOutput, error)
+	ListEventDataStoresPages(input *cloudtrail.ListEventDataStoresInput, fn func(*cloudtrail.ListEventDataStoresOutput, bool) bool) error
+}
+
+var cloudtrailDefaultQuotas = map[string]quotaDefault{
+	"Trails per region":            {Value: 5},
+	"Event data stores per region": {Value: 10},
+}
+
+func NewCloudtrailChecker() Svcquota {
+	serviceCode := "cloudtrail"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Trails per region":            ServiceChecker.getCloudtrailTrailsUsage,
+		"Event data stores per region": ServiceChecker.getCloudtrailEventDataStoresUsage,
+	}
+	requiredPermissions := []string{
+		"cloudtrail:DescribeTrails",
+		"cloudtrail:ListEventDataStores",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+// getCloudtrailTrailsUsage only counts the trails created in the current region,
+// multi-region trails created elsewhere (shadow trails) count against the quota
+// of their home region
+func (c ServiceChecker) getCloudtrailTrailsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	result, err := conf.Cloudtrail.DescribeTrails(&cloudtrail.DescribeTrailsInput{IncludeShadowTrails: aws.Bool(false)})
+	if err != nil {
+		fmt.Printf("failed to retrieve cloudtrail trails, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Trails per region", cloudtrailDefaultQuotas["Trails per region"])
+	quotaInfo.UsageValue = float64(len(result.TrailList))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getCloudtrailEventDataStoresUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	eventDataStores := 0
+	err := conf.Cloudtrail.ListEventDataStoresPages(&cloudtrail.ListEventDataStoresInput{}, func(p *cloudtrail.ListEventDataStoresOutput, lastPage bool) bool {
+		eventDataStores += len(p.EventDataStores)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve cloudtrail event data stores, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Event data stores per region", cloudtrailDefaultQuotas["Event data stores per region"])
+	quotaInfo.UsageValue = float64(eventDataStores)
+	ret = append(ret, quotaInfo)
+	return
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedCloudtrailClient struct {
+	CloudtrailClientInterface
+	DescribeTrailsResp            cloudtrail.DescribeTrailsOutput
+	DescribeTrailsError           error
+	ListEventDataStoresPagesResp  cloudtrail.ListEventDataStoresOutput
+	ListEventDataStoresPagesError error
+}
+
+func (m mockedCloudtrailClient) DescribeTrails(input *cloudtrail.DescribeTrailsInput) (*cloudtrail.DescribeTrailsOutput, error) {
+	return &m.DescribeTrailsResp, m.DescribeTrailsError
+}
+
+func (m mockedCloudtrailClient) ListEventDataStoresPages(input *cloudtrail.ListEventDataStoresInput, fn func(*cloudtrail.ListEventDataStoresOutput, bool) bool) error {
+	return mockPages(m.ListEventDataStoresPagesResp, m.ListEventDataStoresPagesError, fn)
+}
+
+func TestNewCloudtrailCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewCloudtrailChecker())
+}
+
+func TestGetCloudtrailUsage(t *testing.T) {
+	conf.Cloudtrail = mockedCloudtrailClient{
+		DescribeTrailsResp: cloudtrail.DescribeTrailsOutput{
+			TrailList: []*cloudtrail.Trail{{}, {}, {}},
+		},
+		ListEventDataStoresPagesResp: cloudtrail.ListEventDataStoresOutput{
+			EventDataStores: []*cloudtrail.EventDataStore{{}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewCloudtrailChecker, NewQuota("cloudtrail", "Trails per region", float64(5), false))
+
+	trails := svcChecker.getCloudtrailTrailsUsage()
+	assert.Len(t, trails, 1)
+	assert.Equal(t, "cloudtrail", trails[0].Service)
+	assert.Equal(t, float64(5), trails[0].QuotaValue)
+	assert.Equal(t, float64(3), trails[0].UsageValue)
+
+	eventDataStores := svcChecker.getCloudtrailEventDataStoresUsage()
+	assert.Len(t, eventDataStores, 1)
+	assert.Equal(t, float64(10), eventDataStores[0].QuotaValue)
+	assert.Equal(t, float64(1), eventDataStores[0].UsageValue)
+}
+
+func TestGetCloudtrailUsageError(t *testing.T) {
+	conf.Cloudtrail = mockedCloudtrailClient{
+		DescribeTrailsError:           errors.New("test error"),
+		ListEventDataStoresPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewCloudtrailChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getCloudtrailTrailsUsage())
+	assert.Equal(t, expected, svcChecker.getCloudtrailEventDataStoresUsage())
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+)
+
+type CloudwatchClientInterface interface {
+	DescribeAlarmsPages(input *cloudwatch.DescribeAlarmsInput, fn func(*cloudwatch.DescribeAlarmsOutput, bool) bool) error
+	ListDashboardsPages(input *cloudwatch.ListDashboardsInput, fn func(*cloudwatch.ListDashboardsOutput, bool) bool) error
+	ListMetricStreamsPages(input *cloudwatch.ListMetricStreamsInput, fn func(*cloudwatch.ListMetricStreamsOutput, bool) bool) error
+	GetMetricDataPages(input *cloudwatch.GetMetricDataInput, fn func(*cloudwatch.GetMetricDataOutput, bool) bool) error
+}
+
+var cloudwatchDefaultQuotas = map[string]quotaDefault{
+	"Alarms per Region":                     {Value: 5000},
+	"Dashboards per account":                {Value: 5000},
+	"Metric streams per account per Region": {Value: 1000},
+}
+
+func NewCloudwatchChecker() Svcquota {
+	serviceCode := "monitoring"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Alarms per Region":                     ServiceChecker.getCloudwatchAlarmsUsage,
+		"Dashboards per account":                ServiceChecker.getCloudwatchDashboardsUsage,
+		"Metric streams per account per Region": ServiceChecker.getCloudwatchMetricStreamsUsage,
+	}
+	requiredPermissions := []string{
+		"cloudwatch:DescribeAlarms",
+		"cloudwatch:ListDashboards",
+		"cloudwatch:ListMetricStreams",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func (c ServiceChecker) getCloudwatchAlarmsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	alarms := 0
+	// metric alarms are the only ones returned unless alarm types are specified
+	input := &cloudwatch.DescribeAlarmsInput{
+		AlarmTypes: aws.StringSlice([]string{cloudwatch.AlarmTypeMetricAlarm, cloudwatch.AlarmTypeCompositeAlarm}),
+	}
+	err := conf.Cloudwatch.DescribeAlarmsPages(input, func(p *cloudwatch.DescribeAlarmsOutput, lastPage bool) bool {
+		alarms += len(p.MetricAlarms) + len(p.CompositeAlarms)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve cloudwatch alarms, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Alarms per Region", cloudwatchDefaultQuotas["Alarms per Region"])
+	quotaInfo.UsageValue = float64(alarms)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getCloudwatchDashboardsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	dashboards := 0
+	err := conf.Cloudwatch.ListDashboardsPages(&cloudwatch.ListDashboardsInput{}, func(p *cloudwatch.ListDashboardsOutput, lastPage bool) bool {
+		dashboards += len(p.DashboardEntries)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve cloudwatch dashboards, %v", err)
+		return
+	}
+
+	// dashboards are global to the account
+	quotaInfo := c.getAppliedQuotaOrDefault("Dashboards per account", cloudwatchDefaultQuotas["Dashboards per account"])
+	quotaInfo.Global = true
+	quotaInfo.UsageValue = float64(dashboards)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getCloudwatchMetricStreamsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	streams := 0
+	err := conf.Cloudwatch.ListMetricStreamsPages(&cloudwatch.ListMetricStreamsInput{}, func(p *cloudwatch.ListMetricStreamsOutput, lastPage bool) bool {
+		streams += len(p.Entries)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve cloudwatch metric streams, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Metric streams per account per Region", cloudwatchDefaultQuotas["Metric streams per account per Region"])
+	quotaInfo.UsageValue = float64(streams)
+	ret = append(ret, quotaInfo)
+	return
+}
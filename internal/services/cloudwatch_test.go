@@ -0,0 +1,89 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedCloudwatchClient struct {
+	CloudwatchClientInterface
+	DescribeAlarmsPagesResp     cloudwatch.DescribeAlarmsOutput
+	DescribeAlarmsPagesError    error
+	ListDashboardsPagesResp     cloudwatch.ListDashboardsOutput
+	ListDashboardsPagesError    error
+	ListMetricStreamsPagesResp  cloudwatch.ListMetricStreamsOutput
+	ListMetricStreamsPagesError error
+	GetMetricDataPagesResp      cloudwatch.GetMetricDataOutput
+	GetMetricDataPagesError     error
+	GetMetricDataInputs         *[]*cloudwatch.GetMetricDataInput
+}
+
+func (m mockedCloudwatchClient) DescribeAlarmsPages(input *cloudwatch.DescribeAlarmsInput, fn func(*cloudwatch.DescribeAlarmsOutput, bool) bool) error {
+	return mockPages(m.DescribeAlarmsPagesResp, m.DescribeAlarmsPagesError, fn)
+}
+
+func (m mockedCloudwatchClient) ListDashboardsPages(input *cloudwatch.ListDashboardsInput, fn func(*cloudwatch.ListDashboardsOutput, bool) bool) error {
+	return mockPages(m.ListDashboardsPagesResp, m.ListDashboardsPagesError, fn)
+}
+
+func (m mockedCloudwatchClient) ListMetricStreamsPages(input *cloudwatch.ListMetricStreamsInput, fn func(*cloudwatch.ListMetricStreamsOutput, bool) bool) error {
+	return mockPages(m.ListMetricStreamsPagesResp, m.ListMetricStreamsPagesError, fn)
+}
+
+func (m mockedCloudwatchClient) GetMetricDataPages(input *cloudwatch.GetMetricDataInput, fn func(*cloudwatch.GetMetricDataOutput, bool) bool) error {
+	if m.GetMetricDataInputs != nil {
+		*m.GetMetricDataInputs = append(*m.GetMetricDataInputs, input)
+	}
+	return mockPages(m.GetMetricDataPagesResp, m.GetMetricDataPagesError, fn)
+}
+
+func TestNewCloudwatchCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewCloudwatchChecker())
+}
+
+func TestGetCloudwatchUsage(t *testing.T) {
+	conf.Cloudwatch = mockedCloudwatchClient{
+		DescribeAlarmsPagesResp: cloudwatch.DescribeAlarmsOutput{
+			MetricAlarms:    []*cloudwatch.MetricAlarm{{}, {}},
+			CompositeAlarms: []*cloudwatch.CompositeAlarm{{}},
+		},
+		ListDashboardsPagesResp:    cloudwatch.ListDashboardsOutput{DashboardEntries: []*cloudwatch.DashboardEntry{{}, {}}},
+		ListMetricStreamsPagesResp: cloudwatch.ListMetricStreamsOutput{Entries: []*cloudwatch.MetricStreamEntry{{}}},
+	}
+	svcChecker := newTestServiceChecker(NewCloudwatchChecker, NewQuota("monitoring", "Alarms per Region", float64(10000), false))
+
+	alarms := svcChecker.getCloudwatchAlarmsUsage()
+	assert.Len(t, alarms, 1)
+	assert.Equal(t, "monitoring", alarms[0].Service)
+	assert.Equal(t, float64(10000), alarms[0].QuotaValue)
+	assert.Equal(t, float64(3), alarms[0].UsageValue)
+
+	dashboards := svcChecker.getCloudwatchDashboardsUsage()
+	assert.Len(t, dashboards, 1)
+	assert.Equal(t, float64(5000), dashboards[0].QuotaValue)
+	assert.Equal(t, float64(2), dashboards[0].UsageValue)
+	assert.True(t, dashboards[0].Global)
+
+	streams := svcChecker.getCloudwatchMetricStreamsUsage()
+	assert.Len(t, streams, 1)
+	assert.Equal(t, float64(1000), streams[0].QuotaValue)
+	assert.Equal(t, float64(1), streams[0].UsageValue)
+}
+
+func TestGetCloudwatchUsageError(t *testing.T) {
+	conf.Cloudwatch = mockedCloudwatchClient{
+		DescribeAlarmsPagesError:    errors.New("test error"),
+		ListDashboardsPagesError:    errors.New("test error"),
+		ListMetricStreamsPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewCloudwatchChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getCloudwatchAlarmsUsage())
+	assert.Equal(t, expected, svcChecker.getCloudwatchDashboardsUsage())
+	assert.Equal(t, expected, svcChecker.getCloudwatchMetricStreamsUsage())
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+type CloudwatchLogsClientInterface interface {
+	DescribeLogGroupsPages(input *cloudwatchlogs.DescribeLogGroupsInput, fn func(*cloudwatchlogs.DescribeLogGroupsOutput, bool) bool) error
+	DescribeSubscriptionFiltersPages(input *cloudwatchlogs.DescribeSubscriptionFiltersInput, fn func(*cloudwatchlogs.DescribeSubscriptionFiltersOutput, bool) bool) error
+	DescribeMetricFiltersPages(input *cloudwatchlogs.DescribeMetricFiltersInput, fn func(*cloudwatchlogs.DescribeMetricFiltersOutput, bool) bool) error
+	DescribeResourcePolicies(input *cloudwatchlogs.DescribeResourcePoliciesInput) (*cloudwatchlogs.DescribeResourcePoliciesOutput, error)
+}
+
+var cloudwatchLogsDefaultQuotas = map[string]quotaDefault{
+	"Log groups":                         {Value: 1000000},
+	"Subscription filters per log group": {Value: 2},
+	"Metric filters per log group":       {Value: 100},
+	"Resource policies per Region":       {Value: 10},
+}
+
+func NewCloudwatchLogsChecker() Svcquota {
+	serviceCode := "logs"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Log groups":                         ServiceChecker.getCloudwatchLogGroupsUsage,
+		"Subscription filters per log group": ServiceChecker.getCloudwatchLogsSubscriptionFiltersUsage,
+		"Metric filters per log group":       ServiceChecker.getCloudwatchLogsMetricFiltersUsage,
+		"Resource policies per Region":       ServiceChecker.getCloudwatchLogsResourcePoliciesUsage,
+	}
+	requiredPermissions := []string{
+		"logs:DescribeLogGroups",
+		"logs:DescribeSubscriptionFilters",
+		"logs:DescribeMetricFilters",
+		"logs:DescribeResourcePolicies",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+var cloudwatchLogGroups []*cloudwatchlogs.LogGroup = []*cloudwatchlogs.LogGroup{}
+
+// getCloudwatchLogGroups lists the log groups once and shares the result
+// between the different quotas
+func getCloudwatchLogGroups() (ret []*cloudwatchlogs.LogGroup, err error) {
+	ret = cloudwatchLogGroups
+	if len(cloudwatchLogGroups) != 0 {
+		return
+	}
+
+	err = conf.CloudwatchLogs.DescribeLogGroupsPages(&cloudwatchlogs.DescribeLogGroupsInput{}, func(p *cloudwatchlogs.DescribeLogGroupsOutput, lastPage bool) bool {
+		cloudwatchLogGroups = append(cloudwatchLogGroups, p.LogGroups...)
+		return true // continue paging
+	})
+	if err != nil {
+		cloudwatchLogGroups = []*cloudwatchlogs.LogGroup{}
+		return cloudwatchLogGroups, err
+	}
+	return cloudwatchLogGroups, nil
+}
+
+func cloudwatchLogGroupResourceId(logGroupName *string) string {
+	return fmt.Sprintf("AWS::Logs::LogGroup::%s", aws.StringValue(logGroupName))
+}
+
+func (c ServiceChecker) getCloudwatchLogGroupsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	logGroups, err := getCloudwatchLogGroups()
+	if err != nil {
+		fmt.Printf("failed to retrieve cloudwatch log groups, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Log groups", cloudwatchLogsDefaultQuotas["Log groups"])
+	quotaInfo.UsageValue = float64(len(logGroups))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getCloudwatchLogsSubscriptionFiltersUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	logGroups, err := getCloudwatchLogGroups()
+	if err != nil {
+		fmt.Printf("failed to retrieve cloudwatch log groups, %v", err)
+		return
+	}
+
+	for _, logGroup := range logGroups {
+		quotaInfo := c.getAppliedQuotaOrDefault("Subscription filters per log group", cloudwatchLogsDefaultQuotas["Subscription filters per log group"])
+		filters := 0
+		input := &cloudwatchlogs.DescribeSubscriptionFiltersInput{LogGroupName: logGroup.LogGroupName}
+		errFilters := conf.CloudwatchLogs.DescribeSubscriptionFiltersPages(input, func(p *cloudwatchlogs.DescribeSubscriptionFiltersOutput, lastPage bool) bool {
+			filters += len(p.SubscriptionFilters)
+			return true // continue paging
+		})
+		if errFilters != nil {
+			fmt.Printf("failed to retrieve subscription filters for log group %s, %v", aws.StringValue(logGroup.LogGroupName), errFilters)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(filters)
+		quotaInfo.ResourceId = cloudwatchLogGroupResourceId(logGroup.LogGroupName)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getCloudwatchLogsMetricFiltersUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	logGroups, err := getCloudwatchLogGroups()
+	if err != nil {
+		fmt.Printf("failed to retrieve cloudwatch log groups, %v", err)
+		return
+	}
+
+	// metric filters can be listed for the whole region at once, which avoids
+	// one call per log group
+	filtersPerLogGroup := map[string]int{}
+	err = conf.CloudwatchLogs.DescribeMetricFiltersPages(&cloudwatchlogs.DescribeMetricFiltersInput{}, func(p *cloudwatchlogs.DescribeMetricFiltersOutput, lastPage bool) bool {
+		for _, f := range p.MetricFilters {
+			filtersPerLogGroup[aws.StringValue(f.LogGroupName)]++
+		}
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve cloudwatch metric filters, %v", err)
+		return
+	}
+
+	for _, logGroup := range logGroups {
+		quotaInfo := c.getAppliedQuotaOrDefault("Metric filters per log group", cloudwatchLogsDefaultQuotas["Metric filters per log group"])
+		quotaInfo.UsageValue = float64(filtersPerLogGroup[aws.StringValue(logGroup.LogGroupName)])
+		quotaInfo.ResourceId = cloudwatchLogGroupResourceId(logGroup.LogGroupName)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getCloudwatchLogsResourcePoliciesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	policies := 0
+	input := &cloudwatchlogs.DescribeResourcePoliciesInput{}
+	for {
+		result, err := conf.CloudwatchLogs.DescribeResourcePolicies(input)
+		if err != nil {
+			fmt.Printf("failed to retrieve cloudwatch logs resource policies, %v", err)
+			return
+		}
+		policies += len(result.ResourcePolicies)
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Resource policies per Region", cloudwatchLogsDefaultQuotas["Resource policies per Region"])
+	quotaInfo.UsageValue = float64(policies)
+	ret = append(ret, quotaInfo)
+	return
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedCloudwatchLogsClient struct {
+	CloudwatchLogsClientInterface
+	DescribeLogGroupsPagesResp            cloudwatchlogs.DescribeLogGroupsOutput
+	DescribeLogGroupsPagesError           error
+	DescribeSubscriptionFiltersPagesResp  cloudwatchlogs.DescribeSubscriptionFiltersOutput
+	DescribeSubscriptionFiltersPagesError error
+	DescribeMetricFiltersPagesResp        cloudwatchlogs.DescribeMetricFiltersOutput
+	DescribeMetricFiltersPagesError       error
+	DescribeResourcePoliciesResp          cloudwatchlogs.DescribeResourcePoliciesOutput
+	DescribeResourcePoliciesError         error
+}
+
+func (m mockedCloudwatchLogsClient) DescribeLogGroupsPages(input *cloudwatchlogs.DescribeLogGroupsInput, fn func(*cloudwatchlogs.DescribeLogGroupsOutput, bool) bool) error {
+	return mockPages(m.DescribeLogGroupsPagesResp, m.DescribeLogGroupsPagesError, fn)
+}
+
+func (m mockedCloudwatchLogsClient) DescribeSubscriptionFiltersPages(input *cloudwatchlogs.DescribeSubscriptionFiltersInput, fn func(*cloudwatchlogs.DescribeSubscriptionFiltersOutput, bool) bool) error {
+	return mockPages(m.DescribeSubscriptionFiltersPagesResp, m.DescribeSubscriptionFiltersPagesError, fn)
+}
+
+func (m mockedCloudwatchLogsClient) DescribeMetricFiltersPages(input *cloudwatchlogs.DescribeMetricFiltersInput, fn func(*cloudwatchlogs.DescribeMetricFiltersOutput, bool) bool) error {
+	return mockPages(m.DescribeMetricFiltersPagesResp, m.DescribeMetricFiltersPagesError, fn)
+}
+
+func (m mockedCloudwatchLogsClient) DescribeResourcePolicies(input *cloudwatchlogs.DescribeResourcePoliciesInput) (*cloudwatchlogs.DescribeResourcePoliciesOutput, error) {
+	return &m.DescribeResourcePoliciesResp, m.DescribeResourcePoliciesError
+}
+
+var mockedLogGroups = cloudwatchlogs.DescribeLogGroupsOutput{
+	LogGroups: []*cloudwatchlogs.LogGroup{
+		{LogGroupName: aws.String("foo")},
+		{LogGroupName: aws.String("bar")},
+	},
+}
+
+func TestNewCloudwatchLogsCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewCloudwatchLogsChecker())
+}
+
+func TestGetCloudwatchLogGroupsUsage(t *testing.T) {
+	t.Cleanup(func() { cloudwatchLogGroups = []*cloudwatchlogs.LogGroup{} })
+	conf.CloudwatchLogs = mockedCloudwatchLogsClient{DescribeLogGroupsPagesResp: mockedLogGroups}
+	svcChecker := newTestServiceChecker(NewCloudwatchLogsChecker, NewQuota("logs", "Log groups", float64(500), false))
+	actual := svcChecker.getCloudwatchLogGroupsUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "logs", actual[0].Service)
+	assert.Equal(t, float64(500), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+}
+
+func TestGetCloudwatchLogGroupsUsageError(t *testing.T) {
+	t.Cleanup(func() { cloudwatchLogGroups = []*cloudwatchlogs.LogGroup{} })
+	conf.CloudwatchLogs = mockedCloudwatchLogsClient{DescribeLogGroupsPagesError: errors.New("test error")}
+	svcChecker := newTestServiceChecker(NewCloudwatchLogsChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getCloudwatchLogGroupsUsage())
+	assert.Equal(t, expected, svcChecker.getCloudwatchLogsSubscriptionFiltersUsage())
+	assert.Equal(t, expected, svcChecker.getCloudwatchLogsMetricFiltersUsage())
+}
+
+func TestGetCloudwatchLogsSubscriptionFiltersUsage(t *testing.T) {
+	t.Cleanup(func() { cloudwatchLogGroups = []*cloudwatchlogs.LogGroup{} })
+	conf.CloudwatchLogs = mockedCloudwatchLogsClient{
+		DescribeLogGroupsPagesResp: mockedLogGroups,
+		DescribeSubscriptionFiltersPagesResp: cloudwatchlogs.DescribeSubscriptionFiltersOutput{
+			SubscriptionFilters: []*cloudwatchlogs.SubscriptionFilter{{}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewCloudwatchLogsChecker)
+	actual := svcChecker.getCloudwatchLogsSubscriptionFiltersUsage()
+
+	assert.Len(t, actual, 2)
+	assert.Equal(t, "AWS::Logs::LogGroup::foo", actual[0].ResourceId)
+	assert.Equal(t, float64(2), actual[0].QuotaValue)
+	assert.Equal(t, float64(1), actual[0].UsageValue)
+}
+
+func TestGetCloudwatchLogsSubscriptionFiltersUsageError(t *testing.T) {
+	t.Cleanup(func() { cloudwatchLogGroups = []*cloudwatchlogs.LogGroup{} })
+	conf.CloudwatchLogs = mockedCloudwatchLogsClient{
+		DescribeLogGroupsPagesResp:            mockedLogGroups,
+		DescribeSubscriptionFiltersPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewCloudwatchLogsChecker)
+	actual := svcChecker.getCloudwatchLogsSubscriptionFiltersUsage()
+
+	assert.Len(t, actual, 0)
+}
+
+func TestGetCloudwatchLogsMetricFiltersUsage(t *testing.T) {
+	t.Cleanup(func() { cloudwatchLogGroups = []*cloudwatchlogs.LogGroup{} })
+	conf.CloudwatchLogs = mockedCloudwatchLogsClient{
+		DescribeLogGroupsPagesResp: mockedLogGroups,
+		DescribeMetricFiltersPagesResp: cloudwatchlogs.DescribeMetricFiltersOutput{
+			MetricFilters: []*cloudwatchlogs.MetricFilter{
+				{LogGroupName: aws.String("bar")},
+				{LogGroupName: aws.String("bar")},
+				{LogGroupName: aws.String("bar")},
+			},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewCloudwatchLogsChecker)
+	actual := svcChecker.getCloudwatchLogsMetricFiltersUsage()
+
+	assert.Len(t, actual, 2)
+	assert.Equal(t, "AWS::Logs::LogGroup::foo", actual[0].ResourceId)
+	assert.Equal(t, float64(0), actual[0].UsageValue)
+	assert.Equal(t, "AWS::Logs::LogGroup::bar", actual[1].ResourceId)
+	assert.Equal(t, float64(100), actual[1].QuotaValue)
+	assert.Equal(t, float64(3), actual[1].UsageValue)
+}
+
+func TestGetCloudwatchLogsMetricFiltersUsageError(t *testing.T) {
+	t.Cleanup(func() { cloudwatchLogGroups = []*cloudwatchlogs.LogGroup{} })
+	conf.CloudwatchLogs = mockedCloudwatchLogsClient{
+		DescribeLogGroupsPagesResp:      mockedLogGroups,
+		DescribeMetricFiltersPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewCloudwatchLogsChecker)
+	actual := svcChecker.getCloudwatchLogsMetricFiltersUsage()
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, actual)
+}
+
+func TestGetCloudwatchLogsResourcePoliciesUsage(t *testing.T) {
+	conf.CloudwatchLogs = mockedCloudwatchLogsClient{
+		DescribeResourcePoliciesResp: cloudwatchlogs.DescribeResourcePoliciesOutput{
+			ResourcePolicies: []*cloudwatchlogs.ResourcePolicy{{}, {}, {}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewCloudwatchLogsChecker)
+	actual := svcChecker.getCloudwatchLogsResourcePoliciesUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, float64(10), actual[0].QuotaValue)
+	assert.Equal(t, float64(3), actual[0].UsageValue)
+}
+
+func TestGetCloudwatchLogsResourcePoliciesUsageError(t *testing.T) {
+	conf.CloudwatchLogs = mockedCloudwatchLogsClient{DescribeResourcePoliciesError: errors.New("test error")}
+	svcChecker := newTestServiceChecker(NewCloudwatchLogsChecker)
+	actual := svcChecker.getCloudwatchLogsResourcePoliciesUsage()
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, actual)
+}
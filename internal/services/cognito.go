@@ -0,0 +1,162 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cognitoidentity"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+)
+
+type CognitoIdpClientInterface interface {
+	ListUserPoolsPages(input *cognitoidentityprovider.ListUserPoolsInput, fn func(*cognitoidentityprovider.ListUserPoolsOutput, bool) bool) error
+	ListUserPoolClientsPages(input *cognitoidentityprovider.ListUserPoolClientsInput, fn func(*cognitoidentityprovider.ListUserPoolClientsOutput, bool) bool) error
+	DescribeUserPool(input *cognitoidentityprovider.DescribeUserPoolInput) (*cognitoidentityprovider.DescribeUserPoolOutput, error)
+}
+
+type CognitoIdentityClientInterface interface {
+	ListIdentityPoolsPages(input *cognitoidentity.ListIdentityPoolsInput, fn func(*cognitoidentity.ListIdentityPoolsOutput, bool) bool) error
+}
+
+// maximum page size accepted by the cognito list apis
+const cognitoMaxResults = 60
+
+var cognitoIdpDefaultQuotas = map[string]quotaDefault{
+	"User pools":                {Value: 1000},
+	"App clients per user pool": {Value: 1000},
+	"Users per user pool":       {Value: 40000000},
+}
+
+var cognitoIdentityDefaultQuotas = map[string]quotaDefault{
+	"Identity pools": {Value: 1000},
+}
+
+func NewCognitoIdpChecker() Svcquota {
+	serviceCode := "cognito-idp"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"User pools":                ServiceChecker.getCognitoUserPoolsUsage,
+		"App clients per user pool": ServiceChecker.getCognitoAppClientsPerUserPoolUsage,
+		"Users per user pool":       ServiceChecker.getCognitoUsersPerUserPoolUsage,
+	}
+	requiredPermissions := []string{
+		"cognito-idp:ListUserPools",
+		"cognito-idp:ListUserPoolClients",
+		"cognito-idp:DescribeUserPool",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+// identity pools belong to a different service code than user pools, hence a
+// checker of their own
+func NewCognitoIdentityChecker() Svcquota {
+	serviceCode := "cognito-identity"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Identity pools": ServiceChecker.getCognitoIdentityPoolsUsage,
+	}
+	requiredPermissions := []string{"cognito-identity:ListIdentityPools"}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func getCognitoUserPools() (ret []*cognitoidentityprovider.UserPoolDescriptionType, err error) {
+	ret = []*cognitoidentityprovider.UserPoolDescriptionType{}
+	input := &cognitoidentityprovider.ListUserPoolsInput{MaxResults: aws.Int64(cognitoMaxResults)}
+	err = conf.CognitoIdp.ListUserPoolsPages(input, func(p *cognitoidentityprovider.ListUserPoolsOutput, lastPage bool) bool {
+		ret = append(ret, p.UserPools...)
+		return true // continue paging
+	})
+	return
+}
+
+func cognitoUserPoolResourceId(userPool *cognitoidentityprovider.UserPoolDescriptionType) string {
+	return fmt.Sprintf("AWS::Cognito::UserPool::%s", aws.StringValue(userPool.Id))
+}
+
+func (c ServiceChecker) getCognitoUserPoolsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	userPools, err := getCognitoUserPools()
+	if err != nil {
+		fmt.Printf("failed to retrieve cognito user pools, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("User pools", cognitoIdpDefaultQuotas["User pools"])
+	quotaInfo.UsageValue = float64(len(userPools))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getCognitoAppClientsPerUserPoolUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	userPools, err := getCognitoUserPools()
+	if err != nil {
+		fmt.Printf("failed to retrieve cognito user pools, %v", err)
+		return
+	}
+
+	for _, userPool := range userPools {
+		quotaInfo := c.getAppliedQuotaOrDefault("App clients per user pool", cognitoIdpDefaultQuotas["App clients per user pool"])
+		clients := 0
+		input := &cognitoidentityprovider.ListUserPoolClientsInput{UserPoolId: userPool.Id, MaxResults: aws.Int64(cognitoMaxResults)}
+		errClients := conf.CognitoIdp.ListUserPoolClientsPages(input, func(p *cognitoidentityprovider.ListUserPoolClientsOutput, lastPage bool) bool {
+			clients += len(p.UserPoolClients)
+			return true // continue paging
+		})
+		if errClients != nil {
+			fmt.Printf("failed to retrieve app clients for user pool %s, %v", aws.StringValue(userPool.Id), errClients)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(clients)
+		quotaInfo.ResourceId = cognitoUserPoolResourceId(userPool)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+// getCognitoUsersPerUserPoolUsage relies on the estimated number of users of
+// each pool, listing millions of users is not an option
+func (c ServiceChecker) getCognitoUsersPerUserPoolUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	userPools, err := getCognitoUserPools()
+	if err != nil {
+		fmt.Printf("failed to retrieve cognito user pools, %v", err)
+		return
+	}
+
+	for _, userPool := range userPools {
+		quotaInfo := c.getAppliedQuotaOrDefault("Users per user pool", cognitoIdpDefaultQuotas["Users per user pool"])
+		result, errDescribe := conf.CognitoIdp.DescribeUserPool(&cognitoidentityprovider.DescribeUserPoolInput{UserPoolId: userPool.Id})
+		if errDescribe != nil {
+			fmt.Printf("failed to describe user pool %s, %v", aws.StringValue(userPool.Id), errDescribe)
+			continue
+		}
+
+		if result.UserPool != nil {
+			quotaInfo.UsageValue = float64(aws.Int64Value(result.UserPool.EstimatedNumberOfUsers))
+		}
+		quotaInfo.ResourceId = cognitoUserPoolResourceId(userPool)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getCognitoIdentityPoolsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	identityPools := 0
+	input := &cognitoidentity.ListIdentityPoolsInput{MaxResults: aws.Int64(cognitoMaxResults)}
+	err := conf.CognitoIdentity.ListIdentityPoolsPages(input, func(p *cognitoidentity.ListIdentityPoolsOutput, lastPage bool) bool {
+		identityPools += len(p.IdentityPools)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve cognito identity pools, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Identity pools", cognitoIdentityDefaultQuotas["Identity pools"])
+	quotaInfo.UsageValue = float64(identityPools)
+	ret = append(ret, quotaInfo)
+	return
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cognitoidentity"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedCognitoIdpClient struct {
+	CognitoIdpClientInterface
+	ListUserPoolsPagesResp        cognitoidentityprovider.ListUserPoolsOutput
+	ListUserPoolsPagesError       error
+	ListUserPoolClientsPagesResp  cognitoidentityprovider.ListUserPoolClientsOutput
+	ListUserPoolClientsPagesError error
+	DescribeUserPoolResp          cognitoidentityprovider.DescribeUserPoolOutput
+	DescribeUserPoolError         error
+}
+
+func (m mockedCognitoIdpClient) ListUserPoolsPages(input *cognitoidentityprovider.ListUserPoolsInput, fn func(*cognitoidentityprovider.ListUserPoolsOutput, bool) bool) error {
+	return mockPages(m.ListUserPoolsPagesResp, m.ListUserPoolsPagesError, fn)
+}
+
+func (m mockedCognitoIdpClient) ListUserPoolClientsPages(input *cognitoidentityprovider.ListUserPoolClientsInput, fn func(*cognitoidentityprovider.ListUserPoolClientsOutput, bool) bool) error {
+	return mockPages(m.ListUserPoolClientsPagesResp, m.ListUserPoolClientsPagesError, fn)
+}
+
+func (m mockedCognitoIdpClient) DescribeUserPool(input *cognitoidentityprovider.DescribeUserPoolInput) (*cognitoidentityprovider.DescribeUserPoolOutput, error) {
+	return &m.DescribeUserPoolResp, m.DescribeUserPoolError
+}
+
+type mockedCognitoIdentityClient struct {
+	CognitoIdentityClientInterface
+	ListIdentityPoolsPagesResp  cognitoidentity.ListIdentityPoolsOutput
+	ListIdentityPoolsPagesError error
+}
+
+func (m mockedCognitoIdentityClient) ListIdentityPoolsPages(input *cognitoidentity.ListIdentityPoolsInput, fn func(*cognitoidentity.ListIdentityPoolsOutput, bool) bool) error {
+	return mockPages(m.ListIdentityPoolsPagesResp, m.ListIdentityPoolsPagesError, fn)
+}
+
+func TestNewCognitoIdpCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewCognitoIdpChecker())
+}
+
+func TestNewCognitoIdentityCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewCognitoIdentityChecker())
+}
+
+func TestGetCognitoIdpUsage(t *testing.T) {
+	conf.CognitoIdp = mockedCognitoIdpClient{
+		ListUserPoolsPagesResp: cognitoidentityprovider.ListUserPoolsOutput{
+			UserPools: []*cognitoidentityprovider.UserPoolDescriptionType{
+				{Id: aws.String("eu-west-1_pool1")},
+				{Id: aws.String("eu-west-1_pool2")},
+			},
+		},
+		ListUserPoolClientsPagesResp: cognitoidentityprovider.ListUserPoolClientsOutput{
+			UserPoolClients: []*cognitoidentityprovider.UserPoolClientDescription{{}, {}, {}},
+		},
+		DescribeUserPoolResp: cognitoidentityprovider.DescribeUserPoolOutput{
+			UserPool: &cognitoidentityprovider.UserPoolType{EstimatedNumberOfUsers: aws.Int64(12345)},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewCognitoIdpChecker, NewQuota("cognito-idp", "User pools", float64(2000), false))
+
+	userPools := svcChecker.getCognitoUserPoolsUsage()
+	assert.Len(t, userPools, 1)
+	assert.Equal(t, "cognito-idp", userPools[0].Service)
+	assert.Equal(t, float64(2000), userPools[0].QuotaValue)
+	assert.Equal(t, float64(2), userPools[0].UsageValue)
+
+	appClients := svcChecker.getCognitoAppClientsPerUserPoolUsage()
+	assert.Len(t, appClients, 2)
+	assert.Equal(t, "AWS::Cognito::UserPool::eu-west-1_pool1", appClients[0].ResourceId)
+	assert.Equal(t, float64(1000), appClients[0].QuotaValue)
+	assert.Equal(t, float64(3), appClients[0].UsageValue)
+
+	users := svcChecker.getCognitoUsersPerUserPoolUsage()
+	assert.Len(t, users, 2)
+	assert.Equal(t, "AWS::Cognito::UserPool::eu-west-1_pool2", users[1].ResourceId)
+	assert.Equal(t, float64(40000000), users[1].QuotaValue)
+	assert.Equal(t, float64(12345), users[1].UsageValue)
+}
+
+func TestGetCognitoIdpUsageError(t *testing.T) {
+	conf.CognitoIdp = mockedCognitoIdpClient{
+		ListUserPoolsPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewCognitoIdpChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getCognitoUserPoolsUsage())
+	assert.Equal(t, expected, svcChecker.getCognitoAppClientsPerUserPoolUsage())
+	assert.Equal(t, expected, svcChecker.getCognitoUsersPerUserPoolUsage())
+}
+
+func TestGetCognitoIdpPerUserPoolUsageError(t *testing.T) {
+	conf.CognitoIdp = mockedCognitoIdpClient{
+		ListUserPoolsPagesResp: cognitoidentityprovider.ListUserPoolsOutput{
+			UserPools: []*cognitoidentityprovider.UserPoolDescriptionType{{Id: aws.String("eu-west-1_pool1")}},
+		},
+		ListUserPoolClientsPagesError: errors.New("test error"),
+		DescribeUserPoolError:         errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewCognitoIdpChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getCognitoAppClientsPerUserPoolUsage())
+	assert.Equal(t, expected, svcChecker.getCognitoUsersPerUserPoolUsage())
+}
+
+func TestGetCognitoIdentityUsage(t *testing.T) {
+	conf.CognitoIdentity = mockedCognitoIdentityClient{
+		ListIdentityPoolsPagesResp: cognitoidentity.ListIdentityPoolsOutput{
+			IdentityPools: []*cognitoidentity.IdentityPoolShortDescription{{}, {}, {}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewCognitoIdentityChecker)
+
+	identityPools := svcChecker.getCognitoIdentityPoolsUsage()
+	assert.Len(t, identityPools, 1)
+	assert.Equal(t, "cognito-identity", identityPools[0].Service)
+	assert.Equal(t, float64(1000), identityPools[0].QuotaValue)
+	assert.Equal(t, float64(3), identityPools[0].UsageValue)
+}
+
+func TestGetCognitoIdentityUsageError(t *testing.T) {
+	conf.CognitoIdentity = mockedCognitoIdentityClient{
+		ListIdentityPoolsPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewCognitoIdentityChecker)
+
+	assert.Equal(t, []AWSQuotaInfo{}, svcChecker.getCognitoIdentityPoolsUsage())
+}
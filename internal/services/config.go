@@ -0,0 +1,85 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/configservice"
+)
+
+type ConfigServiceClientInterface interface {
+	DescribeConfigRulesPages(input *configservice.DescribeConfigRulesInput, fn func(*configservice.DescribeConfigRulesOutput, bool) bool) error
+	DescribeConformancePacksPages(input *configservice.DescribeConformancePacksInput, fn func(*configservice.DescribeConformancePacksOutput, bool) bool) error
+	DescribeConfigurationRecorders(input *configservice.DescribeConfigurationRecordersInput) (*configservice.DescribeConfigurationRecordersOutput, error)
+}
+
+var configDefaultQuotas = map[string]quotaDefault{
+	"Maximum number of AWS Config Rules":              {Value: 1000},
+	"Maximum number of conformance packs per account": {Value: 50},
+	"Maximum number of configuration recorders":       {Value: 1},
+}
+
+func NewConfigChecker() Svcquota {
+	serviceCode := "config"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Maximum number of AWS Config Rules":              ServiceChecker.getConfigRulesUsage,
+		"Maximum number of conformance packs per account": ServiceChecker.getConfigConformancePacksUsage,
+		"Maximum number of configuration recorders":       ServiceChecker.getConfigConfigurationRecordersUsage,
+	}
+	requiredPermissions := []string{
+		"config:DescribeConfigRules",
+		"config:DescribeConformancePacks",
+		"config:DescribeConfigurationRecorders",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func (c ServiceChecker) getConfigRulesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	rules := 0
+	err := conf.ConfigService.DescribeConfigRulesPages(&configservice.DescribeConfigRulesInput{}, func(p *configservice.DescribeConfigRulesOutput, lastPage bool) bool {
+		rules += len(p.ConfigRules)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve config rules, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Maximum number of AWS Config Rules", configDefaultQuotas["Maximum number of AWS Config Rules"])
+	quotaInfo.UsageValue = float64(rules)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getConfigConformancePacksUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	conformancePacks := 0
+	err := conf.ConfigService.DescribeConformancePacksPages(&configservice.DescribeConformancePacksInput{}, func(p *configservice.DescribeConformancePacksOutput, lastPage bool) bool {
+		conformancePacks += len(p.ConformancePackDetails)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve config conformance packs, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Maximum number of conformance packs per account", configDefaultQuotas["Maximum number of conformance packs per account"])
+	quotaInfo.UsageValue = float64(conformancePacks)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getConfigConfigurationRecordersUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	result, err := conf.ConfigService.DescribeConfigurationRecorders(&configservice.DescribeConfigurationRecordersInput{})
+	if err != nil {
+		fmt.Printf("failed to retrieve config configuration recorders, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Maximum number of configuration recorders", configDefaultQuotas["Maximum number of configuration recorders"])
+	quotaInfo.UsageValue = float64(len(result.ConfigurationRecorders))
+	ret = append(ret, quotaInfo)
+	return
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedConfigServiceClient struct {
+	ConfigServiceClientInterface
+	DescribeConfigRulesPagesResp        configservice.DescribeConfigRulesOutput
+	DescribeConfigRulesPagesError       error
+	DescribeConformancePacksPagesResp   configservice.DescribeConformancePacksOutput
+	DescribeConformancePacksPagesError  error
+	DescribeConfigurationRecordersResp  configservice.DescribeConfigurationRecordersOutput
+	DescribeConfigurationRecordersError error
+}
+
+func (m mockedConfigServiceClient) DescribeConfigRulesPages(input *configservice.DescribeConfigRulesInput, fn func(*configservice.DescribeConfigRulesOutput, bool) bool) error {
+	return mockPages(m.DescribeConfigRulesPagesResp, m.DescribeConfigRulesPagesError, fn)
+}
+
+func (m mockedConfigServiceClient) DescribeConformancePacksPages(input *configservice.DescribeConformancePacksInput, fn func(*configservice.DescribeConformancePacksOutput, bool) bool) error {
+	return mockPages(m.DescribeConformancePacksPagesResp, m.DescribeConformancePacksPagesError, fn)
+}
+
+func (m mockedConfigServiceClient) DescribeConfigurationRecorders(input *configservice.DescribeConfigurationRecordersInput) (*configservice.DescribeConfigurationRecordersOutput, error) {
+	return &m.DescribeConfigurationRecordersResp, m.DescribeConfigurationRecordersError
+}
+
+func TestNewConfigCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewConfigChecker())
+}
+
+func TestGetConfigUsage(t *testing.T) {
+	conf.ConfigService = mockedConfigServiceClient{
+		DescribeConfigRulesPagesResp: configservice.DescribeConfigRulesOutput{
+			ConfigRules: []*configservice.ConfigRule{{}, {}, {}, {}},
+		},
+		DescribeConformancePacksPagesResp: configservice.DescribeConformancePacksOutput{
+			ConformancePackDetails: []*configservice.ConformancePackDetail{{}, {}},
+		},
+		DescribeConfigurationRecordersResp: configservice.DescribeConfigurationRecordersOutput{
+			ConfigurationRecorders: []*configservice.ConfigurationRecorder{{}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewConfigChecker, NewQuota("config", "Maximum number of AWS Config Rules", float64(1500), false))
+
+	rules := svcChecker.getConfigRulesUsage()
+	assert.Len(t, rules, 1)
+	assert.Equal(t, "config", rules[0].Service)
+	assert.Equal(t, float64(1500), rules[0].QuotaValue)
+	assert.Equal(t, float64(4), rules[0].UsageValue)
+
+	conformancePacks := svcChecker.getConfigConformancePacksUsage()
+	assert.Len(t, conformancePacks, 1)
+	assert.Equal(t, float64(50), conformancePacks[0].QuotaValue)
+	assert.Equal(t, float64(2), conformancePacks[0].UsageValue)
+
+	recorders := svcChecker.getConfigConfigurationRecordersUsage()
+	assert.Len(t, recorders, 1)
+	assert.Equal(t, float64(1), recorders[0].QuotaValue)
+	assert.Equal(t, float64(1), recorders[0].UsageValue)
+}
+
+func TestGetConfigUsageError(t *testing.T) {
+	conf.ConfigService = mockedConfigServiceClient{
+		DescribeConfigRulesPagesError:       errors.New("test error"),
+		DescribeConformancePacksPagesError:  errors.New("test error"),
+		DescribeConfigurationRecordersError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewConfigChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getConfigRulesUsage())
+	assert.Equal(t, expected, svcChecker.getConfigConformancePacksUsage())
+	assert.Equal(t, expected, svcChecker.getConfigConfigurationRecordersUsage())
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+)
+
+type DirectConnectClientInterface interface {
+	DescribeConnections(input *directconnect.DescribeConnectionsInput) (*directconnect.Connections, error)
+	DescribeVirtualInterfaces(input *directconnect.DescribeVirtualInterfacesInput) (*directconnect.DescribeVirtualInterfacesOutput, error)
+}
+
+var directConnectDefaultQuotas = map[string]quotaDefault{
+	"Dedicated connections per Region per account":                                 {Value: 10},
+	"Private or public virtual interfaces per Direct Connect dedicated connection": {Value: 50},
+	"Transit virtual interfaces per Direct Connect dedicated connection":           {Value: 4},
+}
+
+func NewDirectConnectChecker() Svcquota {
+	serviceCode := "directconnect"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Dedicated connections per Region per account":                                 ServiceChecker.getDirectConnectConnectionsUsage,
+		"Private or public virtual interfaces per Direct Connect dedicated connection": ServiceChecker.getDirectConnectPrivatePublicVifsUsage,
+		"Transit virtual interfaces per Direct Connect dedicated connection":           ServiceChecker.getDirectConnectTransitVifsUsage,
+	}
+	requiredPermissions := []string{
+		"directconnect:DescribeConnections",
+		"directconnect:DescribeVirtualInterfaces",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func isDirectConnectConnectionActive(connection *directconnect.Connection) bool {
+	switch aws.StringValue(connection.ConnectionState) {
+	case directconnect.ConnectionStateDeleted, directconnect.ConnectionStateRejected:
+		return false
+	}
+	return true
+}
+
+func getDirectConnectConnections() (ret []*directconnect.Connection, err error) {
+	ret = []*directconnect.Connection{}
+	result, err := conf.DirectConnect.DescribeConnections(&directconnect.DescribeConnectionsInput{})
+	if err != nil {
+		return
+	}
+	for _, connection := range result.Connections {
+		if isDirectConnectConnectionActive(connection) {
+			ret = append(ret, connection)
+		}
+	}
+	return
+}
+
+func (c ServiceChecker) getDirectConnectConnectionsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	connections, err := getDirectConnectConnections()
+	if err != nil {
+		fmt.Printf("failed to retrieve direct connect connections, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Dedicated connections per Region per account", directConnectDefaultQuotas["Dedicated connections per Region per account"])
+	quotaInfo.UsageValue = float64(len(connections))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+// getDirectConnectVifsPerConnectionUsage reports, for every connection, the
+// number of virtual interfaces of the given types
+func (c ServiceChecker) getDirectConnectVifsPerConnectionUsage(quotaName string, vifTypes ...string) (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	connections, err := getDirectConnectConnections()
+	if err != nil {
+		fmt.Printf("failed to retrieve direct connect connections, %v", err)
+		return
+	}
+
+	// virtual interfaces are listed for the whole region at once and grouped by
+	// connection
+	result, err := conf.DirectConnect.DescribeVirtualInterfaces(&directconnect.DescribeVirtualInterfacesInput{})
+	if err != nil {
+		fmt.Printf("failed to retrieve direct connect virtual interfaces, %v", err)
+		return
+	}
+	vifsPerConnection := map[string]int{}
+	for _, vif := range result.VirtualInterfaces {
+		switch aws.StringValue(vif.VirtualInterfaceState) {
+		case directconnect.VirtualInterfaceStateDeleted, directconnect.VirtualInterfaceStateRejected:
+			continue
+		}
+		for _, t := range vifTypes {
+			if aws.StringValue(vif.VirtualInterfaceType) == t {
+				vifsPerConnection[aws.StringValue(vif.ConnectionId)]++
+			}
+		}
+	}
+
+	for _, connection := range connections {
+		quotaInfo := c.getAppliedQuotaOrDefault(quotaName, directConnectDefaultQuotas[quotaName])
+		quotaInfo.UsageValue = float64(vifsPerConnection[aws.StringValue(connection.ConnectionId)])
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::DirectConnect::Connection::%s", aws.StringValue(connection.ConnectionId))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getDirectConnectPrivatePublicVifsUsage() (ret []AWSQuotaInfo) {
+	return c.getDirectConnectVifsPerConnectionUsage("Private or public virtual interfaces per Direct Connect dedicated connection", "private", "public")
+}
+
+func (c ServiceChecker) getDirectConnectTransitVifsUsage() (ret []AWSQuotaInfo) {
+	return c.getDirectConnectVifsPerConnectionUsage("Transit virtual interfaces per Direct Connect dedicated connection", "transit")
+}
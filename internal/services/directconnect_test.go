@@ -0,0 +1,97 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedDirectConnectClient struct {
+	DirectConnectClientInterface
+	DescribeConnectionsResp        directconnect.Connections
+	DescribeConnectionsError       error
+	DescribeVirtualInterfacesResp  directconnect.DescribeVirtualInterfacesOutput
+	DescribeVirtualInterfacesError error
+}
+
+func (m mockedDirectConnectClient) DescribeConnections(input *directconnect.DescribeConnectionsInput) (*directconnect.Connections, error) {
+	return &m.DescribeConnectionsResp, m.DescribeConnectionsError
+}
+
+func (m mockedDirectConnectClient) DescribeVirtualInterfaces(input *directconnect.DescribeVirtualInterfacesInput) (*directconnect.DescribeVirtualInterfacesOutput, error) {
+	return &m.DescribeVirtualInterfacesResp, m.DescribeVirtualInterfacesError
+}
+
+var mockedDirectConnectConnections = directconnect.Connections{
+	Connections: []*directconnect.Connection{
+		{ConnectionId: aws.String("dxcon-foo"), ConnectionState: aws.String(directconnect.ConnectionStateAvailable)},
+		{ConnectionId: aws.String("dxcon-bar"), ConnectionState: aws.String(directconnect.ConnectionStateDeleted)},
+	},
+}
+
+func TestNewDirectConnectCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewDirectConnectChecker())
+}
+
+func TestGetDirectConnectConnectionsUsage(t *testing.T) {
+	conf.DirectConnect = mockedDirectConnectClient{DescribeConnectionsResp: mockedDirectConnectConnections}
+	svcChecker := newTestServiceChecker(NewDirectConnectChecker, NewQuota("directconnect", "Dedicated connections per Region per account", float64(20), false))
+	actual := svcChecker.getDirectConnectConnectionsUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "directconnect", actual[0].Service)
+	assert.Equal(t, float64(20), actual[0].QuotaValue)
+	assert.Equal(t, float64(1), actual[0].UsageValue)
+}
+
+func TestGetDirectConnectConnectionsUsageError(t *testing.T) {
+	conf.DirectConnect = mockedDirectConnectClient{DescribeConnectionsError: errors.New("test error")}
+	svcChecker := newTestServiceChecker(NewDirectConnectChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getDirectConnectConnectionsUsage())
+	assert.Equal(t, expected, svcChecker.getDirectConnectPrivatePublicVifsUsage())
+	assert.Equal(t, expected, svcChecker.getDirectConnectTransitVifsUsage())
+}
+
+func TestGetDirectConnectVifsUsage(t *testing.T) {
+	conf.DirectConnect = mockedDirectConnectClient{
+		DescribeConnectionsResp: mockedDirectConnectConnections,
+		DescribeVirtualInterfacesResp: directconnect.DescribeVirtualInterfacesOutput{
+			VirtualInterfaces: []*directconnect.VirtualInterface{
+				{ConnectionId: aws.String("dxcon-foo"), VirtualInterfaceType: aws.String("private"), VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable)},
+				{ConnectionId: aws.String("dxcon-foo"), VirtualInterfaceType: aws.String("public"), VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable)},
+				{ConnectionId: aws.String("dxcon-foo"), VirtualInterfaceType: aws.String("private"), VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateDeleted)},
+				{ConnectionId: aws.String("dxcon-foo"), VirtualInterfaceType: aws.String("transit"), VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable)},
+			},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewDirectConnectChecker)
+
+	privatePublic := svcChecker.getDirectConnectPrivatePublicVifsUsage()
+	assert.Len(t, privatePublic, 1)
+	assert.Equal(t, "AWS::DirectConnect::Connection::dxcon-foo", privatePublic[0].ResourceId)
+	assert.Equal(t, float64(50), privatePublic[0].QuotaValue)
+	assert.Equal(t, float64(2), privatePublic[0].UsageValue)
+
+	transit := svcChecker.getDirectConnectTransitVifsUsage()
+	assert.Len(t, transit, 1)
+	assert.Equal(t, float64(4), transit[0].QuotaValue)
+	assert.Equal(t, float64(1), transit[0].UsageValue)
+}
+
+func TestGetDirectConnectVifsUsageError(t *testing.T) {
+	conf.DirectConnect = mockedDirectConnectClient{
+		DescribeConnectionsResp:        mockedDirectConnectConnections,
+		DescribeVirtualInterfacesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewDirectConnectChecker)
+	actual := svcChecker.getDirectConnectPrivatePublicVifsUsage()
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,5 @@
+package services
+
+func NewDocDbChecker() Svcquota {
+	return newRdsFamilyChecker("docdb", "AWS::DocDB::DBCluster")
+}
@@ -27,7 +27,7 @@ func (c ServiceChecker) getDynanoDBTableUsage() (ret []AWSQuotaInfo) {
 		tableNames = append(tableNames, p.TableNames...)
 		return true // continue paging
 	})
-	quotaInfo := c.GetAllAppliedQuotas()["Maximum number of tables"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Maximum number of tables", quotaDefault{})
 
 	if err != nil {
 		fmt.Printf("failed to retrieve dynamodb tables, %v", err)
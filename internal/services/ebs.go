@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -20,26 +21,188 @@ func NewEbsChecker() Svcquota {
 		"Storage for General Purpose SSD (gp3) volumes, in TiB":      ServiceChecker.getEbsGp3SizeUsage,
 		"Storage for Magnetic (standard) volumes, in TiB":            ServiceChecker.getEbsStandardSizeUsage,
 		"Storage for Throughput Optimized HDD (st1) volumes, in TiB": ServiceChecker.getEbsSt1SizeUsage,
+		"Archived snapshots per volume":                              ServiceChecker.getEbsArchivedSnapshotsPerVolumeUsage,
+		"Concurrent snapshot copies per destination Region":          ServiceChecker.getEbsConcurrentSnapshotCopiesUsage,
+		"Fast snapshot restores per Availability Zone":               ServiceChecker.getEbsFastSnapshotRestoresUsage,
+		"Throughput for General Purpose SSD (gp3) volumes, in MiB/s": ServiceChecker.getEbsGp3ThroughputUsage,
 	}
-	requiredPermissions := []string{"ec2:DescribeSnapshots", "ec2:DescribeVolumes"}
+	requiredPermissions := []string{"ec2:DescribeSnapshots", "ec2:DescribeVolumes", "ec2:DescribeFastSnapshotRestores"}
 
 	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
 }
 
+var ebsDefaultQuotas = map[string]quotaDefault{
+	"Snapshots per Region":                              {Value: 100000, Code: "L-309BACF6"},
+	"Archived snapshots per volume":                     {Value: 25},
+	"Concurrent snapshot copies per destination Region": {Value: 20},
+	"Fast snapshot restores per Availability Zone":      {Value: 5},
+}
+
+var ebsSnapshots []*ec2.Snapshot = []*ec2.Snapshot{}
+
+// getEbsSnapshots lists all the snapshots owned by the account once, and
+// shares the result between the different snapshot quotas
+func getEbsSnapshots() (ret []*ec2.Snapshot, err error) {
+	ret = ebsSnapshots
+	if len(ebsSnapshots) != 0 {
+		return
+	}
+
+	err = conf.Ec2.DescribeSnapshotsPages(&ec2.DescribeSnapshotsInput{OwnerIds: []*string{aws.String("self")}}, func(p *ec2.DescribeSnapshotsOutput, lastPage bool) bool {
+		ebsSnapshots = append(ebsSnapshots, p.Snapshots...)
+		return true // continue paging
+	})
+	if err != nil {
+		ebsSnapshots = []*ec2.Snapshot{}
+		return ebsSnapshots, err
+	}
+	return ebsSnapshots, nil
+}
+
+func isEbsSnapshotArchived(s *ec2.Snapshot) bool {
+	return aws.StringValue(s.StorageTier) == ec2.StorageTierArchive
+}
+
 func (c ServiceChecker) getEbsSnapshotsUsage() (ret []AWSQuotaInfo) {
 	ret = []AWSQuotaInfo{}
-	snapshots := []*ec2.Snapshot{}
+	snapshots, err := getEbsSnapshots()
+	if err != nil {
+		fmt.Printf("failed to retrieve ec2 ebs snapshots, %v", err)
+		return
+	}
+
+	// archived snapshots have their own quota and do not count against this one
+	count := 0
+	for _, s := range snapshots {
+		if !isEbsSnapshotArchived(s) {
+			count++
+		}
+	}
+	quotaInfo := c.getAppliedQuotaOrDefault("Snapshots per Region", ebsDefaultQuotas["Snapshots per Region"])
+	quotaInfo.UsageValue = float64(count)
+
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getEbsArchivedSnapshotsPerVolumeUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	snapshots, err := getEbsSnapshots()
+	if err != nil {
+		fmt.Printf("failed to retrieve ec2 ebs snapshots, %v", err)
+		return
+	}
+
+	archivedPerVolume := map[string]int{}
+	volumeIds := []string{}
+	for _, s := range snapshots {
+		if !isEbsSnapshotArchived(s) {
+			continue
+		}
+		volumeId := aws.StringValue(s.VolumeId)
+		if _, ok := archivedPerVolume[volumeId]; !ok {
+			volumeIds = append(volumeIds, volumeId)
+		}
+		archivedPerVolume[volumeId]++
+	}
+
+	for _, volumeId := range volumeIds {
+		quotaInfo := c.getAppliedQuotaOrDefault("Archived snapshots per volume", ebsDefaultQuotas["Archived snapshots per volume"])
+		quotaInfo.UsageValue = float64(archivedPerVolume[volumeId])
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::EC2::Volume::%s", volumeId)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+// ebsCopiedSnapshotVolumeId is the arbitrary volume id of the snapshots created
+// by CopySnapshot
+const ebsCopiedSnapshotVolumeId = "vol-ffffffff"
+
+// isEbsSnapshotCopy returns whether the snapshot was created by a copy, either
+// from its arbitrary volume id or from the default copy description
+func isEbsSnapshotCopy(s *ec2.Snapshot) bool {
+	return aws.StringValue(s.VolumeId) == ebsCopiedSnapshotVolumeId ||
+		strings.HasPrefix(aws.StringValue(s.Description), "[Copied ")
+}
+
+func (c ServiceChecker) getEbsConcurrentSnapshotCopiesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	snapshots, err := getEbsSnapshots()
+	if err != nil {
+		fmt.Printf("failed to retrieve ec2 ebs snapshots, %v", err)
+		return
+	}
+
+	// copies in progress are the pending snapshots created by a copy, freshly
+	// created snapshots are pending as well
+	pending := 0
+	for _, s := range snapshots {
+		if aws.StringValue(s.State) == ec2.SnapshotStatePending && isEbsSnapshotCopy(s) {
+			pending++
+		}
+	}
+	quotaInfo := c.getAppliedQuotaOrDefault("Concurrent snapshot copies per destination Region", ebsDefaultQuotas["Concurrent snapshot copies per destination Region"])
+	quotaInfo.UsageValue = float64(pending)
+
+	ret = append(ret, quotaInfo)
+	return
+}
 
-	err := conf.Ec2.DescribeSnapshotsPages(&ec2.DescribeSnapshotsInput{OwnerIds: []*string{aws.String("self")}}, func(p *ec2.DescribeSnapshotsOutput, lastPage bool) bool {
-		snapshots = append(snapshots, p.Snapshots...)
+func (c ServiceChecker) getEbsFastSnapshotRestoresUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	restoresPerAz := map[string]int{}
+	azs := []string{}
+	err := conf.Ec2.DescribeFastSnapshotRestoresPages(&ec2.DescribeFastSnapshotRestoresInput{}, func(p *ec2.DescribeFastSnapshotRestoresOutput, lastPage bool) bool {
+		for _, r := range p.FastSnapshotRestores {
+			// disabled restores are still listed for a while, but no longer count
+			state := aws.StringValue(r.State)
+			if state == ec2.FastSnapshotRestoreStateCodeDisabling || state == ec2.FastSnapshotRestoreStateCodeDisabled {
+				continue
+			}
+			az := aws.StringValue(r.AvailabilityZone)
+			if _, ok := restoresPerAz[az]; !ok {
+				azs = append(azs, az)
+			}
+			restoresPerAz[az]++
+		}
 		return true // continue paging
 	})
 	if err != nil {
-		fmt.Printf("failed to retrieve ec2 ebs snapshots, %v", err)
+		fmt.Printf("failed to retrieve ec2 ebs fast snapshot restores, %v", err)
 		return
 	}
-	quotaInfo := c.GetAllAppliedQuotas()["Snapshots per Region"]
-	quotaInfo.UsageValue = float64(len(snapshots))
+
+	for _, az := range azs {
+		quotaInfo := c.getAppliedQuotaOrDefault("Fast snapshot restores per Availability Zone", ebsDefaultQuotas["Fast snapshot restores per Availability Zone"])
+		quotaInfo.UsageValue = float64(restoresPerAz[az])
+		quotaInfo.ResourceId = az
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getEbsGp3ThroughputUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	// the quota has no documented default, it is only reported when
+	// servicequotas returns it
+	quotaInfo, ok := c.GetAllAppliedQuotas()["Throughput for General Purpose SSD (gp3) volumes, in MiB/s"]
+	if !ok {
+		return
+	}
+	volumes, err := getEbsVolumes()
+	if err != nil {
+		fmt.Printf("failed to retrieve ec2 ebs gp3 volumes, %v", err)
+		return
+	}
+
+	throughput := 0
+	for _, v := range volumes {
+		if aws.StringValue(v.VolumeType) == ec2.VolumeTypeGp3 {
+			throughput += int(aws.Int64Value(v.Throughput))
+		}
+	}
+	quotaInfo.UsageValue = float64(throughput)
 
 	ret = append(ret, quotaInfo)
 	return
@@ -53,7 +216,7 @@ func (c ServiceChecker) getEbsIo1IopsUsage() (ret []AWSQuotaInfo) {
 		fmt.Printf("failed to retrieve ec2 ebs io1 volumes, %v", err)
 		return
 	}
-	quotaInfo := c.GetAllAppliedQuotas()["IOPS for Provisioned IOPS SSD (io1) volumes"]
+	quotaInfo := c.getAppliedQuotaOrDefault("IOPS for Provisioned IOPS SSD (io1) volumes", quotaDefault{Code: "L-B3A130E6"})
 	quotaInfo.UsageValue = float64(iops)
 
 	ret = append(ret, quotaInfo)
@@ -68,7 +231,7 @@ func (c ServiceChecker) getEbsIo1SizeUsage() (ret []AWSQuotaInfo) {
 		fmt.Printf("failed to retrieve ec2 ebs io1 volumes, %v", err)
 		return
 	}
-	quotaInfo := c.GetAllAppliedQuotas()["Storage for Provisioned IOPS SSD (io1) volumes, in TiB"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Storage for Provisioned IOPS SSD (io1) volumes, in TiB", quotaDefault{Code: "L-FD252861"})
 	quotaInfo.UsageValue = GiBtoTiB(float64(size))
 
 	ret = append(ret, quotaInfo)
@@ -83,7 +246,7 @@ func (c ServiceChecker) getEbsIo2IopsUsage() (ret []AWSQuotaInfo) {
 		fmt.Printf("failed to retrieve ec2 ebs io2 volumes, %v", err)
 		return
 	}
-	quotaInfo := c.GetAllAppliedQuotas()["IOPS for Provisioned IOPS SSD (io2) volumes"]
+	quotaInfo := c.getAppliedQuotaOrDefault("IOPS for Provisioned IOPS SSD (io2) volumes", quotaDefault{Code: "L-8D977E7E"})
 	quotaInfo.UsageValue = float64(iops)
 
 	ret = append(ret, quotaInfo)
@@ -98,7 +261,7 @@ func (c ServiceChecker) getEbsIo2SizeUsage() (ret []AWSQuotaInfo) {
 		fmt.Printf("failed to retrieve ec2 ebs io2 volumes, %v", err)
 		return
 	}
-	quotaInfo := c.GetAllAppliedQuotas()["Storage for Provisioned IOPS SSD (io2) volumes, in TiB"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Storage for Provisioned IOPS SSD (io2) volumes, in TiB", quotaDefault{Code: "L-09BD8365"})
 	quotaInfo.UsageValue = GiBtoTiB(float64(size))
 
 	ret = append(ret, quotaInfo)
@@ -113,7 +276,7 @@ func (c ServiceChecker) getEbsSc1SizeUsage() (ret []AWSQuotaInfo) {
 		fmt.Printf("failed to retrieve ec2 ebs sc1 volumes, %v", err)
 		return
 	}
-	quotaInfo := c.GetAllAppliedQuotas()["Storage for Cold HDD (sc1) volumes, in TiB"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Storage for Cold HDD (sc1) volumes, in TiB", quotaDefault{Code: "L-17AF77E8"})
 	quotaInfo.UsageValue = GiBtoTiB(float64(size))
 
 	ret = append(ret, quotaInfo)
@@ -128,7 +291,7 @@ func (c ServiceChecker) getEbsGp2SizeUsage() (ret []AWSQuotaInfo) {
 		fmt.Printf("failed to retrieve ec2 ebs gp2 volumes, %v", err)
 		return
 	}
-	quotaInfo := c.GetAllAppliedQuotas()["Storage for General Purpose SSD (gp2) volumes, in TiB"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Storage for General Purpose SSD (gp2) volumes, in TiB", quotaDefault{Code: "L-D18FCD1D"})
 	quotaInfo.UsageValue = GiBtoTiB(float64(size))
 
 	ret = append(ret, quotaInfo)
@@ -143,7 +306,7 @@ func (c ServiceChecker) getEbsGp3SizeUsage() (ret []AWSQuotaInfo) {
 		fmt.Printf("failed to retrieve ec2 ebs gp3 volumes, %v", err)
 		return
 	}
-	quotaInfo := c.GetAllAppliedQuotas()["Storage for General Purpose SSD (gp3) volumes, in TiB"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Storage for General Purpose SSD (gp3) volumes, in TiB", quotaDefault{Code: "L-7A658B76"})
 	quotaInfo.UsageValue = GiBtoTiB(float64(size))
 
 	ret = append(ret, quotaInfo)
@@ -158,7 +321,7 @@ func (c ServiceChecker) getEbsStandardSizeUsage() (ret []AWSQuotaInfo) {
 		fmt.Printf("failed to retrieve ec2 ebs standard volumes, %v", err)
 		return
 	}
-	quotaInfo := c.GetAllAppliedQuotas()["Storage for Magnetic (standard) volumes, in TiB"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Storage for Magnetic (standard) volumes, in TiB", quotaDefault{Code: "L-9CF3C2EB"})
 	quotaInfo.UsageValue = GiBtoTiB(float64(size))
 
 	ret = append(ret, quotaInfo)
@@ -173,36 +336,51 @@ func (c ServiceChecker) getEbsSt1SizeUsage() (ret []AWSQuotaInfo) {
 		fmt.Printf("failed to retrieve ec2 ebs st1 volumes, %v", err)
 		return
 	}
-	quotaInfo := c.GetAllAppliedQuotas()["Storage for Throughput Optimized HDD (st1) volumes, in TiB"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Storage for Throughput Optimized HDD (st1) volumes, in TiB", quotaDefault{Code: "L-82ACEF56"})
 	quotaInfo.UsageValue = GiBtoTiB(float64(size))
 
 	ret = append(ret, quotaInfo)
 	return
 }
 
+var ebsVolumes []*ec2.Volume = []*ec2.Volume{}
+
+// getEbsVolumes lists all the volumes of the region once, and shares the
+// result between the different volume quotas
+func getEbsVolumes() (ret []*ec2.Volume, err error) {
+	ret = ebsVolumes
+	if len(ebsVolumes) != 0 {
+		return
+	}
+
+	err = conf.Ec2.DescribeVolumesPages(&ec2.DescribeVolumesInput{}, func(p *ec2.DescribeVolumesOutput, lastPage bool) bool {
+		ebsVolumes = append(ebsVolumes, p.Volumes...)
+		return true // continue paging
+	})
+	if err != nil {
+		ebsVolumes = []*ec2.Volume{}
+		return ebsVolumes, err
+	}
+	return ebsVolumes, nil
+}
+
 func getEbsVolumeDetails(volumeType string) (iops int, size int, err error) {
 	iops = 0
 	size = 0
 
-	volumes := []*ec2.Volume{}
-	err = conf.Ec2.DescribeVolumesPages(
-		&ec2.DescribeVolumesInput{
-			Filters: []*ec2.Filter{
-				{Name: aws.String("volume-type"),
-					Values: []*string{aws.String(volumeType)},
-				}}},
-		func(p *ec2.DescribeVolumesOutput, lastPage bool) bool {
-			volumes = append(volumes, p.Volumes...)
-			return true // continue paging
-		})
+	volumes, err := getEbsVolumes()
 	if err != nil {
-		fmt.Printf("failed to retrieve ec2 ebs volumes, %v", err)
 		return
 	}
 
+	// not all volume types report iops (e.g. standard), hence the nil-safe
+	// accessors
 	for _, v := range volumes {
-		iops += int(*v.Iops)
-		size += int(*v.Size)
+		if aws.StringValue(v.VolumeType) != volumeType {
+			continue
+		}
+		iops += int(aws.Int64Value(v.Iops))
+		size += int(aws.Int64Value(v.Size))
 	}
 	return
 }
@@ -11,6 +11,20 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// setEbsTestConf sets the clients the ebs checker uses, and restores them and
+// the shared inventories once the test is done
+func setEbsTestConf(t *testing.T, ec2Client mockedEc2Client, quotas []*servicequotas.ServiceQuota) {
+	ec2Before, serviceQuotasBefore := conf.Ec2, conf.ServiceQuotas
+	conf.Ec2 = ec2Client
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(quotas, nil)
+	t.Cleanup(func() {
+		conf.Ec2, conf.ServiceQuotas = ec2Before, serviceQuotasBefore
+		resetServiceQuotas()
+		ebsSnapshots = []*ec2.Snapshot{}
+		ebsVolumes = []*ec2.Volume{}
+	})
+}
+
 func TestNewEbsCheckerImpl(t *testing.T) {
 	require.Implements(t, (*Svcquota)(nil), NewEbsChecker())
 }
@@ -19,11 +33,7 @@ func TestGetEbsSnapshotsUsage(t *testing.T) {
 	mockedOutput := ec2.DescribeSnapshotsOutput{
 		Snapshots: []*ec2.Snapshot{{SnapshotId: aws.String("foo")}},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeSnapshotsPagesResp: mockedOutput}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Snapshots per Region", float64(100), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeSnapshotsPagesResp: mockedOutput}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Snapshots per Region", float64(100), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -40,11 +50,7 @@ func TestGetEbsSnapshotsUsagerror(t *testing.T) {
 	mockedOutput := ec2.DescribeSnapshotsOutput{
 		Snapshots: []*ec2.Snapshot{{SnapshotId: aws.String("foo")}},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeSnapshotsPagesResp: mockedOutput, DescribeSnapshotsPagesError: errors.New("test error")}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Snapshots per Region", float64(100), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeSnapshotsPagesResp: mockedOutput, DescribeSnapshotsPagesError: errors.New("test error")}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Snapshots per Region", float64(100), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -57,15 +63,11 @@ func TestGetEbsSnapshotsUsagerror(t *testing.T) {
 func TestGetEbsIo1IopsUsage(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("io1"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("io1"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "IOPS for Provisioned IOPS SSD (io1) volumes", float64(10000), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}, []*servicequotas.ServiceQuota{NewQuota("ebs", "IOPS for Provisioned IOPS SSD (io1) volumes", float64(10000), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -77,18 +79,15 @@ func TestGetEbsIo1IopsUsage(t *testing.T) {
 	assert.Equal(t, float64(10000), quota.QuotaValue)
 	assert.Equal(t, float64(2000), quota.UsageValue)
 }
+
 func TestGetEbsIo1IopsUsageError(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("io1"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("io1"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "IOPS for Provisioned IOPS SSD (io1) volumes", float64(10000), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}, []*servicequotas.ServiceQuota{NewQuota("ebs", "IOPS for Provisioned IOPS SSD (io1) volumes", float64(10000), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -101,15 +100,11 @@ func TestGetEbsIo1IopsUsageError(t *testing.T) {
 func TestGetEbsIo1SizeUsage(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("io1"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("io1"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Provisioned IOPS SSD (io1) volumes, in TiB", float64(50), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Provisioned IOPS SSD (io1) volumes, in TiB", float64(50), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -121,18 +116,15 @@ func TestGetEbsIo1SizeUsage(t *testing.T) {
 	assert.Equal(t, float64(50), quota.QuotaValue)
 	assert.Equal(t, float64(3), quota.UsageValue)
 }
+
 func TestGetEbsIo1SizeUsageError(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("io1"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("io1"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Provisioned IOPS SSD (io1) volumes, in TiB", float64(50), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Provisioned IOPS SSD (io1) volumes, in TiB", float64(50), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -145,15 +137,11 @@ func TestGetEbsIo1SizeUsageError(t *testing.T) {
 func TestGetEbsIo2IopsUsage(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("io2"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("io2"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "IOPS for Provisioned IOPS SSD (io2) volumes", float64(10000), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}, []*servicequotas.ServiceQuota{NewQuota("ebs", "IOPS for Provisioned IOPS SSD (io2) volumes", float64(10000), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -165,18 +153,15 @@ func TestGetEbsIo2IopsUsage(t *testing.T) {
 	assert.Equal(t, float64(10000), quota.QuotaValue)
 	assert.Equal(t, float64(2000), quota.UsageValue)
 }
+
 func TestGetEbsIo2IopsUsageError(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("io2"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("io2"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "IOPS for Provisioned IOPS SSD (io2) volumes", float64(10000), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}, []*servicequotas.ServiceQuota{NewQuota("ebs", "IOPS for Provisioned IOPS SSD (io2) volumes", float64(10000), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -185,18 +170,15 @@ func TestGetEbsIo2IopsUsageError(t *testing.T) {
 	expected := []AWSQuotaInfo{}
 	assert.Equal(t, expected, actual)
 }
+
 func TestGetEbsSc1SizeUsage(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("sc1"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("sc1"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Cold HDD (sc1) volumes, in TiB", float64(50), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Cold HDD (sc1) volumes, in TiB", float64(50), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -208,18 +190,15 @@ func TestGetEbsSc1SizeUsage(t *testing.T) {
 	assert.Equal(t, float64(50), quota.QuotaValue)
 	assert.Equal(t, float64(3), quota.UsageValue)
 }
+
 func TestGetEbsSc1SizeUsageError(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("sc1"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("sc1"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Cold HDD (sc1) volumes, in TiB", float64(50), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Cold HDD (sc1) volumes, in TiB", float64(50), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -232,15 +211,11 @@ func TestGetEbsSc1SizeUsageError(t *testing.T) {
 func TestGetEbsGp2SizeUsage(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("gp2"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("gp2"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for General Purpose SSD (gp2) volumes, in TiB", float64(50), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for General Purpose SSD (gp2) volumes, in TiB", float64(50), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -252,18 +227,15 @@ func TestGetEbsGp2SizeUsage(t *testing.T) {
 	assert.Equal(t, float64(50), quota.QuotaValue)
 	assert.Equal(t, float64(3), quota.UsageValue)
 }
+
 func TestGetEbsGp2SizeUsageError(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("gp2"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("gp2"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for General Purpose SSD (gp2) volumes, in TiB", float64(50), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for General Purpose SSD (gp2) volumes, in TiB", float64(50), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -276,15 +248,11 @@ func TestGetEbsGp2SizeUsageError(t *testing.T) {
 func TestGetEbsGp3SizeUsage(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("gp3"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("gp3"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for General Purpose SSD (gp3) volumes, in TiB", float64(50), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for General Purpose SSD (gp3) volumes, in TiB", float64(50), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -296,18 +264,15 @@ func TestGetEbsGp3SizeUsage(t *testing.T) {
 	assert.Equal(t, float64(50), quota.QuotaValue)
 	assert.Equal(t, float64(3), quota.UsageValue)
 }
+
 func TestGetEbsGp3SizeUsageError(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("gp3"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("gp3"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for General Purpose SSD (gp3) volumes, in TiB", float64(50), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for General Purpose SSD (gp3) volumes, in TiB", float64(50), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -320,15 +285,11 @@ func TestGetEbsGp3SizeUsageError(t *testing.T) {
 func TestGetEbsStandardSizeUsage(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("standard"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("standard"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Magnetic (standard) volumes, in TiB", float64(50), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Magnetic (standard) volumes, in TiB", float64(50), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -340,18 +301,15 @@ func TestGetEbsStandardSizeUsage(t *testing.T) {
 	assert.Equal(t, float64(50), quota.QuotaValue)
 	assert.Equal(t, float64(3), quota.UsageValue)
 }
+
 func TestGetEbsStandardSizeUsageError(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("standard"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("standard"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Magnetic (standard) volumes, in TiB", float64(50), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Magnetic (standard) volumes, in TiB", float64(50), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -364,15 +322,11 @@ func TestGetEbsStandardSizeUsageError(t *testing.T) {
 func TestGetEbsSt1SizeUsage(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("st1"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("st1"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Throughput Optimized HDD (st1) volumes, in TiB", float64(50), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Throughput Optimized HDD (st1) volumes, in TiB", float64(50), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -384,18 +338,15 @@ func TestGetEbsSt1SizeUsage(t *testing.T) {
 	assert.Equal(t, float64(50), quota.QuotaValue)
 	assert.Equal(t, float64(3), quota.UsageValue)
 }
+
 func TestGetEbsSt1SizeUsageError(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("st1"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("st1"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Throughput Optimized HDD (st1) volumes, in TiB", float64(50), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Throughput Optimized HDD (st1) volumes, in TiB", float64(50), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -404,18 +355,15 @@ func TestGetEbsSt1SizeUsageError(t *testing.T) {
 	expected := []AWSQuotaInfo{}
 	assert.Equal(t, expected, actual)
 }
+
 func TestGetEbsIo2SizeUsage(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("io2"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("io2"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Provisioned IOPS SSD (io2) volumes, in TiB", float64(50), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Provisioned IOPS SSD (io2) volumes, in TiB", float64(50), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -427,18 +375,15 @@ func TestGetEbsIo2SizeUsage(t *testing.T) {
 	assert.Equal(t, float64(50), quota.QuotaValue)
 	assert.Equal(t, float64(3), quota.UsageValue)
 }
+
 func TestGetEbsIo2SizeUsageError(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("io2"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("io2"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}
-
-	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
-		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Provisioned IOPS SSD (io2) volumes, in TiB", float64(50), false)},
-		nil)
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Storage for Provisioned IOPS SSD (io2) volumes, in TiB", float64(50), false)})
 
 	ebsChecker := NewEbsChecker()
 	svcChecker := ebsChecker.(*ServiceChecker)
@@ -451,11 +396,11 @@ func TestGetEbsIo2SizeUsageError(t *testing.T) {
 func TestGetEbsVolumeDetails(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("standard"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("standard"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}, nil)
 
 	iops, size, err := getEbsVolumeDetails("standard")
 	assert.Equal(t, 2000, iops)
@@ -466,11 +411,11 @@ func TestGetEbsVolumeDetails(t *testing.T) {
 func TestGetEbsVolumeDetailsError(t *testing.T) {
 	mockedOutput := ec2.DescribeVolumesOutput{
 		Volumes: []*ec2.Volume{
-			{VolumeId: aws.String("foo"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
-			{VolumeId: aws.String("bar"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("standard"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("standard"), Iops: aws.Int64(1000), Size: aws.Int64(2048)},
 		},
 	}
-	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput, DescribeVolumesPagesError: errors.New("test error")}, nil)
 
 	iops, size, err := getEbsVolumeDetails("standard")
 	assert.Equal(t, 0, iops)
@@ -482,3 +427,178 @@ func TestGiBtoTiB(t *testing.T) {
 	tib := GiBtoTiB(float64(1024))
 	assert.Equal(t, float64(1), tib)
 }
+
+func TestGetEbsVolumeDetailsFiltersType(t *testing.T) {
+	mockedOutput := ec2.DescribeVolumesOutput{
+		Volumes: []*ec2.Volume{
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("gp2"), Iops: aws.Int64(1000), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("standard"), Size: aws.Int64(2048)},
+		},
+	}
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}, nil)
+
+	iops, size, err := getEbsVolumeDetails("standard")
+	assert.Equal(t, 0, iops)
+	assert.Equal(t, 2048, size)
+	assert.Nil(t, err)
+
+	// served from the shared inventory, the api is not called again
+	conf.Ec2 = mockedEc2Client{DescribeVolumesPagesError: errors.New("should not be called")}
+	iops, size, err = getEbsVolumeDetails("gp2")
+	assert.Equal(t, 1000, iops)
+	assert.Equal(t, 1024, size)
+	assert.Nil(t, err)
+}
+
+func TestGetEbsSnapshotsUsageExcludesArchived(t *testing.T) {
+	mockedOutput := ec2.DescribeSnapshotsOutput{
+		Snapshots: []*ec2.Snapshot{
+			{SnapshotId: aws.String("foo"), StorageTier: aws.String("standard")},
+			{SnapshotId: aws.String("bar"), StorageTier: aws.String("archive")},
+		},
+	}
+	setEbsTestConf(t, mockedEc2Client{DescribeSnapshotsPagesResp: mockedOutput}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Snapshots per Region", float64(100), false)})
+
+	ebsChecker := NewEbsChecker()
+	svcChecker := ebsChecker.(*ServiceChecker)
+	actual := svcChecker.getEbsSnapshotsUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, float64(1), actual[0].UsageValue)
+}
+
+func TestGetEbsArchivedSnapshotsPerVolumeUsage(t *testing.T) {
+	mockedOutput := ec2.DescribeSnapshotsOutput{
+		Snapshots: []*ec2.Snapshot{
+			{SnapshotId: aws.String("s1"), VolumeId: aws.String("vol-1"), StorageTier: aws.String("archive")},
+			{SnapshotId: aws.String("s2"), VolumeId: aws.String("vol-1"), StorageTier: aws.String("archive")},
+			{SnapshotId: aws.String("s3"), VolumeId: aws.String("vol-1"), StorageTier: aws.String("standard")},
+			{SnapshotId: aws.String("s4"), VolumeId: aws.String("vol-2"), StorageTier: aws.String("archive")},
+		},
+	}
+	setEbsTestConf(t, mockedEc2Client{DescribeSnapshotsPagesResp: mockedOutput}, []*servicequotas.ServiceQuota{})
+
+	ebsChecker := NewEbsChecker()
+	svcChecker := ebsChecker.(*ServiceChecker)
+	actual := svcChecker.getEbsArchivedSnapshotsPerVolumeUsage()
+
+	assert.Len(t, actual, 2)
+	assert.Equal(t, "AWS::EC2::Volume::vol-1", actual[0].ResourceId)
+	assert.Equal(t, float64(25), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+	assert.Equal(t, float64(1), actual[1].UsageValue)
+}
+
+func TestGetEbsConcurrentSnapshotCopiesUsage(t *testing.T) {
+	mockedOutput := ec2.DescribeSnapshotsOutput{
+		Snapshots: []*ec2.Snapshot{
+			{SnapshotId: aws.String("s1"), State: aws.String("pending"), VolumeId: aws.String("vol-ffffffff")},
+			{SnapshotId: aws.String("s2"), State: aws.String("pending"), VolumeId: aws.String("vol-1"), Description: aws.String("[Copied snap-1 from us-east-1:snap-2]")},
+			{SnapshotId: aws.String("s3"), State: aws.String("completed"), VolumeId: aws.String("vol-ffffffff")},
+			// created, not copied
+			{SnapshotId: aws.String("s4"), State: aws.String("pending"), VolumeId: aws.String("vol-1")},
+		},
+	}
+	setEbsTestConf(t, mockedEc2Client{DescribeSnapshotsPagesResp: mockedOutput}, []*servicequotas.ServiceQuota{})
+
+	ebsChecker := NewEbsChecker()
+	svcChecker := ebsChecker.(*ServiceChecker)
+	actual := svcChecker.getEbsConcurrentSnapshotCopiesUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, float64(20), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+}
+
+func TestGetEbsSnapshotQuotasError(t *testing.T) {
+	setEbsTestConf(t, mockedEc2Client{DescribeSnapshotsPagesError: errors.New("test error")}, []*servicequotas.ServiceQuota{})
+
+	ebsChecker := NewEbsChecker()
+	svcChecker := ebsChecker.(*ServiceChecker)
+	assert.Equal(t, []AWSQuotaInfo{}, svcChecker.getEbsArchivedSnapshotsPerVolumeUsage())
+	assert.Equal(t, []AWSQuotaInfo{}, svcChecker.getEbsConcurrentSnapshotCopiesUsage())
+	assert.Len(t, ebsSnapshots, 0)
+}
+
+func TestGetEbsFastSnapshotRestoresUsage(t *testing.T) {
+	mockedOutput := ec2.DescribeFastSnapshotRestoresOutput{
+		FastSnapshotRestores: []*ec2.DescribeFastSnapshotRestoreSuccessItem{
+			{SnapshotId: aws.String("s1"), AvailabilityZone: aws.String("us-east-1a"), State: aws.String("enabled")},
+			{SnapshotId: aws.String("s2"), AvailabilityZone: aws.String("us-east-1a"), State: aws.String("optimizing")},
+			{SnapshotId: aws.String("s3"), AvailabilityZone: aws.String("us-east-1a"), State: aws.String("disabled")},
+			{SnapshotId: aws.String("s1"), AvailabilityZone: aws.String("us-east-1b"), State: aws.String("enabling")},
+		},
+	}
+	setEbsTestConf(t, mockedEc2Client{DescribeFastSnapshotRestoresPagesResp: mockedOutput}, []*servicequotas.ServiceQuota{})
+
+	ebsChecker := NewEbsChecker()
+	svcChecker := ebsChecker.(*ServiceChecker)
+	actual := svcChecker.getEbsFastSnapshotRestoresUsage()
+
+	assert.Len(t, actual, 2)
+	assert.Equal(t, "us-east-1a", actual[0].ResourceId)
+	assert.Equal(t, float64(5), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+	assert.Equal(t, float64(1), actual[1].UsageValue)
+}
+
+func TestGetEbsFastSnapshotRestoresUsageError(t *testing.T) {
+	setEbsTestConf(t, mockedEc2Client{DescribeFastSnapshotRestoresPagesError: errors.New("test error")}, []*servicequotas.ServiceQuota{})
+
+	ebsChecker := NewEbsChecker()
+	svcChecker := ebsChecker.(*ServiceChecker)
+	assert.Equal(t, []AWSQuotaInfo{}, svcChecker.getEbsFastSnapshotRestoresUsage())
+}
+
+func TestGetEbsGp3ThroughputUsage(t *testing.T) {
+	mockedOutput := ec2.DescribeVolumesOutput{
+		Volumes: []*ec2.Volume{
+			{VolumeId: aws.String("foo"), VolumeType: aws.String("gp3"), Throughput: aws.Int64(125), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("bar"), VolumeType: aws.String("gp3"), Throughput: aws.Int64(250), Size: aws.Int64(1024)},
+			{VolumeId: aws.String("baz"), VolumeType: aws.String("gp2"), Size: aws.Int64(1024)},
+		},
+	}
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}, []*servicequotas.ServiceQuota{NewQuota("ebs", "Throughput for General Purpose SSD (gp3) volumes, in MiB/s", float64(1000), false)})
+
+	ebsChecker := NewEbsChecker()
+	svcChecker := ebsChecker.(*ServiceChecker)
+	actual := svcChecker.getEbsGp3ThroughputUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, float64(1000), actual[0].QuotaValue)
+	assert.Equal(t, float64(375), actual[0].UsageValue)
+}
+
+func TestGetEbsSnapshotsUsageDefault(t *testing.T) {
+	mockedOutput := ec2.DescribeSnapshotsOutput{
+		Snapshots: []*ec2.Snapshot{{SnapshotId: aws.String("foo")}},
+	}
+	setEbsTestConf(t, mockedEc2Client{DescribeSnapshotsPagesResp: mockedOutput}, []*servicequotas.ServiceQuota{})
+
+	ebsChecker := NewEbsChecker()
+	svcChecker := ebsChecker.(*ServiceChecker)
+	actual := svcChecker.getEbsSnapshotsUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, float64(100000), actual[0].QuotaValue)
+}
+
+func TestGetEbsGp3ThroughputUsageUnknownQuota(t *testing.T) {
+	mockedOutput := ec2.DescribeVolumesOutput{
+		Volumes: []*ec2.Volume{{VolumeId: aws.String("foo"), VolumeType: aws.String("gp3"), Throughput: aws.Int64(125)}},
+	}
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesRes: mockedOutput}, []*servicequotas.ServiceQuota{})
+
+	ebsChecker := NewEbsChecker()
+	svcChecker := ebsChecker.(*ServiceChecker)
+	assert.Equal(t, []AWSQuotaInfo{}, svcChecker.getEbsGp3ThroughputUsage())
+}
+
+func TestGetEbsGp3ThroughputUsageError(t *testing.T) {
+	setEbsTestConf(t, mockedEc2Client{DescribeVolumesPagesError: errors.New("test error")},
+		[]*servicequotas.ServiceQuota{NewQuota("ebs", "Throughput for General Purpose SSD (gp3) volumes, in MiB/s", float64(1000), false)})
+
+	ebsChecker := NewEbsChecker()
+	svcChecker := ebsChecker.(*ServiceChecker)
+	assert.Equal(t, []AWSQuotaInfo{}, svcChecker.getEbsGp3ThroughputUsage())
+}
@@ -1,8 +1,74 @@
 package services
 
-import "github.com/aws/aws-sdk-go/service/ec2"
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
 
 type Ec2ClientInterface interface {
 	DescribeSnapshotsPages(input *ec2.DescribeSnapshotsInput, fn func(*ec2.DescribeSnapshotsOutput, bool) bool) error
 	DescribeVolumesPages(input *ec2.DescribeVolumesInput, fn func(*ec2.DescribeVolumesOutput, bool) bool) error
+	DescribeFastSnapshotRestoresPages(input *ec2.DescribeFastSnapshotRestoresInput, fn func(*ec2.DescribeFastSnapshotRestoresOutput, bool) bool) error
+	DescribeLaunchTemplatesPages(input *ec2.DescribeLaunchTemplatesInput, fn func(*ec2.DescribeLaunchTemplatesOutput, bool) bool) error
+	DescribeLaunchTemplateVersionsPages(input *ec2.DescribeLaunchTemplateVersionsInput, fn func(*ec2.DescribeLaunchTemplateVersionsOutput, bool) bool) error
+	DescribeTransitGatewaysPages(input *ec2.DescribeTransitGatewaysInput, fn func(*ec2.DescribeTransitGatewaysOutput, bool) bool) error
+	DescribeTransitGatewayAttachmentsPages(input *ec2.DescribeTransitGatewayAttachmentsInput, fn func(*ec2.DescribeTransitGatewayAttachmentsOutput, bool) bool) error
+	DescribeTransitGatewayRouteTablesPages(input *ec2.DescribeTransitGatewayRouteTablesInput, fn func(*ec2.DescribeTransitGatewayRouteTablesOutput, bool) bool) error
+	SearchTransitGatewayRoutes(input *ec2.SearchTransitGatewayRoutesInput) (*ec2.SearchTransitGatewayRoutesOutput, error)
+	DescribeVpnConnections(input *ec2.DescribeVpnConnectionsInput) (*ec2.DescribeVpnConnectionsOutput, error)
+	DescribeCustomerGateways(input *ec2.DescribeCustomerGatewaysInput) (*ec2.DescribeCustomerGatewaysOutput, error)
+	DescribeVpnGateways(input *ec2.DescribeVpnGatewaysInput) (*ec2.DescribeVpnGatewaysOutput, error)
+	DescribeAvailabilityZones(input *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error)
+}
+
+var ec2DefaultQuotas = map[string]quotaDefault{
+	"Versions per launch template": {Value: 10000},
+}
+
+func NewEc2Checker() Svcquota {
+	serviceCode := "ec2"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Versions per launch template": ServiceChecker.getEc2LaunchTemplateVersionsUsage,
+	}
+	requiredPermissions := []string{
+		"ec2:DescribeLaunchTemplates",
+		"ec2:DescribeLaunchTemplateVersions",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func (c ServiceChecker) getEc2LaunchTemplateVersionsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	templates := []*ec2.LaunchTemplate{}
+	err := conf.Ec2.DescribeLaunchTemplatesPages(&ec2.DescribeLaunchTemplatesInput{}, func(p *ec2.DescribeLaunchTemplatesOutput, lastPage bool) bool {
+		templates = append(templates, p.LaunchTemplates...)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve launch templates, %v", err)
+		return
+	}
+
+	for _, t := range templates {
+		quotaInfo := c.getAppliedQuotaOrDefault("Versions per launch template", ec2DefaultQuotas["Versions per launch template"])
+		// the latest version number also counts the deleted versions, the
+		// remaining ones need to be listed
+		versions := 0
+		errVersions := conf.Ec2.DescribeLaunchTemplateVersionsPages(&ec2.DescribeLaunchTemplateVersionsInput{LaunchTemplateId: t.LaunchTemplateId}, func(p *ec2.DescribeLaunchTemplateVersionsOutput, lastPage bool) bool {
+			versions += len(p.LaunchTemplateVersions)
+			return true // continue paging
+		})
+		if errVersions != nil {
+			fmt.Printf("failed to retrieve versions of launch template %s, %v", aws.StringValue(t.LaunchTemplateId), errVersions)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(versions)
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::EC2::LaunchTemplate::%s", aws.StringValue(t.LaunchTemplateId))
+		ret = append(ret, quotaInfo)
+	}
+	return
 }
@@ -1,15 +1,44 @@
 package services
 
 import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockedEc2Client struct {
 	Ec2ClientInterface
-	DescribeSnapshotsPagesResp  ec2.DescribeSnapshotsOutput
-	DescribeSnapshotsPagesError error
-	DescribeVolumesPagesRes     ec2.DescribeVolumesOutput
-	DescribeVolumesPagesError   error
+	DescribeSnapshotsPagesResp                  ec2.DescribeSnapshotsOutput
+	DescribeSnapshotsPagesError                 error
+	DescribeVolumesPagesRes                     ec2.DescribeVolumesOutput
+	DescribeVolumesPagesError                   error
+	DescribeFastSnapshotRestoresPagesResp       ec2.DescribeFastSnapshotRestoresOutput
+	DescribeFastSnapshotRestoresPagesError      error
+	DescribeLaunchTemplatesPagesResp            ec2.DescribeLaunchTemplatesOutput
+	DescribeLaunchTemplatesPagesError           error
+	DescribeLaunchTemplateVersionsPagesResp     map[string]ec2.DescribeLaunchTemplateVersionsOutput
+	DescribeLaunchTemplateVersionsPagesError    error
+	DescribeTransitGatewaysPagesResp            ec2.DescribeTransitGatewaysOutput
+	DescribeTransitGatewaysPagesError           error
+	DescribeTransitGatewayAttachmentsPagesResp  ec2.DescribeTransitGatewayAttachmentsOutput
+	DescribeTransitGatewayAttachmentsPagesError error
+	DescribeTransitGatewayRouteTablesPagesResp  ec2.DescribeTransitGatewayRouteTablesOutput
+	DescribeTransitGatewayRouteTablesPagesError error
+	SearchTransitGatewayRoutesResp              ec2.SearchTransitGatewayRoutesOutput
+	SearchTransitGatewayRoutesError             error
+	SearchTransitGatewayRoutesFn                func(*ec2.SearchTransitGatewayRoutesInput) *ec2.SearchTransitGatewayRoutesOutput
+	DescribeVpnConnectionsResp                  ec2.DescribeVpnConnectionsOutput
+	DescribeVpnConnectionsError                 error
+	DescribeCustomerGatewaysResp                ec2.DescribeCustomerGatewaysOutput
+	DescribeCustomerGatewaysError               error
+	DescribeVpnGatewaysResp                     ec2.DescribeVpnGatewaysOutput
+	DescribeVpnGatewaysError                    error
+	DescribeAvailabilityZonesResp               ec2.DescribeAvailabilityZonesOutput
+	DescribeAvailabilityZonesError              error
 }
 
 func (m mockedEc2Client) DescribeSnapshotsPages(input *ec2.DescribeSnapshotsInput, fn func(*ec2.DescribeSnapshotsOutput, bool) bool) error {
@@ -21,3 +50,94 @@ func (m mockedEc2Client) DescribeVolumesPages(input *ec2.DescribeVolumesInput, f
 	fn(&m.DescribeVolumesPagesRes, false)
 	return m.DescribeVolumesPagesError
 }
+
+func (m mockedEc2Client) DescribeFastSnapshotRestoresPages(input *ec2.DescribeFastSnapshotRestoresInput, fn func(*ec2.DescribeFastSnapshotRestoresOutput, bool) bool) error {
+	fn(&m.DescribeFastSnapshotRestoresPagesResp, false)
+	return m.DescribeFastSnapshotRestoresPagesError
+}
+
+func (m mockedEc2Client) DescribeLaunchTemplatesPages(input *ec2.DescribeLaunchTemplatesInput, fn func(*ec2.DescribeLaunchTemplatesOutput, bool) bool) error {
+	fn(&m.DescribeLaunchTemplatesPagesResp, false)
+	return m.DescribeLaunchTemplatesPagesError
+}
+
+func (m mockedEc2Client) DescribeLaunchTemplateVersionsPages(input *ec2.DescribeLaunchTemplateVersionsInput, fn func(*ec2.DescribeLaunchTemplateVersionsOutput, bool) bool) error {
+	return mockPages(m.DescribeLaunchTemplateVersionsPagesResp[aws.StringValue(input.LaunchTemplateId)], m.DescribeLaunchTemplateVersionsPagesError, fn)
+}
+
+func (m mockedEc2Client) DescribeTransitGatewaysPages(input *ec2.DescribeTransitGatewaysInput, fn func(*ec2.DescribeTransitGatewaysOutput, bool) bool) error {
+	fn(&m.DescribeTransitGatewaysPagesResp, false)
+	return m.DescribeTransitGatewaysPagesError
+}
+
+func (m mockedEc2Client) DescribeTransitGatewayAttachmentsPages(input *ec2.DescribeTransitGatewayAttachmentsInput, fn func(*ec2.DescribeTransitGatewayAttachmentsOutput, bool) bool) error {
+	fn(&m.DescribeTransitGatewayAttachmentsPagesResp, false)
+	return m.DescribeTransitGatewayAttachmentsPagesError
+}
+
+func (m mockedEc2Client) DescribeTransitGatewayRouteTablesPages(input *ec2.DescribeTransitGatewayRouteTablesInput, fn func(*ec2.DescribeTransitGatewayRouteTablesOutput, bool) bool) error {
+	fn(&m.DescribeTransitGatewayRouteTablesPagesResp, false)
+	return m.DescribeTransitGatewayRouteTablesPagesError
+}
+
+func (m mockedEc2Client) SearchTransitGatewayRoutes(input *ec2.SearchTransitGatewayRoutesInput) (*ec2.SearchTransitGatewayRoutesOutput, error) {
+	if m.SearchTransitGatewayRoutesFn != nil {
+		return m.SearchTransitGatewayRoutesFn(input), m.SearchTransitGatewayRoutesError
+	}
+	return &m.SearchTransitGatewayRoutesResp, m.SearchTransitGatewayRoutesError
+}
+
+func (m mockedEc2Client) DescribeVpnConnections(input *ec2.DescribeVpnConnectionsInput) (*ec2.DescribeVpnConnectionsOutput, error) {
+	return &m.DescribeVpnConnectionsResp, m.DescribeVpnConnectionsError
+}
+
+func (m mockedEc2Client) DescribeCustomerGateways(input *ec2.DescribeCustomerGatewaysInput) (*ec2.DescribeCustomerGatewaysOutput, error) {
+	return &m.DescribeCustomerGatewaysResp, m.DescribeCustomerGatewaysError
+}
+
+func (m mockedEc2Client) DescribeVpnGateways(input *ec2.DescribeVpnGatewaysInput) (*ec2.DescribeVpnGatewaysOutput, error) {
+	return &m.DescribeVpnGatewaysResp, m.DescribeVpnGatewaysError
+}
+
+func (m mockedEc2Client) DescribeAvailabilityZones(input *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	return &m.DescribeAvailabilityZonesResp, m.DescribeAvailabilityZonesError
+}
+
+func TestNewEc2CheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewEc2Checker())
+}
+
+func TestGetEc2LaunchTemplateVersionsUsage(t *testing.T) {
+	conf.Ec2 = mockedEc2Client{
+		DescribeLaunchTemplatesPagesResp: ec2.DescribeLaunchTemplatesOutput{
+			LaunchTemplates: []*ec2.LaunchTemplate{{LaunchTemplateId: aws.String("lt-foo"), LatestVersionNumber: aws.Int64(12)}},
+		},
+		// versions 3 to 12 were deleted
+		DescribeLaunchTemplateVersionsPagesResp: map[string]ec2.DescribeLaunchTemplateVersionsOutput{
+			"lt-foo": {LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{{VersionNumber: aws.Int64(1)}, {VersionNumber: aws.Int64(2)}}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewEc2Checker)
+	actual := svcChecker.getEc2LaunchTemplateVersionsUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "ec2", actual[0].Service)
+	assert.Equal(t, "AWS::EC2::LaunchTemplate::lt-foo", actual[0].ResourceId)
+	assert.Equal(t, float64(10000), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+}
+
+func TestGetEc2LaunchTemplateVersionsUsageError(t *testing.T) {
+	conf.Ec2 = mockedEc2Client{DescribeLaunchTemplatesPagesError: errors.New("test error")}
+	svcChecker := newTestServiceChecker(NewEc2Checker)
+
+	assert.Equal(t, []AWSQuotaInfo{}, svcChecker.getEc2LaunchTemplateVersionsUsage())
+
+	conf.Ec2 = mockedEc2Client{
+		DescribeLaunchTemplatesPagesResp: ec2.DescribeLaunchTemplatesOutput{
+			LaunchTemplates: []*ec2.LaunchTemplate{{LaunchTemplateId: aws.String("lt-foo")}},
+		},
+		DescribeLaunchTemplateVersionsPagesError: errors.New("test error"),
+	}
+	assert.Equal(t, []AWSQuotaInfo{}, svcChecker.getEc2LaunchTemplateVersionsUsage())
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/efs"
+)
+
+type EfsClientInterface interface {
+	DescribeFileSystemsPages(input *efs.DescribeFileSystemsInput, fn func(*efs.DescribeFileSystemsOutput, bool) bool) error
+	DescribeAccessPointsPages(input *efs.DescribeAccessPointsInput, fn func(*efs.DescribeAccessPointsOutput, bool) bool) error
+}
+
+var efsDefaultQuotas = map[string]quotaDefault{
+	"File systems per account":      {Value: 1000},
+	"Access points per file system": {Value: 1000},
+}
+
+func NewEfsChecker() Svcquota {
+	serviceCode := "elasticfilesystem"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"File systems per account":      ServiceChecker.getEfsFileSystemsUsage,
+		"Mount targets per file system": ServiceChecker.getEfsMountTargetsPerFileSystemUsage,
+		"Access points per file system": ServiceChecker.getEfsAccessPointsPerFileSystemUsage,
+	}
+	requiredPermissions := []string{
+		"elasticfilesystem:DescribeFileSystems",
+		"elasticfilesystem:DescribeAccessPoints",
+		"ec2:DescribeAvailabilityZones",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+var efsFileSystems []*efs.FileSystemDescription = []*efs.FileSystemDescription{}
+
+// getEfsFileSystems lists the file systems once and shares the result between
+// the different quotas
+func getEfsFileSystems() (ret []*efs.FileSystemDescription, err error) {
+	ret = efsFileSystems
+	if len(efsFileSystems) != 0 {
+		return
+	}
+
+	err = conf.Efs.DescribeFileSystemsPages(&efs.DescribeFileSystemsInput{}, func(p *efs.DescribeFileSystemsOutput, lastPage bool) bool {
+		efsFileSystems = append(efsFileSystems, p.FileSystems...)
+		return true // continue paging
+	})
+	if err != nil {
+		efsFileSystems = []*efs.FileSystemDescription{}
+		return efsFileSystems, err
+	}
+	return efsFileSystems, nil
+}
+
+func efsFileSystemResourceId(fileSystem *efs.FileSystemDescription) string {
+	return fmt.Sprintf("AWS::EFS::FileSystem::%s", aws.StringValue(fileSystem.FileSystemId))
+}
+
+func (c ServiceChecker) getEfsFileSystemsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	fileSystems, err := getEfsFileSystems()
+	if err != nil {
+		fmt.Printf("failed to retrieve efs file systems, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("File systems per account", efsDefaultQuotas["File systems per account"])
+	quotaInfo.UsageValue = float64(len(fileSystems))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+// getEfsMountTargetsPerFileSystemUsage compares the mount targets of each file
+// system to the availability zones of the region, as a file system can have at
+// most one mount target per availability zone
+func (c ServiceChecker) getEfsMountTargetsPerFileSystemUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	fileSystems, err := getEfsFileSystems()
+	if err != nil {
+		fmt.Printf("failed to retrieve efs file systems, %v", err)
+		return
+	}
+	zones, err := conf.Ec2.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{})
+	if err != nil {
+		fmt.Printf("failed to retrieve availability zones, %v", err)
+		return
+	}
+
+	for _, fileSystem := range fileSystems {
+		quotaInfo := c.getAppliedQuotaOrDefault("Mount targets per file system", quotaDefault{Value: float64(len(zones.AvailabilityZones))})
+		quotaInfo.UsageValue = float64(aws.Int64Value(fileSystem.NumberOfMountTargets))
+		quotaInfo.ResourceId = efsFileSystemResourceId(fileSystem)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getEfsAccessPointsPerFileSystemUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	fileSystems, err := getEfsFileSystems()
+	if err != nil {
+		fmt.Printf("failed to retrieve efs file systems, %v", err)
+		return
+	}
+
+	for _, fileSystem := range fileSystems {
+		quotaInfo := c.getAppliedQuotaOrDefault("Access points per file system", efsDefaultQuotas["Access points per file system"])
+		accessPoints := 0
+		errAccessPoints := conf.Efs.DescribeAccessPointsPages(&efs.DescribeAccessPointsInput{FileSystemId: fileSystem.FileSystemId}, func(p *efs.DescribeAccessPointsOutput, lastPage bool) bool {
+			accessPoints += len(p.AccessPoints)
+			return true // continue paging
+		})
+		if errAccessPoints != nil {
+			fmt.Printf("failed to retrieve access points for file system %s, %v", aws.StringValue(fileSystem.FileSystemId), errAccessPoints)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(accessPoints)
+		quotaInfo.ResourceId = efsFileSystemResourceId(fileSystem)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
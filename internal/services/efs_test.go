@@ -0,0 +1,104 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedEfsClient struct {
+	EfsClientInterface
+	DescribeFileSystemsPagesResp   efs.DescribeFileSystemsOutput
+	DescribeFileSystemsPagesError  error
+	DescribeAccessPointsPagesResp  efs.DescribeAccessPointsOutput
+	DescribeAccessPointsPagesError error
+}
+
+func (m mockedEfsClient) DescribeFileSystemsPages(input *efs.DescribeFileSystemsInput, fn func(*efs.DescribeFileSystemsOutput, bool) bool) error {
+	return mockPages(m.DescribeFileSystemsPagesResp, m.DescribeFileSystemsPagesError, fn)
+}
+
+func (m mockedEfsClient) DescribeAccessPointsPages(input *efs.DescribeAccessPointsInput, fn func(*efs.DescribeAccessPointsOutput, bool) bool) error {
+	return mockPages(m.DescribeAccessPointsPagesResp, m.DescribeAccessPointsPagesError, fn)
+}
+
+func TestNewEfsCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewEfsChecker())
+}
+
+func TestGetEfsUsage(t *testing.T) {
+	t.Cleanup(func() { efsFileSystems = []*efs.FileSystemDescription{} })
+	conf.Efs = mockedEfsClient{
+		DescribeFileSystemsPagesResp: efs.DescribeFileSystemsOutput{
+			FileSystems: []*efs.FileSystemDescription{
+				{FileSystemId: aws.String("fs-1"), NumberOfMountTargets: aws.Int64(3)},
+				{FileSystemId: aws.String("fs-2"), NumberOfMountTargets: aws.Int64(1)},
+			},
+		},
+		DescribeAccessPointsPagesResp: efs.DescribeAccessPointsOutput{
+			AccessPoints: []*efs.AccessPointDescription{{}, {}, {}, {}},
+		},
+	}
+	conf.Ec2 = mockedEc2Client{
+		DescribeAvailabilityZonesResp: ec2.DescribeAvailabilityZonesOutput{
+			AvailabilityZones: []*ec2.AvailabilityZone{{}, {}, {}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewEfsChecker, NewQuota("elasticfilesystem", "File systems per account", float64(2000), false))
+
+	fileSystems := svcChecker.getEfsFileSystemsUsage()
+	assert.Len(t, fileSystems, 1)
+	assert.Equal(t, "elasticfilesystem", fileSystems[0].Service)
+	assert.Equal(t, float64(2000), fileSystems[0].QuotaValue)
+	assert.Equal(t, float64(2), fileSystems[0].UsageValue)
+
+	// one mount target per availability zone
+	mountTargets := svcChecker.getEfsMountTargetsPerFileSystemUsage()
+	assert.Len(t, mountTargets, 2)
+	assert.Equal(t, "AWS::EFS::FileSystem::fs-1", mountTargets[0].ResourceId)
+	assert.Equal(t, float64(3), mountTargets[0].QuotaValue)
+	assert.Equal(t, float64(3), mountTargets[0].UsageValue)
+	assert.Equal(t, float64(1), mountTargets[1].UsageValue)
+
+	accessPoints := svcChecker.getEfsAccessPointsPerFileSystemUsage()
+	assert.Len(t, accessPoints, 2)
+	assert.Equal(t, "AWS::EFS::FileSystem::fs-2", accessPoints[1].ResourceId)
+	assert.Equal(t, float64(1000), accessPoints[1].QuotaValue)
+	assert.Equal(t, float64(4), accessPoints[1].UsageValue)
+}
+
+func TestGetEfsUsageError(t *testing.T) {
+	t.Cleanup(func() { efsFileSystems = []*efs.FileSystemDescription{} })
+	conf.Efs = mockedEfsClient{
+		DescribeFileSystemsPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewEfsChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getEfsFileSystemsUsage())
+	assert.Equal(t, expected, svcChecker.getEfsMountTargetsPerFileSystemUsage())
+	assert.Equal(t, expected, svcChecker.getEfsAccessPointsPerFileSystemUsage())
+}
+
+func TestGetEfsPerFileSystemUsageError(t *testing.T) {
+	t.Cleanup(func() { efsFileSystems = []*efs.FileSystemDescription{} })
+	conf.Efs = mockedEfsClient{
+		DescribeFileSystemsPagesResp: efs.DescribeFileSystemsOutput{
+			FileSystems: []*efs.FileSystemDescription{{FileSystemId: aws.String("fs-1")}},
+		},
+		DescribeAccessPointsPagesError: errors.New("test error"),
+	}
+	conf.Ec2 = mockedEc2Client{
+		DescribeAvailabilityZonesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewEfsChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getEfsMountTargetsPerFileSystemUsage())
+	assert.Equal(t, expected, svcChecker.getEfsAccessPointsPerFileSystemUsage())
+}
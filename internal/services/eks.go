@@ -3,33 +3,95 @@ package services
 import (
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/eks"
 )
 
 type EksClientInterface interface {
 	ListClustersPages(input *eks.ListClustersInput, fn func(*eks.ListClustersOutput, bool) bool) error
 	ListNodegroupsPages(input *eks.ListNodegroupsInput, fn func(*eks.ListNodegroupsOutput, bool) bool) error
+	ListFargateProfilesPages(input *eks.ListFargateProfilesInput, fn func(*eks.ListFargateProfilesOutput, bool) bool) error
+	DescribeCluster(input *eks.DescribeClusterInput) (*eks.DescribeClusterOutput, error)
+	DescribeNodegroup(input *eks.DescribeNodegroupInput) (*eks.DescribeNodegroupOutput, error)
+	DescribeFargateProfile(input *eks.DescribeFargateProfileInput) (*eks.DescribeFargateProfileOutput, error)
+}
+
+var eksDefaultQuotas = map[string]quotaDefault{
+	"Fargate profiles per cluster":              {Value: 10},
+	"Selectors per Fargate profile":             {Value: 5},
+	"Labels per managed node group":             {Value: 100},
+	"Nodes per managed node group":              {Value: 450, Code: "L-BD136A63"},
+	"Control plane security groups per cluster": {Value: 4},
+	"Subnets per cluster":                       {Value: 16},
 }
 
 func NewEksChecker() Svcquota {
 	serviceCode := "eks"
 	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
-		"Clusters":                        ServiceChecker.getEKSClusterUsage,
-		"Managed node groups per cluster": ServiceChecker.getEKSNodeGroupsPerClusterUsage,
+		"Clusters":                                  ServiceChecker.getEKSClusterUsage,
+		"Managed node groups per cluster":           ServiceChecker.getEKSNodeGroupsPerClusterUsage,
+		"Fargate profiles per cluster":              ServiceChecker.getEKSFargateProfilesPerClusterUsage,
+		"Selectors per Fargate profile":             ServiceChecker.getEKSSelectorsPerFargateProfileUsage,
+		"Labels per managed node group":             ServiceChecker.getEKSLabelsPerNodeGroupUsage,
+		"Nodes per managed node group":              ServiceChecker.getEKSNodesPerNodeGroupUsage,
+		"Control plane security groups per cluster": ServiceChecker.getEKSSecurityGroupsPerClusterUsage,
+		"Subnets per cluster":                       ServiceChecker.getEKSSubnetsPerClusterUsage,
+	}
+	requiredPermissions := []string{
+		"eks:ListClusters",
+		"eks:ListNodegroups",
+		"eks:ListFargateProfiles",
+		"eks:DescribeCluster",
+		"eks:DescribeNodegroup",
+		"eks:DescribeFargateProfile",
 	}
-	requiredPermissions := []string{"eks:ListClusters", "eks:ListNodegroups"}
 
 	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
 }
 
-func (c ServiceChecker) getEKSClusterUsage() (ret []AWSQuotaInfo) {
-	ret = []AWSQuotaInfo{}
-	clusterNames := []*string{}
-	err := conf.Eks.ListClustersPages(&eks.ListClustersInput{}, func(o *eks.ListClustersOutput, lastPage bool) bool {
-		clusterNames = append(clusterNames, o.Clusters...)
+var eksClusterNames []*string = []*string{}
+
+// getEksClusterNames lists the eks clusters once and shares the result between
+// the different quotas
+func getEksClusterNames() (ret []*string, err error) {
+	ret = eksClusterNames
+	if len(eksClusterNames) != 0 {
+		return
+	}
+
+	err = conf.Eks.ListClustersPages(&eks.ListClustersInput{}, func(o *eks.ListClustersOutput, lastPage bool) bool {
+		eksClusterNames = append(eksClusterNames, o.Clusters...)
+		return true // continue paging
+	})
+	if err != nil {
+		eksClusterNames = []*string{}
+		return eksClusterNames, err
+	}
+	return eksClusterNames, nil
+}
+
+func getEksNodegroupNames(cluster *string) (ret []*string, err error) {
+	ret = []*string{}
+	err = conf.Eks.ListNodegroupsPages(&eks.ListNodegroupsInput{ClusterName: cluster}, func(o *eks.ListNodegroupsOutput, lastPage bool) bool {
+		ret = append(ret, o.Nodegroups...)
+		return true // continue paging
+	})
+	return
+}
+
+func getEksFargateProfileNames(cluster *string) (ret []*string, err error) {
+	ret = []*string{}
+	err = conf.Eks.ListFargateProfilesPages(&eks.ListFargateProfilesInput{ClusterName: cluster}, func(o *eks.ListFargateProfilesOutput, lastPage bool) bool {
+		ret = append(ret, o.FargateProfileNames...)
 		return true // continue paging
 	})
-	quotaInfo := c.GetAllAppliedQuotas()["Clusters"]
+	return
+}
+
+func (c ServiceChecker) getEKSClusterUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	clusterNames, err := getEksClusterNames()
+	quotaInfo := c.getAppliedQuotaOrDefault("Clusters", quotaDefault{Code: "L-1194D53C"})
 
 	if err != nil {
 		fmt.Printf("failed to retrieve eks clusters, %v", err)
@@ -43,25 +105,17 @@ func (c ServiceChecker) getEKSClusterUsage() (ret []AWSQuotaInfo) {
 
 func (c ServiceChecker) getEKSNodeGroupsPerClusterUsage() (ret []AWSQuotaInfo) {
 	ret = []AWSQuotaInfo{}
-	clusterNames := []*string{}
-	errListClusters := conf.Eks.ListClustersPages(&eks.ListClustersInput{}, func(o *eks.ListClustersOutput, lastPage bool) bool {
-		clusterNames = append(clusterNames, o.Clusters...)
-		return true // continue paging
-	})
+	clusterNames, errListClusters := getEksClusterNames()
 	if errListClusters != nil {
 		fmt.Printf("failed to retrieve eks clusters, %v", errListClusters)
 		return
 	}
 
 	for _, cluster := range clusterNames {
-		nodegroups := []*string{}
-		quotaInfo := c.GetAllAppliedQuotas()["Managed node groups per cluster"]
-		errListNodeGroups := conf.Eks.ListNodegroupsPages(&eks.ListNodegroupsInput{ClusterName: cluster}, func(o *eks.ListNodegroupsOutput, lastPage bool) bool {
-			nodegroups = append(nodegroups, o.Nodegroups...)
-			return true // continue paging
-		})
+		quotaInfo := c.getAppliedQuotaOrDefault("Managed node groups per cluster", quotaDefault{Code: "L-6D54EA21"})
+		nodegroups, errListNodeGroups := getEksNodegroupNames(cluster)
 		if errListNodeGroups != nil {
-			fmt.Printf("failed to retrieve nodegroups for cluster %s, %v", *cluster, errListClusters)
+			fmt.Printf("failed to retrieve nodegroups for cluster %s, %v", *cluster, errListNodeGroups)
 			continue
 		}
 
@@ -71,3 +125,195 @@ func (c ServiceChecker) getEKSNodeGroupsPerClusterUsage() (ret []AWSQuotaInfo) {
 	}
 	return
 }
+
+func (c ServiceChecker) getEKSFargateProfilesPerClusterUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	clusterNames, err := getEksClusterNames()
+	if err != nil {
+		fmt.Printf("failed to retrieve eks clusters, %v", err)
+		return
+	}
+
+	for _, cluster := range clusterNames {
+		quotaInfo := c.getAppliedQuotaOrDefault("Fargate profiles per cluster", eksDefaultQuotas["Fargate profiles per cluster"])
+		profiles, errProfiles := getEksFargateProfileNames(cluster)
+		if errProfiles != nil {
+			fmt.Printf("failed to retrieve fargate profiles for cluster %s, %v", *cluster, errProfiles)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(len(profiles))
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::EKS::Cluster::%s", *cluster)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getEKSSelectorsPerFargateProfileUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	clusterNames, err := getEksClusterNames()
+	if err != nil {
+		fmt.Printf("failed to retrieve eks clusters, %v", err)
+		return
+	}
+
+	for _, cluster := range clusterNames {
+		profiles, errProfiles := getEksFargateProfileNames(cluster)
+		if errProfiles != nil {
+			fmt.Printf("failed to retrieve fargate profiles for cluster %s, %v", *cluster, errProfiles)
+			continue
+		}
+		for _, profile := range profiles {
+			quotaInfo := c.getAppliedQuotaOrDefault("Selectors per Fargate profile", eksDefaultQuotas["Selectors per Fargate profile"])
+			result, errDescribe := conf.Eks.DescribeFargateProfile(&eks.DescribeFargateProfileInput{ClusterName: cluster, FargateProfileName: profile})
+			if errDescribe != nil {
+				fmt.Printf("failed to describe fargate profile %s, %v", *profile, errDescribe)
+				continue
+			}
+
+			quotaInfo.UsageValue = float64(len(result.FargateProfile.Selectors))
+			quotaInfo.ResourceId = fmt.Sprintf("AWS::EKS::FargateProfile::%s/%s", *cluster, *profile)
+			ret = append(ret, quotaInfo)
+		}
+	}
+	return
+}
+
+var eksNodegroups []*eks.Nodegroup = []*eks.Nodegroup{}
+
+// getEksNodegroups describes every managed node group of every cluster once and
+// shares the result between the different quotas
+func getEksNodegroups() (ret []*eks.Nodegroup, err error) {
+	ret = eksNodegroups
+	if len(eksNodegroups) != 0 {
+		return
+	}
+
+	clusterNames, err := getEksClusterNames()
+	if err != nil {
+		return
+	}
+
+	for _, cluster := range clusterNames {
+		nodegroups, errListNodeGroups := getEksNodegroupNames(cluster)
+		if errListNodeGroups != nil {
+			fmt.Printf("failed to retrieve nodegroups for cluster %s, %v", *cluster, errListNodeGroups)
+			continue
+		}
+		for _, nodegroup := range nodegroups {
+			result, errDescribe := conf.Eks.DescribeNodegroup(&eks.DescribeNodegroupInput{ClusterName: cluster, NodegroupName: nodegroup})
+			if errDescribe != nil {
+				fmt.Printf("failed to describe nodegroup %s, %v", *nodegroup, errDescribe)
+				continue
+			}
+			eksNodegroups = append(eksNodegroups, result.Nodegroup)
+		}
+	}
+	return eksNodegroups, nil
+}
+
+func eksNodegroupResourceId(n *eks.Nodegroup) string {
+	return fmt.Sprintf("AWS::EKS::Nodegroup::%s/%s", aws.StringValue(n.ClusterName), aws.StringValue(n.NodegroupName))
+}
+
+func (c ServiceChecker) getEKSLabelsPerNodeGroupUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	nodegroups, err := getEksNodegroups()
+	if err != nil {
+		fmt.Printf("failed to retrieve eks nodegroups, %v", err)
+		return
+	}
+
+	for _, n := range nodegroups {
+		quotaInfo := c.getAppliedQuotaOrDefault("Labels per managed node group", eksDefaultQuotas["Labels per managed node group"])
+		quotaInfo.UsageValue = float64(len(n.Labels))
+		quotaInfo.ResourceId = eksNodegroupResourceId(n)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getEKSNodesPerNodeGroupUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	nodegroups, err := getEksNodegroups()
+	if err != nil {
+		fmt.Printf("failed to retrieve eks nodegroups, %v", err)
+		return
+	}
+
+	for _, n := range nodegroups {
+		quotaInfo := c.getAppliedQuotaOrDefault("Nodes per managed node group", eksDefaultQuotas["Nodes per managed node group"])
+		if n.ScalingConfig != nil {
+			// the node group can scale up to its max size, which is the
+			// number of nodes it has to be allowed
+			quotaInfo.UsageValue = float64(aws.Int64Value(n.ScalingConfig.MaxSize))
+		}
+		quotaInfo.ResourceId = eksNodegroupResourceId(n)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+var eksClusters []*eks.Cluster = []*eks.Cluster{}
+
+// getEksClusters describes every cluster once and shares the result between the
+// different quotas
+func getEksClusters() (ret []*eks.Cluster, err error) {
+	ret = eksClusters
+	if len(eksClusters) != 0 {
+		return
+	}
+
+	clusterNames, err := getEksClusterNames()
+	if err != nil {
+		return
+	}
+
+	for _, cluster := range clusterNames {
+		result, errDescribe := conf.Eks.DescribeCluster(&eks.DescribeClusterInput{Name: cluster})
+		if errDescribe != nil {
+			fmt.Printf("failed to describe cluster %s, %v", *cluster, errDescribe)
+			continue
+		}
+		eksClusters = append(eksClusters, result.Cluster)
+	}
+	return eksClusters, nil
+}
+
+func (c ServiceChecker) getEKSSecurityGroupsPerClusterUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	clusters, err := getEksClusters()
+	if err != nil {
+		fmt.Printf("failed to retrieve eks clusters, %v", err)
+		return
+	}
+
+	for _, cluster := range clusters {
+		quotaInfo := c.getAppliedQuotaOrDefault("Control plane security groups per cluster", eksDefaultQuotas["Control plane security groups per cluster"])
+		if cluster.ResourcesVpcConfig != nil {
+			quotaInfo.UsageValue = float64(len(cluster.ResourcesVpcConfig.SecurityGroupIds))
+		}
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::EKS::Cluster::%s", aws.StringValue(cluster.Name))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getEKSSubnetsPerClusterUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	clusters, err := getEksClusters()
+	if err != nil {
+		fmt.Printf("failed to retrieve eks clusters, %v", err)
+		return
+	}
+
+	for _, cluster := range clusters {
+		quotaInfo := c.getAppliedQuotaOrDefault("Subnets per cluster", eksDefaultQuotas["Subnets per cluster"])
+		if cluster.ResourcesVpcConfig != nil {
+			quotaInfo.UsageValue = float64(len(cluster.ResourcesVpcConfig.SubnetIds))
+		}
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::EKS::Cluster::%s", aws.StringValue(cluster.Name))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
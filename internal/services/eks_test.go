@@ -13,10 +13,18 @@ import (
 
 type mockedEksClient struct {
 	EksClientInterface
-	ListClustersPagesResp    eks.ListClustersOutput
-	ListClustersPagesError   error
-	ListNodegroupsPagesResp  eks.ListNodegroupsOutput
-	ListNodegroupsPagesError error
+	ListClustersPagesResp         eks.ListClustersOutput
+	ListClustersPagesError        error
+	ListNodegroupsPagesResp       eks.ListNodegroupsOutput
+	ListNodegroupsPagesError      error
+	ListFargateProfilesPagesResp  eks.ListFargateProfilesOutput
+	ListFargateProfilesPagesError error
+	DescribeClusterResp           eks.DescribeClusterOutput
+	DescribeClusterError          error
+	DescribeNodegroupResp         eks.DescribeNodegroupOutput
+	DescribeNodegroupError        error
+	DescribeFargateProfileResp    eks.DescribeFargateProfileOutput
+	DescribeFargateProfileError   error
 }
 
 func (m mockedEksClient) ListClustersPages(
@@ -33,6 +41,31 @@ func (m mockedEksClient) ListNodegroupsPages(
 	return m.ListNodegroupsPagesError
 }
 
+func (m mockedEksClient) ListFargateProfilesPages(
+	input *eks.ListFargateProfilesInput,
+	fn func(*eks.ListFargateProfilesOutput, bool) bool) error {
+	fn(&m.ListFargateProfilesPagesResp, false)
+	return m.ListFargateProfilesPagesError
+}
+
+func (m mockedEksClient) DescribeCluster(input *eks.DescribeClusterInput) (*eks.DescribeClusterOutput, error) {
+	return &m.DescribeClusterResp, m.DescribeClusterError
+}
+
+func (m mockedEksClient) DescribeNodegroup(input *eks.DescribeNodegroupInput) (*eks.DescribeNodegroupOutput, error) {
+	return &m.DescribeNodegroupResp, m.DescribeNodegroupError
+}
+
+func (m mockedEksClient) DescribeFargateProfile(input *eks.DescribeFargateProfileInput) (*eks.DescribeFargateProfileOutput, error) {
+	return &m.DescribeFargateProfileResp, m.DescribeFargateProfileError
+}
+
+func resetEksCaches() {
+	eksClusterNames = []*string{}
+	eksClusters = []*eks.Cluster{}
+	eksNodegroups = []*eks.Nodegroup{}
+}
+
 func TestNewEksCheckerImpl(t *testing.T) {
 	require.Implements(t, (*Svcquota)(nil), NewEksChecker())
 }
@@ -56,6 +89,7 @@ func TestGetEKSClusterUsage(t *testing.T) {
 	assert.Equal(t, "eks", quota.Service)
 	assert.Equal(t, float64(10), quota.QuotaValue)
 	assert.Equal(t, float64(len(mockedOutput.Clusters)), quota.UsageValue)
+	t.Cleanup(resetEksCaches)
 }
 
 func TestGetEKSClusterUsageError(t *testing.T) {
@@ -73,6 +107,7 @@ func TestGetEKSClusterUsageError(t *testing.T) {
 	actual := svcChecker.getEKSClusterUsage()
 	expected := []AWSQuotaInfo{}
 	assert.Equal(t, expected, actual)
+	t.Cleanup(resetEksCaches)
 }
 
 func TestGetEKSNodeGroupsPerClusterUsage(t *testing.T) {
@@ -98,6 +133,7 @@ func TestGetEKSNodeGroupsPerClusterUsage(t *testing.T) {
 	assert.Equal(t, "AWS::EKS::Cluster::foo", firstQuota.ResourceId)
 	assert.Equal(t, float64(10), firstQuota.QuotaValue)
 	assert.Equal(t, float64(len(mockedListNodegroupsOutput.Nodegroups)), firstQuota.UsageValue)
+	t.Cleanup(resetEksCaches)
 }
 
 func TestGetEKSNodeGroupsPerClusterUsageErrorCluster(t *testing.T) {
@@ -110,12 +146,14 @@ func TestGetEKSNodeGroupsPerClusterUsageErrorCluster(t *testing.T) {
 	conf.Eks = mockedEksClient{
 		ListClustersPagesResp: mockedListClustersOutput, ListClustersPagesError: errors.New("test error"),
 		ListNodegroupsPagesResp: mockedListNodegroupsOutput}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
 
 	eksChecker := NewEksChecker()
 	svcChecker := eksChecker.(*ServiceChecker)
 	actual := svcChecker.getEKSNodeGroupsPerClusterUsage()
 
 	assert.Len(t, actual, 0)
+	t.Cleanup(resetEksCaches)
 }
 
 func TestGetEKSNodeGroupsPerClusterUsageErrorNodeGroup(t *testing.T) {
@@ -138,4 +176,234 @@ func TestGetEKSNodeGroupsPerClusterUsageErrorNodeGroup(t *testing.T) {
 	actual := svcChecker.getEKSNodeGroupsPerClusterUsage()
 
 	assert.Len(t, actual, 0)
+	t.Cleanup(resetEksCaches)
+}
+
+func TestGetEKSClusterNamesShared(t *testing.T) {
+	eksClusterNames = []*string{aws.String("cached")}
+	conf.Eks = mockedEksClient{ListClustersPagesError: errors.New("should not be called")}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	actual, err := getEksClusterNames()
+	assert.Nil(t, err)
+	assert.Len(t, actual, 1)
+	t.Cleanup(resetEksCaches)
+}
+
+func TestGetEKSFargateProfilesPerClusterUsage(t *testing.T) {
+	conf.Eks = mockedEksClient{
+		ListClustersPagesResp:        eks.ListClustersOutput{Clusters: []*string{aws.String("foo")}},
+		ListFargateProfilesPagesResp: eks.ListFargateProfilesOutput{FargateProfileNames: []*string{aws.String("fp1"), aws.String("fp2")}},
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{NewQuota("eks", "Fargate profiles per cluster", float64(10), false)},
+		nil)
+
+	eksChecker := NewEksChecker()
+	svcChecker := eksChecker.(*ServiceChecker)
+	actual := svcChecker.getEKSFargateProfilesPerClusterUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "AWS::EKS::Cluster::foo", actual[0].ResourceId)
+	assert.Equal(t, float64(10), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+	t.Cleanup(resetEksCaches)
+}
+
+func TestGetEKSFargateProfilesPerClusterUsageError(t *testing.T) {
+	conf.Eks = mockedEksClient{
+		ListClustersPagesResp:         eks.ListClustersOutput{Clusters: []*string{aws.String("foo")}},
+		ListFargateProfilesPagesError: errors.New("test error"),
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	eksChecker := NewEksChecker()
+	svcChecker := eksChecker.(*ServiceChecker)
+	actual := svcChecker.getEKSFargateProfilesPerClusterUsage()
+
+	assert.Len(t, actual, 0)
+	t.Cleanup(resetEksCaches)
+}
+
+func TestGetEKSSelectorsPerFargateProfileUsage(t *testing.T) {
+	conf.Eks = mockedEksClient{
+		ListClustersPagesResp:        eks.ListClustersOutput{Clusters: []*string{aws.String("foo")}},
+		ListFargateProfilesPagesResp: eks.ListFargateProfilesOutput{FargateProfileNames: []*string{aws.String("fp1")}},
+		DescribeFargateProfileResp: eks.DescribeFargateProfileOutput{
+			FargateProfile: &eks.FargateProfile{Selectors: []*eks.FargateProfileSelector{{Namespace: aws.String("a")}, {Namespace: aws.String("b")}}},
+		},
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	eksChecker := NewEksChecker()
+	svcChecker := eksChecker.(*ServiceChecker)
+	actual := svcChecker.getEKSSelectorsPerFargateProfileUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "AWS::EKS::FargateProfile::foo/fp1", actual[0].ResourceId)
+	assert.Equal(t, float64(5), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+	t.Cleanup(resetEksCaches)
+}
+
+func TestGetEKSSelectorsPerFargateProfileUsageError(t *testing.T) {
+	conf.Eks = mockedEksClient{
+		ListClustersPagesResp:        eks.ListClustersOutput{Clusters: []*string{aws.String("foo")}},
+		ListFargateProfilesPagesResp: eks.ListFargateProfilesOutput{FargateProfileNames: []*string{aws.String("fp1")}},
+		DescribeFargateProfileError:  errors.New("test error"),
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	eksChecker := NewEksChecker()
+	svcChecker := eksChecker.(*ServiceChecker)
+	actual := svcChecker.getEKSSelectorsPerFargateProfileUsage()
+
+	assert.Len(t, actual, 0)
+	t.Cleanup(resetEksCaches)
+}
+
+func TestGetEKSNodeGroupDetailsUsage(t *testing.T) {
+	conf.Eks = mockedEksClient{
+		ListClustersPagesResp:   eks.ListClustersOutput{Clusters: []*string{aws.String("foo")}},
+		ListNodegroupsPagesResp: eks.ListNodegroupsOutput{Nodegroups: []*string{aws.String("ng1")}},
+		DescribeNodegroupResp: eks.DescribeNodegroupOutput{
+			Nodegroup: &eks.Nodegroup{
+				ClusterName:   aws.String("foo"),
+				NodegroupName: aws.String("ng1"),
+				Labels:        map[string]*string{"a": aws.String("b"), "c": aws.String("d")},
+				ScalingConfig: &eks.NodegroupScalingConfig{DesiredSize: aws.Int64(3), MaxSize: aws.Int64(5)},
+			},
+		},
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{NewQuota("eks", "Nodes per managed node group", float64(450), false)},
+		nil)
+
+	eksChecker := NewEksChecker()
+	svcChecker := eksChecker.(*ServiceChecker)
+
+	labels := svcChecker.getEKSLabelsPerNodeGroupUsage()
+	assert.Len(t, labels, 1)
+	assert.Equal(t, "AWS::EKS::Nodegroup::foo/ng1", labels[0].ResourceId)
+	assert.Equal(t, float64(2), labels[0].UsageValue)
+
+	nodes := svcChecker.getEKSNodesPerNodeGroupUsage()
+	assert.Len(t, nodes, 1)
+	assert.Equal(t, float64(450), nodes[0].QuotaValue)
+	assert.Equal(t, float64(5), nodes[0].UsageValue)
+	t.Cleanup(resetEksCaches)
+}
+
+func TestGetEKSNodeGroupDetailsUsageError(t *testing.T) {
+	conf.Eks = mockedEksClient{
+		ListClustersPagesResp:   eks.ListClustersOutput{Clusters: []*string{aws.String("foo")}},
+		ListNodegroupsPagesResp: eks.ListNodegroupsOutput{Nodegroups: []*string{aws.String("ng1")}},
+		DescribeNodegroupError:  errors.New("test error"),
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	eksChecker := NewEksChecker()
+	svcChecker := eksChecker.(*ServiceChecker)
+
+	assert.Len(t, svcChecker.getEKSLabelsPerNodeGroupUsage(), 0)
+	assert.Len(t, svcChecker.getEKSNodesPerNodeGroupUsage(), 0)
+	t.Cleanup(resetEksCaches)
+}
+
+func TestGetEKSClusterVpcConfigUsage(t *testing.T) {
+	conf.Eks = mockedEksClient{
+		ListClustersPagesResp: eks.ListClustersOutput{Clusters: []*string{aws.String("foo")}},
+		DescribeClusterResp: eks.DescribeClusterOutput{
+			Cluster: &eks.Cluster{
+				Name: aws.String("foo"),
+				ResourcesVpcConfig: &eks.VpcConfigResponse{
+					SecurityGroupIds: []*string{aws.String("sg-1")},
+					SubnetIds:        []*string{aws.String("subnet-1"), aws.String("subnet-2")},
+				},
+			},
+		},
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	eksChecker := NewEksChecker()
+	svcChecker := eksChecker.(*ServiceChecker)
+
+	sgs := svcChecker.getEKSSecurityGroupsPerClusterUsage()
+	assert.Len(t, sgs, 1)
+	assert.Equal(t, "AWS::EKS::Cluster::foo", sgs[0].ResourceId)
+	assert.Equal(t, float64(1), sgs[0].UsageValue)
+
+	subnets := svcChecker.getEKSSubnetsPerClusterUsage()
+	assert.Len(t, subnets, 1)
+	assert.Equal(t, float64(2), subnets[0].UsageValue)
+	t.Cleanup(resetEksCaches)
+}
+
+func TestGetEKSClusterVpcConfigUsageError(t *testing.T) {
+	conf.Eks = mockedEksClient{
+		ListClustersPagesResp: eks.ListClustersOutput{Clusters: []*string{aws.String("foo")}},
+		DescribeClusterError:  errors.New("test error"),
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	eksChecker := NewEksChecker()
+	svcChecker := eksChecker.(*ServiceChecker)
+
+	assert.Len(t, svcChecker.getEKSSecurityGroupsPerClusterUsage(), 0)
+	assert.Len(t, svcChecker.getEKSSubnetsPerClusterUsage(), 0)
+	t.Cleanup(resetEksCaches)
+}
+
+func TestGetEKSNodesPerNodeGroupUsageOverride(t *testing.T) {
+	conf.Eks = mockedEksClient{
+		ListClustersPagesResp:   eks.ListClustersOutput{Clusters: []*string{aws.String("foo")}},
+		ListNodegroupsPagesResp: eks.ListNodegroupsOutput{Nodegroups: []*string{aws.String("ng1")}},
+		DescribeNodegroupResp: eks.DescribeNodegroupOutput{
+			Nodegroup: &eks.Nodegroup{
+				ClusterName:   aws.String("foo"),
+				NodegroupName: aws.String("ng1"),
+				ScalingConfig: &eks.NodegroupScalingConfig{DesiredSize: aws.Int64(3), MaxSize: aws.Int64(5)},
+			},
+		},
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	eksChecker := NewEksChecker()
+	svcChecker := eksChecker.(*ServiceChecker)
+	actual := ApplyQuotaOverrides(svcChecker.getEKSNodesPerNodeGroupUsage(), []AWSQuotaOverride{
+		{Service: "eks", QuotaName: "Nodes per managed node group", ResourceId: "AWS::EKS::Nodegroup::bar/*", QuotaValue: float64(20)},
+		{Service: "eks", QuotaName: "Nodes per managed node group", ResourceId: "AWS::EKS::Nodegroup::foo/*", QuotaValue: float64(10)}}, "")
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, float64(10), actual[0].QuotaValue)
+	assert.Equal(t, float64(5), actual[0].UsageValue)
+	assert.Equal(t, QuotaSourceOverride, actual[0].Source)
+	t.Cleanup(resetEksCaches)
+}
+
+func TestGetEKSDescribedResourcesShared(t *testing.T) {
+	conf.Eks = mockedEksClient{
+		ListClustersPagesResp:   eks.ListClustersOutput{Clusters: []*string{aws.String("foo")}},
+		ListNodegroupsPagesResp: eks.ListNodegroupsOutput{Nodegroups: []*string{aws.String("ng1")}},
+		DescribeClusterResp:     eks.DescribeClusterOutput{Cluster: &eks.Cluster{Name: aws.String("foo")}},
+		DescribeNodegroupResp: eks.DescribeNodegroupOutput{
+			Nodegroup: &eks.Nodegroup{ClusterName: aws.String("foo"), NodegroupName: aws.String("ng1")},
+		},
+	}
+	t.Cleanup(resetEksCaches)
+	clusters, err := getEksClusters()
+	assert.Nil(t, err)
+	nodegroups, err := getEksNodegroups()
+	assert.Nil(t, err)
+
+	conf.Eks = mockedEksClient{
+		DescribeClusterError:   errors.New("test error"),
+		DescribeNodegroupError: errors.New("test error"),
+	}
+	cachedClusters, err := getEksClusters()
+	assert.Nil(t, err)
+	assert.Equal(t, clusters, cachedClusters)
+	cachedNodegroups, err := getEksNodegroups()
+	assert.Nil(t, err)
+	assert.Equal(t, nodegroups, cachedNodegroups)
 }
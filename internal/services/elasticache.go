@@ -8,14 +8,23 @@ import (
 
 type ElastiCacheClientInterface interface {
 	DescribeCacheClustersPages(input *elasticache.DescribeCacheClustersInput, fn func(*elasticache.DescribeCacheClustersOutput, bool) bool) error
+	DescribeServerlessCachesPages(input *elasticache.DescribeServerlessCachesInput, fn func(*elasticache.DescribeServerlessCachesOutput, bool) bool) error
+}
+
+var elastiCacheDefaultQuotas = map[string]quotaDefault{
+	"Serverless caches per Region": {Value: 40},
 }
 
 func NewElastiCacheChecker() Svcquota {
 	serviceCode := "elasticache"
 	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
-		"Nodes per Region": ServiceChecker.getElastiCacheNodesUsage,
+		"Nodes per Region":             ServiceChecker.getElastiCacheNodesUsage,
+		"Serverless caches per Region": ServiceChecker.getElastiCacheServerlessCachesUsage,
+	}
+	requiredPermissions := []string{
+		"elasticache:DescribeCacheClusters",
+		"elasticache:DescribeServerlessCaches",
 	}
-	requiredPermissions := []string{"elasticache:DescribeCacheClusters"}
 
 	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
 }
@@ -27,7 +36,7 @@ func (c ServiceChecker) getElastiCacheNodesUsage() (ret []AWSQuotaInfo) {
 		nodeNames = append(nodeNames, p.CacheClusters...)
 		return true // continue paging
 	})
-	quotaInfo := c.GetAllAppliedQuotas()["Nodes per Region"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Nodes per Region", quotaDefault{})
 
 	if err != nil {
 		fmt.Printf("failed to retrieve elasticache nodes, %v", err)
@@ -38,3 +47,22 @@ func (c ServiceChecker) getElastiCacheNodesUsage() (ret []AWSQuotaInfo) {
 	ret = append(ret, quotaInfo)
 	return
 }
+
+func (c ServiceChecker) getElastiCacheServerlessCachesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	caches := 0
+	err := conf.ElastiCache.DescribeServerlessCachesPages(&elasticache.DescribeServerlessCachesInput{}, func(p *elasticache.DescribeServerlessCachesOutput, lastPage bool) bool {
+		caches += len(p.ServerlessCaches)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve elasticache serverless caches, %v", err)
+		return
+	}
+
+	quotaName := "Serverless caches per Region"
+	quotaInfo := c.getAppliedQuotaOrDefault(quotaName, elastiCacheDefaultQuotas[quotaName])
+	quotaInfo.UsageValue = float64(caches)
+	ret = append(ret, quotaInfo)
+	return
+}
@@ -13,8 +13,10 @@ import (
 
 type mockedElastiCacheClient struct {
 	ElastiCacheClientInterface
-	DescribeCacheClustersPagesResp  elasticache.DescribeCacheClustersOutput
-	DescribeCacheClustersPagesError error
+	DescribeCacheClustersPagesResp     elasticache.DescribeCacheClustersOutput
+	DescribeCacheClustersPagesError    error
+	DescribeServerlessCachesPagesResp  elasticache.DescribeServerlessCachesOutput
+	DescribeServerlessCachesPagesError error
 }
 
 func (m mockedElastiCacheClient) DescribeCacheClustersPages(input *elasticache.DescribeCacheClustersInput, fn func(*elasticache.DescribeCacheClustersOutput, bool) bool) error {
@@ -22,6 +24,10 @@ func (m mockedElastiCacheClient) DescribeCacheClustersPages(input *elasticache.D
 	return m.DescribeCacheClustersPagesError
 }
 
+func (m mockedElastiCacheClient) DescribeServerlessCachesPages(input *elasticache.DescribeServerlessCachesInput, fn func(*elasticache.DescribeServerlessCachesOutput, bool) bool) error {
+	return mockPages(m.DescribeServerlessCachesPagesResp, m.DescribeServerlessCachesPagesError, fn)
+}
+
 func TestNewElastiCacheCheckerImpl(t *testing.T) {
 	require.Implements(t, (*Svcquota)(nil), NewElastiCacheChecker())
 }
@@ -64,3 +70,30 @@ func TestGetElastiCacheNodesUsageError(t *testing.T) {
 	expected := []AWSQuotaInfo{}
 	assert.Equal(t, expected, actual)
 }
+
+func TestGetElastiCacheServerlessCachesUsage(t *testing.T) {
+	conf.ElastiCache = mockedElastiCacheClient{
+		DescribeServerlessCachesPagesResp: elasticache.DescribeServerlessCachesOutput{
+			ServerlessCaches: []*elasticache.ServerlessCache{{}, {}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewElastiCacheChecker)
+
+	actual := svcChecker.getElastiCacheServerlessCachesUsage()
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "Serverless caches per Region", actual[0].QuotaName)
+	assert.Equal(t, float64(40), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+
+	svcChecker = newTestServiceChecker(NewElastiCacheChecker,
+		NewQuota("elasticache", "Serverless caches per Region", float64(100), false))
+	actual = svcChecker.getElastiCacheServerlessCachesUsage()
+	assert.Equal(t, float64(100), actual[0].QuotaValue)
+}
+
+func TestGetElastiCacheServerlessCachesUsageError(t *testing.T) {
+	conf.ElastiCache = mockedElastiCacheClient{DescribeServerlessCachesPagesError: errors.New("test error")}
+	svcChecker := newTestServiceChecker(NewElastiCacheChecker)
+
+	assert.Equal(t, []AWSQuotaInfo{}, svcChecker.getElastiCacheServerlessCachesUsage())
+}
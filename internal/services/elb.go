@@ -18,23 +18,73 @@ type ElbClientInterface interface {
 type Elbv2ClientInterface interface {
 	DescribeAccountLimits(input *elbv2.DescribeAccountLimitsInput) (*elbv2.DescribeAccountLimitsOutput, error)
 	DescribeLoadBalancersPages(input *elbv2.DescribeLoadBalancersInput, fn func(*elbv2.DescribeLoadBalancersOutput, bool) bool) error
+	DescribeListenersPages(input *elbv2.DescribeListenersInput, fn func(*elbv2.DescribeListenersOutput, bool) bool) error
+	DescribeRules(input *elbv2.DescribeRulesInput) (*elbv2.DescribeRulesOutput, error)
+	DescribeListenerCertificates(input *elbv2.DescribeListenerCertificatesInput) (*elbv2.DescribeListenerCertificatesOutput, error)
+	DescribeTargetGroupsPages(input *elbv2.DescribeTargetGroupsInput, fn func(*elbv2.DescribeTargetGroupsOutput, bool) bool) error
+	DescribeTargetHealth(input *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error)
 }
 
 func NewElbChecker() Svcquota {
 	serviceCode := "elasticloadbalancing"
 	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
-		"Classic Load Balancers per Region":     ServiceChecker.getElbClassicLoadBalancerUsage,
-		"Application Load Balancers per Region": ServiceChecker.getElbApplicationLoadBalancerUsage,
-		"Network Load Balancers per Region":     ServiceChecker.getElbNetworkLoadBalancerUsage,
+		"Classic Load Balancers per Region":              ServiceChecker.getElbClassicLoadBalancerUsage,
+		"Application Load Balancers per Region":          ServiceChecker.getElbApplicationLoadBalancerUsage,
+		"Network Load Balancers per Region":              ServiceChecker.getElbNetworkLoadBalancerUsage,
+		"Listeners per Application Load Balancer":        ServiceChecker.getElbListenersPerApplicationLoadBalancerUsage,
+		"Listeners per Network Load Balancer":            ServiceChecker.getElbListenersPerNetworkLoadBalancerUsage,
+		"Listeners per Classic Load Balancer":            ServiceChecker.getElbListenersPerClassicLoadBalancerUsage,
+		"Rules per Application Load Balancer":            ServiceChecker.getElbRulesPerApplicationLoadBalancerUsage,
+		"Certificates per Application Load Balancer":     ServiceChecker.getElbCertificatesPerApplicationLoadBalancerUsage,
+		"Target Groups per Region":                       ServiceChecker.getElbTargetGroupsUsage,
+		"Targets per Target Group per Region":            ServiceChecker.getElbTargetsPerTargetGroupUsage,
+		"Targets per Application Load Balancer":          ServiceChecker.getElbTargetsPerApplicationLoadBalancerUsage,
+		"Targets per Network Load Balancer":              ServiceChecker.getElbTargetsPerNetworkLoadBalancerUsage,
+		"Registered Instances per Classic Load Balancer": ServiceChecker.getElbInstancesPerClassicLoadBalancerUsage,
 	}
 	requiredPermissions := []string{
 		"elasticloadbalancing:DescribeLoadBalancers",
 		"elasticloadbalancing:DescribeAccountLimits",
+		"elasticloadbalancing:DescribeListeners",
+		"elasticloadbalancing:DescribeRules",
+		"elasticloadbalancing:DescribeListenerCertificates",
+		"elasticloadbalancing:DescribeTargetGroups",
+		"elasticloadbalancing:DescribeTargetHealth",
 	}
 
 	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
 }
 
+// elbAccountQuotaNames maps the servicequotas quota name to the name returned
+// by DescribeAccountLimits
+var elbAccountQuotaNames = map[string]string{
+	"Classic Load Balancers per Region":              "classic-load-balancers",
+	"Application Load Balancers per Region":          "application-load-balancers",
+	"Network Load Balancers per Region":              "network-load-balancers",
+	"Listeners per Application Load Balancer":        "listeners-per-application-load-balancer",
+	"Listeners per Network Load Balancer":            "listeners-per-network-load-balancer",
+	"Listeners per Classic Load Balancer":            "classic-listeners",
+	"Rules per Application Load Balancer":            "rules-per-application-load-balancer",
+	"Certificates per Application Load Balancer":     "certificates-per-application-load-balancer",
+	"Target Groups per Region":                       "target-groups",
+	"Targets per Application Load Balancer":          "targets-per-application-load-balancer",
+	"Targets per Network Load Balancer":              "targets-per-network-load-balancer",
+	"Registered Instances per Classic Load Balancer": "classic-registered-instances",
+}
+
+var elbDefaultQuotas = map[string]quotaDefault{
+	"Listeners per Application Load Balancer":        {Value: 50},
+	"Listeners per Network Load Balancer":            {Value: 50},
+	"Listeners per Classic Load Balancer":            {Value: 100},
+	"Rules per Application Load Balancer":            {Value: 100},
+	"Certificates per Application Load Balancer":     {Value: 25},
+	"Target Groups per Region":                       {Value: 3000, Code: "L-B22855CB"},
+	"Targets per Target Group per Region":            {Value: 1000},
+	"Targets per Application Load Balancer":          {Value: 1000},
+	"Targets per Network Load Balancer":              {Value: 3000},
+	"Registered Instances per Classic Load Balancer": {Value: 1000},
+}
+
 var elbAccountQuota map[string]float64 = map[string]float64{}
 
 func (c ServiceChecker) getElbAccountQuotas() (ret map[string]float64) {
@@ -59,20 +109,122 @@ func (c ServiceChecker) getElbAccountQuotas() (ret map[string]float64) {
 	return
 }
 
-func (c ServiceChecker) getElbApplicationLoadBalancerUsage() (ret []AWSQuotaInfo) {
-	ret = []AWSQuotaInfo{}
-	quotaInfo := c.GetAllAppliedQuotas()["Application Load Balancers per Region"]
+// getElbQuota returns the quota with the given name. When the account limits
+// reported by the service include it, that value wins over servicequotas'
+func (c ServiceChecker) getElbQuota(quotaName string) (ret AWSQuotaInfo) {
+	ret = c.getAppliedQuotaOrDefault(quotaName, elbDefaultQuotas[quotaName])
+	if limitName, ok := elbAccountQuotaNames[quotaName]; ok {
+		if val, ok := c.getElbAccountQuotas()[limitName]; ok {
+			ret = withServiceValue(ret, val)
+		}
+	}
+	return
+}
 
-	// we need to iterate through all LBs and check which ones are NLB vs ALB
-	albs := []*elbv2.LoadBalancer{}
-	err := conf.Elbv2.DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{}, func(p *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
-		for _, q := range p.LoadBalancers {
-			if *q.Type == "application" {
-				albs = append(albs, q)
-			}
+var elbv2LoadBalancers []*elbv2.LoadBalancer = []*elbv2.LoadBalancer{}
+
+// getElbv2LoadBalancers returns the load balancers of the given type. They are
+// listed once and shared between the different quotas
+func getElbv2LoadBalancers(lbType string) (ret []*elbv2.LoadBalancer, err error) {
+	ret = []*elbv2.LoadBalancer{}
+	if len(elbv2LoadBalancers) == 0 {
+		err = conf.Elbv2.DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{}, func(p *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
+			elbv2LoadBalancers = append(elbv2LoadBalancers, p.LoadBalancers...)
+			return true // continue paging
+		})
+		if err != nil {
+			elbv2LoadBalancers = []*elbv2.LoadBalancer{}
+			return
+		}
+	}
+
+	for _, lb := range elbv2LoadBalancers {
+		if aws.StringValue(lb.Type) == lbType {
+			ret = append(ret, lb)
 		}
+	}
+	return
+}
+
+func getElbClassicLoadBalancers() (ret []*elb.LoadBalancerDescription, err error) {
+	ret = []*elb.LoadBalancerDescription{}
+	err = conf.Elb.DescribeLoadBalancersPages(&elb.DescribeLoadBalancersInput{}, func(p *elb.DescribeLoadBalancersOutput, lastPage bool) bool {
+		ret = append(ret, p.LoadBalancerDescriptions...)
 		return true // continue paging
 	})
+	return
+}
+
+// elbv2Listeners holds the listeners of each load balancer, keyed by arn
+var elbv2Listeners map[string][]*elbv2.Listener = map[string][]*elbv2.Listener{}
+
+// getElbv2Listeners returns the listeners of the given load balancer, listed
+// once and shared between the different quotas
+func getElbv2Listeners(lbArn *string) (ret []*elbv2.Listener, err error) {
+	if listeners, ok := elbv2Listeners[aws.StringValue(lbArn)]; ok {
+		return listeners, nil
+	}
+
+	ret = []*elbv2.Listener{}
+	err = conf.Elbv2.DescribeListenersPages(&elbv2.DescribeListenersInput{LoadBalancerArn: lbArn}, func(p *elbv2.DescribeListenersOutput, lastPage bool) bool {
+		ret = append(ret, p.Listeners...)
+		return true // continue paging
+	})
+	if err != nil {
+		return []*elbv2.Listener{}, err
+	}
+	elbv2Listeners[aws.StringValue(lbArn)] = ret
+	return
+}
+
+var elbv2TargetGroups []*elbv2.TargetGroup = []*elbv2.TargetGroup{}
+
+// getElbv2TargetGroups lists the target groups once and shares the result
+// between the different quotas
+func getElbv2TargetGroups() (ret []*elbv2.TargetGroup, err error) {
+	ret = elbv2TargetGroups
+	if len(elbv2TargetGroups) != 0 {
+		return
+	}
+
+	err = conf.Elbv2.DescribeTargetGroupsPages(&elbv2.DescribeTargetGroupsInput{}, func(p *elbv2.DescribeTargetGroupsOutput, lastPage bool) bool {
+		elbv2TargetGroups = append(elbv2TargetGroups, p.TargetGroups...)
+		return true // continue paging
+	})
+	if err != nil {
+		elbv2TargetGroups = []*elbv2.TargetGroup{}
+		return elbv2TargetGroups, err
+	}
+	return elbv2TargetGroups, nil
+}
+
+// elbv2TargetsPerTargetGroup holds the number of registered targets, keyed by
+// target group arn
+var elbv2TargetsPerTargetGroup map[string]int = map[string]int{}
+
+// getElbv2TargetsPerTargetGroup returns the number of registered targets, keyed
+// by target group arn. Target health is only described once per target group
+func getElbv2TargetsPerTargetGroup(targetGroups []*elbv2.TargetGroup) (ret map[string]int) {
+	ret = elbv2TargetsPerTargetGroup
+	for _, tg := range targetGroups {
+		if _, ok := elbv2TargetsPerTargetGroup[aws.StringValue(tg.TargetGroupArn)]; ok {
+			continue
+		}
+		result, err := conf.Elbv2.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{TargetGroupArn: tg.TargetGroupArn})
+		if err != nil {
+			fmt.Printf("failed to retrieve targets for target group %s, %v", aws.StringValue(tg.TargetGroupArn), err)
+			continue
+		}
+		elbv2TargetsPerTargetGroup[aws.StringValue(tg.TargetGroupArn)] = len(result.TargetHealthDescriptions)
+	}
+	return
+}
+
+func (c ServiceChecker) getElbApplicationLoadBalancerUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	quotaInfo := c.getAppliedQuotaOrDefault("Application Load Balancers per Region", quotaDefault{Code: "L-53DA6B97"})
+
+	albs, err := getElbv2LoadBalancers("application")
 	if err != nil {
 		fmt.Printf("failed to retrieve load balancers, %v", err)
 		return
@@ -80,7 +232,7 @@ func (c ServiceChecker) getElbApplicationLoadBalancerUsage() (ret []AWSQuotaInfo
 
 	// we then get the quota info from the service itself (overwrites servicequotas')
 	if val, ok := c.getElbAccountQuotas()["application-load-balancers"]; ok {
-		quotaInfo.QuotaValue = val
+		quotaInfo = withServiceValue(quotaInfo, val)
 	}
 
 	quotaInfo.UsageValue = float64(len(albs))
@@ -90,7 +242,7 @@ func (c ServiceChecker) getElbApplicationLoadBalancerUsage() (ret []AWSQuotaInfo
 
 func (c ServiceChecker) getElbClassicLoadBalancerUsage() (ret []AWSQuotaInfo) {
 	ret = []AWSQuotaInfo{}
-	quotaInfo := c.GetAllAppliedQuotas()["Classic Load Balancers per Region"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Classic Load Balancers per Region", quotaDefault{Code: "L-E9E9831D"})
 
 	// we need to iterate through all LBs and check which ones are NLB vs ALB
 	classic := []*elb.LoadBalancerDescription{}
@@ -105,7 +257,7 @@ func (c ServiceChecker) getElbClassicLoadBalancerUsage() (ret []AWSQuotaInfo) {
 
 	// we then get the quota info from the service itself (overwrites servicequotas')
 	if val, ok := c.getElbAccountQuotas()["classic-load-balancers"]; ok {
-		quotaInfo.QuotaValue = val
+		quotaInfo = withServiceValue(quotaInfo, val)
 	}
 
 	quotaInfo.UsageValue = float64(len(classic))
@@ -115,18 +267,9 @@ func (c ServiceChecker) getElbClassicLoadBalancerUsage() (ret []AWSQuotaInfo) {
 
 func (c ServiceChecker) getElbNetworkLoadBalancerUsage() (ret []AWSQuotaInfo) {
 	ret = []AWSQuotaInfo{}
-	quotaInfo := c.GetAllAppliedQuotas()["Network Load Balancers per Region"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Network Load Balancers per Region", quotaDefault{Code: "L-69A177A2"})
 
-	// we need to iterate through all LBs and check which ones are NLB vs ALB
-	nlbs := []*elbv2.LoadBalancer{}
-	err := conf.Elbv2.DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{}, func(p *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
-		for _, q := range p.LoadBalancers {
-			if *q.Type == "network" {
-				nlbs = append(nlbs, q)
-			}
-		}
-		return true // continue paging
-	})
+	nlbs, err := getElbv2LoadBalancers("network")
 	if err != nil {
 		fmt.Printf("failed to retrieve network load balancers, %v", err)
 		return
@@ -134,10 +277,249 @@ func (c ServiceChecker) getElbNetworkLoadBalancerUsage() (ret []AWSQuotaInfo) {
 
 	// we then get the quota info from the service itself (overwrites servicequotas')
 	if val, ok := c.getElbAccountQuotas()["network-load-balancers"]; ok {
-		quotaInfo.QuotaValue = val
+		quotaInfo = withServiceValue(quotaInfo, val)
 	}
 
 	quotaInfo.UsageValue = float64(len(nlbs))
 	ret = append(ret, quotaInfo)
 	return
 }
+
+func elbv2ResourceId(lb *elbv2.LoadBalancer) string {
+	return fmt.Sprintf("AWS::ElasticLoadBalancingV2::LoadBalancer::%s", aws.StringValue(lb.LoadBalancerName))
+}
+
+func elbClassicResourceId(lb *elb.LoadBalancerDescription) string {
+	return fmt.Sprintf("AWS::ElasticLoadBalancing::LoadBalancer::%s", aws.StringValue(lb.LoadBalancerName))
+}
+
+func (c ServiceChecker) getElbv2ListenersUsage(quotaName string, lbType string) (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	lbs, err := getElbv2LoadBalancers(lbType)
+	if err != nil {
+		fmt.Printf("failed to retrieve %s load balancers, %v", lbType, err)
+		return
+	}
+
+	for _, lb := range lbs {
+		quotaInfo := c.getElbQuota(quotaName)
+		listeners, errListeners := getElbv2Listeners(lb.LoadBalancerArn)
+		if errListeners != nil {
+			fmt.Printf("failed to retrieve listeners for load balancer %s, %v", aws.StringValue(lb.LoadBalancerName), errListeners)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(len(listeners))
+		quotaInfo.ResourceId = elbv2ResourceId(lb)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getElbListenersPerApplicationLoadBalancerUsage() (ret []AWSQuotaInfo) {
+	return c.getElbv2ListenersUsage("Listeners per Application Load Balancer", "application")
+}
+
+func (c ServiceChecker) getElbListenersPerNetworkLoadBalancerUsage() (ret []AWSQuotaInfo) {
+	return c.getElbv2ListenersUsage("Listeners per Network Load Balancer", "network")
+}
+
+func (c ServiceChecker) getElbListenersPerClassicLoadBalancerUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	lbs, err := getElbClassicLoadBalancers()
+	if err != nil {
+		fmt.Printf("failed to retrieve classic load balancers, %v", err)
+		return
+	}
+
+	for _, lb := range lbs {
+		quotaInfo := c.getElbQuota("Listeners per Classic Load Balancer")
+		quotaInfo.UsageValue = float64(len(lb.ListenerDescriptions))
+		quotaInfo.ResourceId = elbClassicResourceId(lb)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getElbInstancesPerClassicLoadBalancerUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	lbs, err := getElbClassicLoadBalancers()
+	if err != nil {
+		fmt.Printf("failed to retrieve classic load balancers, %v", err)
+		return
+	}
+
+	for _, lb := range lbs {
+		quotaInfo := c.getElbQuota("Registered Instances per Classic Load Balancer")
+		quotaInfo.UsageValue = float64(len(lb.Instances))
+		quotaInfo.ResourceId = elbClassicResourceId(lb)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getElbRulesPerApplicationLoadBalancerUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	albs, err := getElbv2LoadBalancers("application")
+	if err != nil {
+		fmt.Printf("failed to retrieve application load balancers, %v", err)
+		return
+	}
+
+	for _, lb := range albs {
+		quotaInfo := c.getElbQuota("Rules per Application Load Balancer")
+		listeners, errListeners := getElbv2Listeners(lb.LoadBalancerArn)
+		if errListeners != nil {
+			fmt.Printf("failed to retrieve listeners for load balancer %s, %v", aws.StringValue(lb.LoadBalancerName), errListeners)
+			continue
+		}
+
+		rules := 0
+		for _, l := range listeners {
+			input := &elbv2.DescribeRulesInput{ListenerArn: l.ListenerArn}
+			for {
+				result, errRules := conf.Elbv2.DescribeRules(input)
+				if errRules != nil {
+					fmt.Printf("failed to retrieve rules for listener %s, %v", aws.StringValue(l.ListenerArn), errRules)
+					break
+				}
+				// default rules do not count against the quota
+				for _, r := range result.Rules {
+					if !aws.BoolValue(r.IsDefault) {
+						rules++
+					}
+				}
+				if result.NextMarker == nil {
+					break
+				}
+				input.Marker = result.NextMarker
+			}
+		}
+
+		quotaInfo.UsageValue = float64(rules)
+		quotaInfo.ResourceId = elbv2ResourceId(lb)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getElbCertificatesPerApplicationLoadBalancerUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	albs, err := getElbv2LoadBalancers("application")
+	if err != nil {
+		fmt.Printf("failed to retrieve application load balancers, %v", err)
+		return
+	}
+
+	for _, lb := range albs {
+		quotaInfo := c.getElbQuota("Certificates per Application Load Balancer")
+		listeners, errListeners := getElbv2Listeners(lb.LoadBalancerArn)
+		if errListeners != nil {
+			fmt.Printf("failed to retrieve listeners for load balancer %s, %v", aws.StringValue(lb.LoadBalancerName), errListeners)
+			continue
+		}
+
+		certificates := 0
+		for _, l := range listeners {
+			input := &elbv2.DescribeListenerCertificatesInput{ListenerArn: l.ListenerArn}
+			for {
+				result, errCertificates := conf.Elbv2.DescribeListenerCertificates(input)
+				if errCertificates != nil {
+					fmt.Printf("failed to retrieve certificates for listener %s, %v", aws.StringValue(l.ListenerArn), errCertificates)
+					break
+				}
+				// default certificates do not count against the quota
+				for _, cert := range result.Certificates {
+					if !aws.BoolValue(cert.IsDefault) {
+						certificates++
+					}
+				}
+				if result.NextMarker == nil {
+					break
+				}
+				input.Marker = result.NextMarker
+			}
+		}
+
+		quotaInfo.UsageValue = float64(certificates)
+		quotaInfo.ResourceId = elbv2ResourceId(lb)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getElbTargetGroupsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	targetGroups, err := getElbv2TargetGroups()
+	if err != nil {
+		fmt.Printf("failed to retrieve target groups, %v", err)
+		return
+	}
+
+	quotaInfo := c.getElbQuota("Target Groups per Region")
+	quotaInfo.UsageValue = float64(len(targetGroups))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getElbTargetsPerTargetGroupUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	targetGroups, err := getElbv2TargetGroups()
+	if err != nil {
+		fmt.Printf("failed to retrieve target groups, %v", err)
+		return
+	}
+
+	targets := getElbv2TargetsPerTargetGroup(targetGroups)
+	for _, tg := range targetGroups {
+		count, ok := targets[aws.StringValue(tg.TargetGroupArn)]
+		if !ok {
+			continue
+		}
+		quotaInfo := c.getElbQuota("Targets per Target Group per Region")
+		quotaInfo.UsageValue = float64(count)
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::ElasticLoadBalancingV2::TargetGroup::%s", aws.StringValue(tg.TargetGroupName))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+// getElbv2TargetsPerLoadBalancerUsage sums the targets of all the target
+// groups attached to each load balancer of the given type
+func (c ServiceChecker) getElbv2TargetsPerLoadBalancerUsage(quotaName string, lbType string) (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	lbs, err := getElbv2LoadBalancers(lbType)
+	if err != nil {
+		fmt.Printf("failed to retrieve %s load balancers, %v", lbType, err)
+		return
+	}
+	targetGroups, err := getElbv2TargetGroups()
+	if err != nil {
+		fmt.Printf("failed to retrieve target groups, %v", err)
+		return
+	}
+
+	targets := getElbv2TargetsPerTargetGroup(targetGroups)
+	targetsPerLb := map[string]int{}
+	for _, tg := range targetGroups {
+		for _, lbArn := range tg.LoadBalancerArns {
+			targetsPerLb[aws.StringValue(lbArn)] += targets[aws.StringValue(tg.TargetGroupArn)]
+		}
+	}
+
+	for _, lb := range lbs {
+		quotaInfo := c.getElbQuota(quotaName)
+		quotaInfo.UsageValue = float64(targetsPerLb[aws.StringValue(lb.LoadBalancerArn)])
+		quotaInfo.ResourceId = elbv2ResourceId(lb)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getElbTargetsPerApplicationLoadBalancerUsage() (ret []AWSQuotaInfo) {
+	return c.getElbv2TargetsPerLoadBalancerUsage("Targets per Application Load Balancer", "application")
+}
+
+func (c ServiceChecker) getElbTargetsPerNetworkLoadBalancerUsage() (ret []AWSQuotaInfo) {
+	return c.getElbv2TargetsPerLoadBalancerUsage("Targets per Network Load Balancer", "network")
+}
@@ -31,10 +31,21 @@ func (m mockedElbClient) DescribeLoadBalancersPages(input *elb.DescribeLoadBalan
 
 type mockedElbv2Client struct {
 	Elbv2ClientInterface
-	DescribeAccountLimitsResp       elbv2.DescribeAccountLimitsOutput
-	DescribeAccountLimitsError      error
-	DescribeLoadBalancersPagesRest  elbv2.DescribeLoadBalancersOutput
-	DescribeLoadBalancersPagesError error
+	DescribeAccountLimitsResp         elbv2.DescribeAccountLimitsOutput
+	DescribeAccountLimitsError        error
+	DescribeLoadBalancersPagesRest    elbv2.DescribeLoadBalancersOutput
+	DescribeLoadBalancersPagesError   error
+	DescribeListenersPagesResp        elbv2.DescribeListenersOutput
+	DescribeListenersPagesError       error
+	DescribeRulesResp                 elbv2.DescribeRulesOutput
+	DescribeRulesError                error
+	DescribeListenerCertificatesResp  elbv2.DescribeListenerCertificatesOutput
+	DescribeListenerCertificatesError error
+	DescribeTargetGroupsPagesResp     elbv2.DescribeTargetGroupsOutput
+	DescribeTargetGroupsPagesError    error
+	DescribeTargetHealthResp          elbv2.DescribeTargetHealthOutput
+	DescribeTargetHealthError         error
+	DescribeTargetHealthCalls         *int
 }
 
 func (m mockedElbv2Client) DescribeAccountLimits(input *elbv2.DescribeAccountLimitsInput) (*elbv2.DescribeAccountLimitsOutput, error) {
@@ -46,11 +57,45 @@ func (m mockedElbv2Client) DescribeLoadBalancersPages(input *elbv2.DescribeLoadB
 	return m.DescribeLoadBalancersPagesError
 }
 
+func (m mockedElbv2Client) DescribeListenersPages(input *elbv2.DescribeListenersInput, fn func(*elbv2.DescribeListenersOutput, bool) bool) error {
+	fn(&m.DescribeListenersPagesResp, false)
+	return m.DescribeListenersPagesError
+}
+
+func (m mockedElbv2Client) DescribeRules(input *elbv2.DescribeRulesInput) (*elbv2.DescribeRulesOutput, error) {
+	return &m.DescribeRulesResp, m.DescribeRulesError
+}
+
+func (m mockedElbv2Client) DescribeListenerCertificates(input *elbv2.DescribeListenerCertificatesInput) (*elbv2.DescribeListenerCertificatesOutput, error) {
+	return &m.DescribeListenerCertificatesResp, m.DescribeListenerCertificatesError
+}
+
+func (m mockedElbv2Client) DescribeTargetGroupsPages(input *elbv2.DescribeTargetGroupsInput, fn func(*elbv2.DescribeTargetGroupsOutput, bool) bool) error {
+	fn(&m.DescribeTargetGroupsPagesResp, false)
+	return m.DescribeTargetGroupsPagesError
+}
+
+func (m mockedElbv2Client) DescribeTargetHealth(input *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+	if m.DescribeTargetHealthCalls != nil {
+		*m.DescribeTargetHealthCalls++
+	}
+	return &m.DescribeTargetHealthResp, m.DescribeTargetHealthError
+}
+
+func resetElbCaches() {
+	elbAccountQuota = map[string]float64{}
+	elbv2LoadBalancers = []*elbv2.LoadBalancer{}
+	elbv2Listeners = map[string][]*elbv2.Listener{}
+	elbv2TargetGroups = []*elbv2.TargetGroup{}
+	elbv2TargetsPerTargetGroup = map[string]int{}
+}
+
 func TestNewElbCheckerImpl(t *testing.T) {
 	require.Implements(t, (*Svcquota)(nil), NewElbChecker())
 }
 
 func TestGetElbAccountQuotas(t *testing.T) {
+	t.Cleanup(resetElbCaches)
 	mockedDescribeAccountLimitsOutputv2 := elbv2.DescribeAccountLimitsOutput{
 		Limits: []*elbv2.Limit{{Name: aws.String("foo"), Max: aws.String("100")}},
 	}
@@ -68,10 +113,10 @@ func TestGetElbAccountQuotas(t *testing.T) {
 	assert.Equal(t, float64(100), foo)
 	bar := actual["bar"]
 	assert.Equal(t, float64(1000), bar)
-	t.Cleanup(func() { elbAccountQuota = map[string]float64{} })
 }
 
 func TestGetElbAccountQuotasErrorv2(t *testing.T) {
+	t.Cleanup(resetElbCaches)
 	mockedDescribeAccountLimitsOutputv2 := elbv2.DescribeAccountLimitsOutput{
 		Limits: []*elbv2.Limit{{Name: aws.String("foo"), Max: aws.String("100")}},
 	}
@@ -85,10 +130,10 @@ func TestGetElbAccountQuotasErrorv2(t *testing.T) {
 	svcChecker := elbChecker.(*ServiceChecker)
 	actual := svcChecker.getElbAccountQuotas()
 	assert.Len(t, actual, 0)
-	t.Cleanup(func() { elbAccountQuota = map[string]float64{} })
 }
 
 func TestGetElbAccountQuotasErrorClassic(t *testing.T) {
+	t.Cleanup(resetElbCaches)
 	mockedDescribeAccountLimitsOutputv2 := elbv2.DescribeAccountLimitsOutput{
 		Limits: []*elbv2.Limit{{Name: aws.String("foo"), Max: aws.String("100")}},
 	}
@@ -102,10 +147,10 @@ func TestGetElbAccountQuotasErrorClassic(t *testing.T) {
 	svcChecker := elbChecker.(*ServiceChecker)
 	actual := svcChecker.getElbAccountQuotas()
 	assert.Len(t, actual, 0)
-	t.Cleanup(func() { elbAccountQuota = map[string]float64{} })
 }
 
 func TestGetElbAccountQuotasExists(t *testing.T) {
+	t.Cleanup(resetElbCaches)
 	elbAccountQuota = map[string]float64{
 		"foo": float64(10),
 		"bar": float64(100),
@@ -114,10 +159,10 @@ func TestGetElbAccountQuotasExists(t *testing.T) {
 	svcChecker := elbChecker.(*ServiceChecker)
 	actual := svcChecker.getElbAccountQuotas()
 	assert.Len(t, actual, 2)
-	t.Cleanup(func() { elbAccountQuota = map[string]float64{} })
 }
 
 func TestGetElv2LoadBalancerUsage(t *testing.T) {
+	t.Cleanup(resetElbCaches)
 	mockedDescribeAccountLimitsOutput := elbv2.DescribeAccountLimitsOutput{
 		Limits: []*elbv2.Limit{
 			{Name: aws.String("application-load-balancers"), Max: aws.String("200")},
@@ -158,10 +203,10 @@ func TestGetElv2LoadBalancerUsage(t *testing.T) {
 	assert.Equal(t, float64(300), nlbQuota.QuotaValue)
 	assert.Equal(t, float64(1), nlbQuota.UsageValue)
 
-	t.Cleanup(func() { elbAccountQuota = map[string]float64{} })
 }
 
 func TestGetElbv2BalancerUsageError(t *testing.T) {
+	t.Cleanup(resetElbCaches)
 	mockedDescribeAccountLimitsOutput := elbv2.DescribeAccountLimitsOutput{
 		Limits: []*elbv2.Limit{
 			{Name: aws.String("application-load-balancers"), Max: aws.String("200")},
@@ -192,10 +237,10 @@ func TestGetElbv2BalancerUsageError(t *testing.T) {
 
 	actualNLB := svcChecker.getElbNetworkLoadBalancerUsage()
 	assert.Len(t, actualNLB, 0)
-	t.Cleanup(func() { elbAccountQuota = map[string]float64{} })
 }
 
 func TestGetElbClassicLoadBalancerUsage(t *testing.T) {
+	t.Cleanup(resetElbCaches)
 	mockedDescribeAccountLimitsOutput := elb.DescribeAccountLimitsOutput{
 		Limits: []*elb.Limit{{Name: aws.String("classic-load-balancers"), Max: aws.String("200")}},
 	}
@@ -222,10 +267,10 @@ func TestGetElbClassicLoadBalancerUsage(t *testing.T) {
 	assert.Equal(t, "elasticloadbalancing", quota.Service)
 	assert.Equal(t, float64(200), quota.QuotaValue)
 	assert.Equal(t, float64(3), quota.UsageValue)
-	t.Cleanup(func() { elbAccountQuota = map[string]float64{} })
 }
 
 func TestGetElbClassicLoadBalancerUsageError(t *testing.T) {
+	t.Cleanup(resetElbCaches)
 	mockedDescribeAccountLimitsOutput := elb.DescribeAccountLimitsOutput{
 		Limits: []*elb.Limit{{Name: aws.String("classic-load-balancers"), Max: aws.String("200")}},
 	}
@@ -252,5 +297,235 @@ func TestGetElbClassicLoadBalancerUsageError(t *testing.T) {
 
 	assert.Len(t, actual, 0)
 	assert.Equal(t, expected, actual)
-	t.Cleanup(func() { elbAccountQuota = map[string]float64{} })
+}
+
+var mockedElbv2LoadBalancers = elbv2.DescribeLoadBalancersOutput{
+	LoadBalancers: []*elbv2.LoadBalancer{
+		{LoadBalancerName: aws.String("alb"), LoadBalancerArn: aws.String("alb-arn"), Type: aws.String("application")},
+		{LoadBalancerName: aws.String("nlb"), LoadBalancerArn: aws.String("nlb-arn"), Type: aws.String("network")},
+	},
+}
+
+func TestGetElbQuotaAccountLimitWins(t *testing.T) {
+	t.Cleanup(resetElbCaches)
+	elbAccountQuota = map[string]float64{"listeners-per-application-load-balancer": float64(10)}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{
+			NewQuota("elasticloadbalancing", "Listeners per Application Load Balancer", float64(50), false),
+			NewQuota("elasticloadbalancing", "Listeners per Network Load Balancer", float64(50), false),
+		},
+		nil)
+
+	elbChecker := NewElbChecker()
+	svcChecker := elbChecker.(*ServiceChecker)
+	assert.Equal(t, float64(10), svcChecker.getElbQuota("Listeners per Application Load Balancer").QuotaValue)
+	assert.Equal(t, float64(50), svcChecker.getElbQuota("Listeners per Network Load Balancer").QuotaValue)
+}
+
+func TestGetElbListenersPerLoadBalancerUsage(t *testing.T) {
+	t.Cleanup(resetElbCaches)
+	elbAccountQuota = map[string]float64{"listeners-per-application-load-balancer": float64(10)}
+	conf.Elbv2 = mockedElbv2Client{
+		DescribeLoadBalancersPagesRest: mockedElbv2LoadBalancers,
+		DescribeListenersPagesResp: elbv2.DescribeListenersOutput{
+			Listeners: []*elbv2.Listener{{ListenerArn: aws.String("l1")}, {ListenerArn: aws.String("l2")}},
+		},
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	elbChecker := NewElbChecker()
+	svcChecker := elbChecker.(*ServiceChecker)
+
+	albs := svcChecker.getElbListenersPerApplicationLoadBalancerUsage()
+	assert.Len(t, albs, 1)
+	assert.Equal(t, "AWS::ElasticLoadBalancingV2::LoadBalancer::alb", albs[0].ResourceId)
+	assert.Equal(t, float64(10), albs[0].QuotaValue)
+	assert.Equal(t, float64(2), albs[0].UsageValue)
+
+	nlbs := svcChecker.getElbListenersPerNetworkLoadBalancerUsage()
+	assert.Len(t, nlbs, 1)
+	assert.Equal(t, "AWS::ElasticLoadBalancingV2::LoadBalancer::nlb", nlbs[0].ResourceId)
+	assert.Equal(t, float64(50), nlbs[0].QuotaValue)
+}
+
+func TestGetElbListenersPerLoadBalancerUsageError(t *testing.T) {
+	t.Cleanup(resetElbCaches)
+	elbAccountQuota = map[string]float64{"foo": float64(10)}
+	conf.Elbv2 = mockedElbv2Client{
+		DescribeLoadBalancersPagesRest: mockedElbv2LoadBalancers,
+		DescribeListenersPagesError:    errors.New("test error"),
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	elbChecker := NewElbChecker()
+	svcChecker := elbChecker.(*ServiceChecker)
+	assert.Len(t, svcChecker.getElbListenersPerApplicationLoadBalancerUsage(), 0)
+	assert.Len(t, svcChecker.getElbRulesPerApplicationLoadBalancerUsage(), 0)
+	assert.Len(t, svcChecker.getElbCertificatesPerApplicationLoadBalancerUsage(), 0)
+}
+
+func TestGetElbClassicPerLoadBalancerUsage(t *testing.T) {
+	t.Cleanup(resetElbCaches)
+	elbAccountQuota = map[string]float64{"classic-registered-instances": float64(20)}
+	conf.Elb = mockedElbClient{
+		DescribeLoadBalancersPagesRest: elb.DescribeLoadBalancersOutput{
+			LoadBalancerDescriptions: []*elb.LoadBalancerDescription{{
+				LoadBalancerName:     aws.String("foo"),
+				ListenerDescriptions: []*elb.ListenerDescription{{}},
+				Instances:            []*elb.Instance{{InstanceId: aws.String("i-1")}, {InstanceId: aws.String("i-2")}},
+			}},
+		},
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	elbChecker := NewElbChecker()
+	svcChecker := elbChecker.(*ServiceChecker)
+
+	listeners := svcChecker.getElbListenersPerClassicLoadBalancerUsage()
+	assert.Len(t, listeners, 1)
+	assert.Equal(t, "AWS::ElasticLoadBalancing::LoadBalancer::foo", listeners[0].ResourceId)
+	assert.Equal(t, float64(100), listeners[0].QuotaValue)
+	assert.Equal(t, float64(1), listeners[0].UsageValue)
+
+	instances := svcChecker.getElbInstancesPerClassicLoadBalancerUsage()
+	assert.Len(t, instances, 1)
+	assert.Equal(t, float64(20), instances[0].QuotaValue)
+	assert.Equal(t, float64(2), instances[0].UsageValue)
+}
+
+func TestGetElbClassicPerLoadBalancerUsageError(t *testing.T) {
+	t.Cleanup(resetElbCaches)
+	conf.Elb = mockedElbClient{DescribeLoadBalancersPagesError: errors.New("test error")}
+
+	elbChecker := NewElbChecker()
+	svcChecker := elbChecker.(*ServiceChecker)
+	assert.Len(t, svcChecker.getElbListenersPerClassicLoadBalancerUsage(), 0)
+	assert.Len(t, svcChecker.getElbInstancesPerClassicLoadBalancerUsage(), 0)
+}
+
+func TestGetElbRulesAndCertificatesPerApplicationLoadBalancerUsage(t *testing.T) {
+	t.Cleanup(resetElbCaches)
+	elbAccountQuota = map[string]float64{"foo": float64(10)}
+	conf.Elbv2 = mockedElbv2Client{
+		DescribeLoadBalancersPagesRest: mockedElbv2LoadBalancers,
+		DescribeListenersPagesResp: elbv2.DescribeListenersOutput{
+			Listeners: []*elbv2.Listener{{ListenerArn: aws.String("l1")}, {ListenerArn: aws.String("l2")}},
+		},
+		DescribeRulesResp: elbv2.DescribeRulesOutput{
+			Rules: []*elbv2.Rule{{IsDefault: aws.Bool(true)}, {IsDefault: aws.Bool(false)}, {IsDefault: aws.Bool(false)}},
+		},
+		DescribeListenerCertificatesResp: elbv2.DescribeListenerCertificatesOutput{
+			Certificates: []*elbv2.Certificate{{IsDefault: aws.Bool(true)}, {IsDefault: aws.Bool(false)}},
+		},
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	elbChecker := NewElbChecker()
+	svcChecker := elbChecker.(*ServiceChecker)
+
+	rules := svcChecker.getElbRulesPerApplicationLoadBalancerUsage()
+	assert.Len(t, rules, 1)
+	assert.Equal(t, float64(100), rules[0].QuotaValue)
+	assert.Equal(t, float64(4), rules[0].UsageValue)
+
+	certificates := svcChecker.getElbCertificatesPerApplicationLoadBalancerUsage()
+	assert.Len(t, certificates, 1)
+	assert.Equal(t, float64(25), certificates[0].QuotaValue)
+	assert.Equal(t, float64(2), certificates[0].UsageValue)
+}
+
+func TestGetElbTargetsUsage(t *testing.T) {
+	t.Cleanup(resetElbCaches)
+	elbAccountQuota = map[string]float64{"target-groups": float64(5)}
+	describeTargetHealthCalls := 0
+	conf.Elbv2 = mockedElbv2Client{
+		DescribeTargetHealthCalls:      &describeTargetHealthCalls,
+		DescribeLoadBalancersPagesRest: mockedElbv2LoadBalancers,
+		DescribeTargetGroupsPagesResp: elbv2.DescribeTargetGroupsOutput{
+			TargetGroups: []*elbv2.TargetGroup{
+				{TargetGroupName: aws.String("tg1"), TargetGroupArn: aws.String("tg1-arn"), LoadBalancerArns: []*string{aws.String("alb-arn")}},
+				{TargetGroupName: aws.String("tg2"), TargetGroupArn: aws.String("tg2-arn"), LoadBalancerArns: []*string{aws.String("alb-arn")}},
+			},
+		},
+		DescribeTargetHealthResp: elbv2.DescribeTargetHealthOutput{
+			TargetHealthDescriptions: []*elbv2.TargetHealthDescription{{}, {}, {}},
+		},
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	elbChecker := NewElbChecker()
+	svcChecker := elbChecker.(*ServiceChecker)
+
+	targetGroups := svcChecker.getElbTargetGroupsUsage()
+	assert.Len(t, targetGroups, 1)
+	assert.Equal(t, float64(5), targetGroups[0].QuotaValue)
+	assert.Equal(t, float64(2), targetGroups[0].UsageValue)
+
+	perTargetGroup := svcChecker.getElbTargetsPerTargetGroupUsage()
+	assert.Len(t, perTargetGroup, 2)
+	assert.Equal(t, "AWS::ElasticLoadBalancingV2::TargetGroup::tg1", perTargetGroup[0].ResourceId)
+	assert.Equal(t, float64(3), perTargetGroup[0].UsageValue)
+
+	perAlb := svcChecker.getElbTargetsPerApplicationLoadBalancerUsage()
+	assert.Len(t, perAlb, 1)
+	assert.Equal(t, float64(6), perAlb[0].UsageValue)
+
+	perNlb := svcChecker.getElbTargetsPerNetworkLoadBalancerUsage()
+	assert.Len(t, perNlb, 1)
+	assert.Equal(t, float64(0), perNlb[0].UsageValue)
+
+	// target health is described once per target group for all the quotas
+	assert.Equal(t, 2, describeTargetHealthCalls)
+}
+
+func TestGetElbTargetsUsageError(t *testing.T) {
+	t.Cleanup(resetElbCaches)
+	elbAccountQuota = map[string]float64{"foo": float64(10)}
+	conf.Elbv2 = mockedElbv2Client{
+		DescribeLoadBalancersPagesRest: mockedElbv2LoadBalancers,
+		DescribeTargetGroupsPagesError: errors.New("test error"),
+	}
+
+	elbChecker := NewElbChecker()
+	svcChecker := elbChecker.(*ServiceChecker)
+	assert.Len(t, svcChecker.getElbTargetGroupsUsage(), 0)
+	assert.Len(t, svcChecker.getElbTargetsPerTargetGroupUsage(), 0)
+	assert.Len(t, svcChecker.getElbTargetsPerApplicationLoadBalancerUsage(), 0)
+}
+
+func TestGetElbTargetsPerTargetGroupUsageErrorHealth(t *testing.T) {
+	t.Cleanup(resetElbCaches)
+	elbAccountQuota = map[string]float64{"foo": float64(10)}
+	conf.Elbv2 = mockedElbv2Client{
+		DescribeTargetGroupsPagesResp: elbv2.DescribeTargetGroupsOutput{
+			TargetGroups: []*elbv2.TargetGroup{{TargetGroupName: aws.String("tg1"), TargetGroupArn: aws.String("tg1-arn")}},
+		},
+		DescribeTargetHealthError: errors.New("test error"),
+	}
+
+	elbChecker := NewElbChecker()
+	svcChecker := elbChecker.(*ServiceChecker)
+	assert.Len(t, svcChecker.getElbTargetsPerTargetGroupUsage(), 0)
+}
+
+func TestGetElbClassicLoadBalancerUsageOverride(t *testing.T) {
+	t.Cleanup(resetElbCaches)
+	conf.Elbv2 = mockedElbv2Client{DescribeAccountLimitsResp: elbv2.DescribeAccountLimitsOutput{}}
+	conf.Elb = mockedElbClient{
+		DescribeAccountLimitsResp: elb.DescribeAccountLimitsOutput{
+			Limits: []*elb.Limit{{Name: aws.String("classic-load-balancers"), Max: aws.String("200")}},
+		},
+		DescribeLoadBalancersPagesRest: elb.DescribeLoadBalancersOutput{},
+	}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	elbChecker := NewElbChecker()
+	svcChecker := elbChecker.(*ServiceChecker)
+	actual := ApplyQuotaOverrides(svcChecker.getElbClassicLoadBalancerUsage(), []AWSQuotaOverride{
+		{Service: "elasticloadbalancing", QuotaName: "Classic Load Balancers per Region", QuotaValue: float64(300)}}, "")
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, float64(300), actual[0].QuotaValue)
+	assert.Equal(t, float64(200), actual[0].ServiceValue)
+	assert.Equal(t, QuotaSourceOverride, actual[0].Source)
 }
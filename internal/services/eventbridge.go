@@ -0,0 +1,155 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+)
+
+type EventbridgeClientInterface interface {
+	ListEventBuses(input *eventbridge.ListEventBusesInput) (*eventbridge.ListEventBusesOutput, error)
+	ListRules(input *eventbridge.ListRulesInput) (*eventbridge.ListRulesOutput, error)
+	ListTargetsByRule(input *eventbridge.ListTargetsByRuleInput) (*eventbridge.ListTargetsByRuleOutput, error)
+}
+
+var eventbridgeDefaultQuotas = map[string]quotaDefault{
+	"Event buses":         {Value: 100},
+	"Rules per event bus": {Value: 300},
+	"Targets per rule":    {Value: 5},
+}
+
+func NewEventbridgeChecker() Svcquota {
+	serviceCode := "events"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Event buses":         ServiceChecker.getEventbridgeEventBusesUsage,
+		"Rules per event bus": ServiceChecker.getEventbridgeRulesPerEventBusUsage,
+		"Targets per rule":    ServiceChecker.getEventbridgeTargetsPerRuleUsage,
+	}
+	requiredPermissions := []string{
+		"events:ListEventBuses",
+		"events:ListRules",
+		"events:ListTargetsByRule",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func getEventbridgeEventBuses() (ret []*eventbridge.EventBus, err error) {
+	ret = []*eventbridge.EventBus{}
+	input := &eventbridge.ListEventBusesInput{}
+	for {
+		result, errList := conf.Eventbridge.ListEventBuses(input)
+		if errList != nil {
+			return []*eventbridge.EventBus{}, errList
+		}
+		ret = append(ret, result.EventBuses...)
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+	return
+}
+
+func getEventbridgeRules(eventBusName *string) (ret []*eventbridge.Rule, err error) {
+	ret = []*eventbridge.Rule{}
+	input := &eventbridge.ListRulesInput{EventBusName: eventBusName}
+	for {
+		result, errList := conf.Eventbridge.ListRules(input)
+		if errList != nil {
+			return []*eventbridge.Rule{}, errList
+		}
+		ret = append(ret, result.Rules...)
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+	return
+}
+
+func getEventbridgeTargetsCount(eventBusName *string, ruleName *string) (ret int, err error) {
+	input := &eventbridge.ListTargetsByRuleInput{EventBusName: eventBusName, Rule: ruleName}
+	for {
+		result, errList := conf.Eventbridge.ListTargetsByRule(input)
+		if errList != nil {
+			return 0, errList
+		}
+		ret += len(result.Targets)
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+	return
+}
+
+func (c ServiceChecker) getEventbridgeEventBusesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	eventBuses, err := getEventbridgeEventBuses()
+	if err != nil {
+		fmt.Printf("failed to retrieve eventbridge event buses, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Event buses", eventbridgeDefaultQuotas["Event buses"])
+	quotaInfo.UsageValue = float64(len(eventBuses))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getEventbridgeRulesPerEventBusUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	eventBuses, err := getEventbridgeEventBuses()
+	if err != nil {
+		fmt.Printf("failed to retrieve eventbridge event buses, %v", err)
+		return
+	}
+
+	for _, eventBus := range eventBuses {
+		quotaInfo := c.getAppliedQuotaOrDefault("Rules per event bus", eventbridgeDefaultQuotas["Rules per event bus"])
+		rules, errRules := getEventbridgeRules(eventBus.Name)
+		if errRules != nil {
+			fmt.Printf("failed to retrieve rules for event bus %s, %v", aws.StringValue(eventBus.Name), errRules)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(len(rules))
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::Events::EventBus::%s", aws.StringValue(eventBus.Name))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getEventbridgeTargetsPerRuleUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	eventBuses, err := getEventbridgeEventBuses()
+	if err != nil {
+		fmt.Printf("failed to retrieve eventbridge event buses, %v", err)
+		return
+	}
+
+	for _, eventBus := range eventBuses {
+		rules, errRules := getEventbridgeRules(eventBus.Name)
+		if errRules != nil {
+			fmt.Printf("failed to retrieve rules for event bus %s, %v", aws.StringValue(eventBus.Name), errRules)
+			continue
+		}
+
+		for _, rule := range rules {
+			quotaInfo := c.getAppliedQuotaOrDefault("Targets per rule", eventbridgeDefaultQuotas["Targets per rule"])
+			targets, errTargets := getEventbridgeTargetsCount(eventBus.Name, rule.Name)
+			if errTargets != nil {
+				fmt.Printf("failed to retrieve targets for rule %s, %v", aws.StringValue(rule.Name), errTargets)
+				continue
+			}
+
+			quotaInfo.UsageValue = float64(targets)
+			// rule names are only unique within an event bus
+			quotaInfo.ResourceId = fmt.Sprintf("AWS::Events::Rule::%s/%s", aws.StringValue(eventBus.Name), aws.StringValue(rule.Name))
+			ret = append(ret, quotaInfo)
+		}
+	}
+	return
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedEventbridgeClient struct {
+	EventbridgeClientInterface
+	ListEventBusesResp     eventbridge.ListEventBusesOutput
+	ListEventBusesError    error
+	ListRulesResp          eventbridge.ListRulesOutput
+	ListRulesError         error
+	ListTargetsByRuleResp  eventbridge.ListTargetsByRuleOutput
+	ListTargetsByRuleError error
+}
+
+func (m mockedEventbridgeClient) ListEventBuses(input *eventbridge.ListEventBusesInput) (*eventbridge.ListEventBusesOutput, error) {
+	return &m.ListEventBusesResp, m.ListEventBusesError
+}
+
+func (m mockedEventbridgeClient) ListRules(input *eventbridge.ListRulesInput) (*eventbridge.ListRulesOutput, error) {
+	return &m.ListRulesResp, m.ListRulesError
+}
+
+func (m mockedEventbridgeClient) ListTargetsByRule(input *eventbridge.ListTargetsByRuleInput) (*eventbridge.ListTargetsByRuleOutput, error) {
+	return &m.ListTargetsByRuleResp, m.ListTargetsByRuleError
+}
+
+var mockedEventBuses = eventbridge.ListEventBusesOutput{
+	EventBuses: []*eventbridge.EventBus{{Name: aws.String("default")}, {Name: aws.String("custom")}},
+}
+
+func TestNewEventbridgeCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewEventbridgeChecker())
+}
+
+func TestGetEventbridgeEventBusesUsage(t *testing.T) {
+	conf.Eventbridge = mockedEventbridgeClient{ListEventBusesResp: mockedEventBuses}
+	svcChecker := newTestServiceChecker(NewEventbridgeChecker, NewQuota("events", "Event buses", float64(200), false))
+	actual := svcChecker.getEventbridgeEventBusesUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "events", actual[0].Service)
+	assert.Equal(t, float64(200), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+}
+
+func TestGetEventbridgeEventBusesUsageError(t *testing.T) {
+	conf.Eventbridge = mockedEventbridgeClient{ListEventBusesError: errors.New("test error")}
+	svcChecker := newTestServiceChecker(NewEventbridgeChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getEventbridgeEventBusesUsage())
+	assert.Equal(t, expected, svcChecker.getEventbridgeRulesPerEventBusUsage())
+	assert.Equal(t, expected, svcChecker.getEventbridgeTargetsPerRuleUsage())
+}
+
+func TestGetEventbridgeRulesPerEventBusUsage(t *testing.T) {
+	conf.Eventbridge = mockedEventbridgeClient{
+		ListEventBusesResp: mockedEventBuses,
+		ListRulesResp:      eventbridge.ListRulesOutput{Rules: []*eventbridge.Rule{{}, {}, {}}},
+	}
+	svcChecker := newTestServiceChecker(NewEventbridgeChecker)
+	actual := svcChecker.getEventbridgeRulesPerEventBusUsage()
+
+	assert.Len(t, actual, 2)
+	assert.Equal(t, "AWS::Events::EventBus::custom", actual[1].ResourceId)
+	assert.Equal(t, float64(300), actual[1].QuotaValue)
+	assert.Equal(t, float64(3), actual[1].UsageValue)
+}
+
+func TestGetEventbridgeRulesPerEventBusUsageError(t *testing.T) {
+	conf.Eventbridge = mockedEventbridgeClient{
+		ListEventBusesResp: mockedEventBuses,
+		ListRulesError:     errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewEventbridgeChecker)
+
+	assert.Len(t, svcChecker.getEventbridgeRulesPerEventBusUsage(), 0)
+	assert.Len(t, svcChecker.getEventbridgeTargetsPerRuleUsage(), 0)
+}
+
+func TestGetEventbridgeTargetsPerRuleUsage(t *testing.T) {
+	conf.Eventbridge = mockedEventbridgeClient{
+		ListEventBusesResp:    eventbridge.ListEventBusesOutput{EventBuses: []*eventbridge.EventBus{{Name: aws.String("default")}}},
+		ListRulesResp:         eventbridge.ListRulesOutput{Rules: []*eventbridge.Rule{{Name: aws.String("foo")}}},
+		ListTargetsByRuleResp: eventbridge.ListTargetsByRuleOutput{Targets: []*eventbridge.Target{{}, {}}},
+	}
+	svcChecker := newTestServiceChecker(NewEventbridgeChecker)
+	actual := svcChecker.getEventbridgeTargetsPerRuleUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "AWS::Events::Rule::default/foo", actual[0].ResourceId)
+	assert.Equal(t, float64(5), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+}
+
+func TestGetEventbridgeTargetsPerRuleUsageError(t *testing.T) {
+	conf.Eventbridge = mockedEventbridgeClient{
+		ListEventBusesResp:     mockedEventBuses,
+		ListRulesResp:          eventbridge.ListRulesOutput{Rules: []*eventbridge.Rule{{Name: aws.String("foo")}}},
+		ListTargetsByRuleError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewEventbridgeChecker)
+	actual := svcChecker.getEventbridgeTargetsPerRuleUsage()
+
+	assert.Len(t, actual, 0)
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/fsx"
+)
+
+type FsxClientInterface interface {
+	DescribeFileSystemsPages(input *fsx.DescribeFileSystemsInput, fn func(*fsx.DescribeFileSystemsOutput, bool) bool) error
+}
+
+// quota name prefix of each fsx file system type
+var fsxFileSystemTypes = map[string]string{
+	fsx.FileSystemTypeLustre:  "Lustre",
+	fsx.FileSystemTypeOntap:   "ONTAP",
+	fsx.FileSystemTypeOpenzfs: "OpenZFS",
+	fsx.FileSystemTypeWindows: "Windows",
+}
+
+var fsxDefaultQuotas = map[string]quotaDefault{
+	"Lustre file systems":                      {Value: 100},
+	"Lustre total storage capacity (GiB)":      {Value: 100800},
+	"Lustre total throughput capacity (MBps)":  {Value: 10240},
+	"ONTAP file systems":                       {Value: 100},
+	"ONTAP total storage capacity (GiB)":       {Value: 524288},
+	"ONTAP total throughput capacity (MBps)":   {Value: 10240},
+	"OpenZFS file systems":                     {Value: 100},
+	"OpenZFS total storage capacity (GiB)":     {Value: 524288},
+	"OpenZFS total throughput capacity (MBps)": {Value: 10240},
+	"Windows file systems":                     {Value: 100},
+	"Windows total storage capacity (GiB)":     {Value: 524288},
+	"Windows total throughput capacity (MBps)": {Value: 10240},
+}
+
+func NewFsxChecker() Svcquota {
+	serviceCode := "fsx"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){}
+	for fileSystemType, name := range fsxFileSystemTypes {
+		fileSystemType := fileSystemType
+		supportedQuotas[name+" file systems"] = func(c ServiceChecker) []AWSQuotaInfo {
+			return c.getFsxFileSystemsUsage(fileSystemType)
+		}
+		supportedQuotas[name+" total storage capacity (GiB)"] = func(c ServiceChecker) []AWSQuotaInfo {
+			return c.getFsxStorageCapacityUsage(fileSystemType)
+		}
+		supportedQuotas[name+" total throughput capacity (MBps)"] = func(c ServiceChecker) []AWSQuotaInfo {
+			return c.getFsxThroughputCapacityUsage(fileSystemType)
+		}
+	}
+	requiredPermissions := []string{"fsx:DescribeFileSystems"}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+var fsxFileSystems []*fsx.FileSystem = []*fsx.FileSystem{}
+
+// getFsxFileSystems lists the file systems once and shares the result between
+// the different quotas. File systems being deleted are ignored
+func getFsxFileSystems() (ret []*fsx.FileSystem, err error) {
+	ret = fsxFileSystems
+	if len(fsxFileSystems) != 0 {
+		return
+	}
+
+	err = conf.Fsx.DescribeFileSystemsPages(&fsx.DescribeFileSystemsInput{}, func(p *fsx.DescribeFileSystemsOutput, lastPage bool) bool {
+		for _, fileSystem := range p.FileSystems {
+			if aws.StringValue(fileSystem.Lifecycle) != fsx.FileSystemLifecycleDeleting {
+				fsxFileSystems = append(fsxFileSystems, fileSystem)
+			}
+		}
+		return true // continue paging
+	})
+	if err != nil {
+		fsxFileSystems = []*fsx.FileSystem{}
+		return fsxFileSystems, err
+	}
+	return fsxFileSystems, nil
+}
+
+// getFsxThroughputCapacity returns the throughput capacity, in MBps, of the
+// given file system. Lustre file systems provision it per TiB of storage
+func getFsxThroughputCapacity(fileSystem *fsx.FileSystem) int64 {
+	switch {
+	case fileSystem.LustreConfiguration != nil:
+		return aws.Int64Value(fileSystem.LustreConfiguration.PerUnitStorageThroughput) * aws.Int64Value(fileSystem.StorageCapacity) / 1024
+	case fileSystem.OntapConfiguration != nil:
+		return aws.Int64Value(fileSystem.OntapConfiguration.ThroughputCapacity)
+	case fileSystem.OpenZFSConfiguration != nil:
+		return aws.Int64Value(fileSystem.OpenZFSConfiguration.ThroughputCapacity)
+	case fileSystem.WindowsConfiguration != nil:
+		return aws.Int64Value(fileSystem.WindowsConfiguration.ThroughputCapacity)
+	}
+	return 0
+}
+
+// getFsxTypeUsage sums the value returned by usage over the file systems of the
+// given type
+func (c ServiceChecker) getFsxTypeUsage(quotaName string, fileSystemType string, usage func(*fsx.FileSystem) int64) (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	fileSystems, err := getFsxFileSystems()
+	if err != nil {
+		fmt.Printf("failed to retrieve fsx file systems, %v", err)
+		return
+	}
+
+	total := int64(0)
+	for _, fileSystem := range fileSystems {
+		if aws.StringValue(fileSystem.FileSystemType) == fileSystemType {
+			total += usage(fileSystem)
+		}
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault(quotaName, fsxDefaultQuotas[quotaName])
+	quotaInfo.UsageValue = float64(total)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getFsxFileSystemsUsage(fileSystemType string) (ret []AWSQuotaInfo) {
+	return c.getFsxTypeUsage(fsxFileSystemTypes[fileSystemType]+" file systems", fileSystemType, func(*fsx.FileSystem) int64 {
+		return 1
+	})
+}
+
+func (c ServiceChecker) getFsxStorageCapacityUsage(fileSystemType string) (ret []AWSQuotaInfo) {
+	return c.getFsxTypeUsage(fsxFileSystemTypes[fileSystemType]+" total storage capacity (GiB)", fileSystemType, func(fileSystem *fsx.FileSystem) int64 {
+		return aws.Int64Value(fileSystem.StorageCapacity)
+	})
+}
+
+func (c ServiceChecker) getFsxThroughputCapacityUsage(fileSystemType string) (ret []AWSQuotaInfo) {
+	return c.getFsxTypeUsage(fsxFileSystemTypes[fileSystemType]+" total throughput capacity (MBps)", fileSystemType, getFsxThroughputCapacity)
+}
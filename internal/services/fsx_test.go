@@ -0,0 +1,103 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/fsx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedFsxClient struct {
+	FsxClientInterface
+	DescribeFileSystemsPagesResp  fsx.DescribeFileSystemsOutput
+	DescribeFileSystemsPagesError error
+}
+
+func (m mockedFsxClient) DescribeFileSystemsPages(input *fsx.DescribeFileSystemsInput, fn func(*fsx.DescribeFileSystemsOutput, bool) bool) error {
+	return mockPages(m.DescribeFileSystemsPagesResp, m.DescribeFileSystemsPagesError, fn)
+}
+
+func TestNewFsxCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewFsxChecker())
+}
+
+func TestNewFsxCheckerQuotas(t *testing.T) {
+	svcChecker := newTestServiceChecker(NewFsxChecker)
+
+	assert.Len(t, svcChecker.SupportedQuotas, len(fsxDefaultQuotas))
+	for quotaName := range fsxDefaultQuotas {
+		assert.Contains(t, svcChecker.SupportedQuotas, quotaName)
+	}
+}
+
+func TestGetFsxUsage(t *testing.T) {
+	t.Cleanup(func() { fsxFileSystems = []*fsx.FileSystem{} })
+	conf.Fsx = mockedFsxClient{
+		DescribeFileSystemsPagesResp: fsx.DescribeFileSystemsOutput{
+			FileSystems: []*fsx.FileSystem{
+				{
+					FileSystemType:       aws.String(fsx.FileSystemTypeWindows),
+					StorageCapacity:      aws.Int64(300),
+					WindowsConfiguration: &fsx.WindowsFileSystemConfiguration{ThroughputCapacity: aws.Int64(32)},
+				},
+				{
+					FileSystemType:       aws.String(fsx.FileSystemTypeWindows),
+					StorageCapacity:      aws.Int64(2000),
+					WindowsConfiguration: &fsx.WindowsFileSystemConfiguration{ThroughputCapacity: aws.Int64(64)},
+				},
+				{
+					FileSystemType:      aws.String(fsx.FileSystemTypeLustre),
+					StorageCapacity:     aws.Int64(2400),
+					LustreConfiguration: &fsx.LustreFileSystemConfiguration{PerUnitStorageThroughput: aws.Int64(200)},
+				},
+				{
+					FileSystemType:       aws.String(fsx.FileSystemTypeWindows),
+					Lifecycle:            aws.String(fsx.FileSystemLifecycleDeleting),
+					StorageCapacity:      aws.Int64(5000),
+					WindowsConfiguration: &fsx.WindowsFileSystemConfiguration{ThroughputCapacity: aws.Int64(512)},
+				},
+			},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewFsxChecker, NewQuota("fsx", "Windows file systems", float64(200), false))
+
+	windowsFileSystems := svcChecker.getFsxFileSystemsUsage(fsx.FileSystemTypeWindows)
+	assert.Len(t, windowsFileSystems, 1)
+	assert.Equal(t, "fsx", windowsFileSystems[0].Service)
+	assert.Equal(t, "Windows file systems", windowsFileSystems[0].QuotaName)
+	assert.Equal(t, float64(200), windowsFileSystems[0].QuotaValue)
+	assert.Equal(t, float64(2), windowsFileSystems[0].UsageValue)
+
+	windowsStorage := svcChecker.getFsxStorageCapacityUsage(fsx.FileSystemTypeWindows)
+	assert.Equal(t, float64(524288), windowsStorage[0].QuotaValue)
+	assert.Equal(t, float64(2300), windowsStorage[0].UsageValue)
+
+	windowsThroughput := svcChecker.getFsxThroughputCapacityUsage(fsx.FileSystemTypeWindows)
+	assert.Equal(t, float64(96), windowsThroughput[0].UsageValue)
+
+	// lustre throughput is provisioned per TiB of storage
+	lustreThroughput := svcChecker.getFsxThroughputCapacityUsage(fsx.FileSystemTypeLustre)
+	assert.Equal(t, "Lustre total throughput capacity (MBps)", lustreThroughput[0].QuotaName)
+	assert.Equal(t, float64(468), lustreThroughput[0].UsageValue)
+
+	ontapFileSystems := svcChecker.getFsxFileSystemsUsage(fsx.FileSystemTypeOntap)
+	assert.Len(t, ontapFileSystems, 1)
+	assert.Equal(t, float64(100), ontapFileSystems[0].QuotaValue)
+	assert.Equal(t, float64(0), ontapFileSystems[0].UsageValue)
+}
+
+func TestGetFsxUsageError(t *testing.T) {
+	t.Cleanup(func() { fsxFileSystems = []*fsx.FileSystem{} })
+	conf.Fsx = mockedFsxClient{
+		DescribeFileSystemsPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewFsxChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getFsxFileSystemsUsage(fsx.FileSystemTypeLustre))
+	assert.Equal(t, expected, svcChecker.getFsxStorageCapacityUsage(fsx.FileSystemTypeOntap))
+	assert.Equal(t, expected, svcChecker.getFsxThroughputCapacityUsage(fsx.FileSystemTypeOpenzfs))
+}
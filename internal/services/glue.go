@@ -0,0 +1,242 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
+)
+
+type GlueClientInterface interface {
+	GetDatabasesPages(input *glue.GetDatabasesInput, fn func(*glue.GetDatabasesOutput, bool) bool) error
+	GetTablesPages(input *glue.GetTablesInput, fn func(*glue.GetTablesOutput, bool) bool) error
+	GetJobsPages(input *glue.GetJobsInput, fn func(*glue.GetJobsOutput, bool) bool) error
+	GetJobRunsPages(input *glue.GetJobRunsInput, fn func(*glue.GetJobRunsOutput, bool) bool) error
+	GetCrawlersPages(input *glue.GetCrawlersInput, fn func(*glue.GetCrawlersOutput, bool) bool) error
+	GetTriggersPages(input *glue.GetTriggersInput, fn func(*glue.GetTriggersOutput, bool) bool) error
+}
+
+var glueDefaultQuotas = map[string]quotaDefault{
+	"Databases per account":               {Value: 10000},
+	"Tables per database":                 {Value: 200000},
+	"Jobs per account":                    {Value: 1000},
+	"Crawlers per account":                {Value: 1000},
+	"Triggers per account":                {Value: 1000},
+	"Max concurrent job runs per account": {Value: 50},
+	"Max concurrent job runs per job":     {Value: 1},
+}
+
+func NewGlueChecker() Svcquota {
+	serviceCode := "glue"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Databases per account":               ServiceChecker.getGlueDatabasesUsage,
+		"Tables per database":                 ServiceChecker.getGlueTablesPerDatabaseUsage,
+		"Jobs per account":                    ServiceChecker.getGlueJobsUsage,
+		"Crawlers per account":                ServiceChecker.getGlueCrawlersUsage,
+		"Triggers per account":                ServiceChecker.getGlueTriggersUsage,
+		"Max concurrent job runs per account": ServiceChecker.getGlueConcurrentJobRunsUsage,
+		"Max concurrent job runs per job":     ServiceChecker.getGlueConcurrentJobRunsPerJobUsage,
+	}
+	requiredPermissions := []string{
+		"glue:GetDatabases",
+		"glue:GetTables",
+		"glue:GetJobs",
+		"glue:GetJobRuns",
+		"glue:GetCrawlers",
+		"glue:GetTriggers",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func getGlueDatabases() (ret []*glue.Database, err error) {
+	ret = []*glue.Database{}
+	err = conf.Glue.GetDatabasesPages(&glue.GetDatabasesInput{}, func(p *glue.GetDatabasesOutput, lastPage bool) bool {
+		ret = append(ret, p.DatabaseList...)
+		return true // continue paging
+	})
+	return
+}
+
+var glueJobs []*glue.Job = []*glue.Job{}
+
+// getGlueJobs lists the jobs once and shares the result between the different
+// quotas
+func getGlueJobs() (ret []*glue.Job, err error) {
+	ret = glueJobs
+	if len(glueJobs) != 0 {
+		return
+	}
+
+	err = conf.Glue.GetJobsPages(&glue.GetJobsInput{}, func(p *glue.GetJobsOutput, lastPage bool) bool {
+		glueJobs = append(glueJobs, p.Jobs...)
+		return true // continue paging
+	})
+	if err != nil {
+		glueJobs = []*glue.Job{}
+		return glueJobs, err
+	}
+	return glueJobs, nil
+}
+
+// getGlueConcurrentJobRuns returns the number of runs of the given job that
+// are currently in progress
+func getGlueConcurrentJobRuns(jobName *string) (ret int, err error) {
+	err = conf.Glue.GetJobRunsPages(&glue.GetJobRunsInput{JobName: jobName}, func(p *glue.GetJobRunsOutput, lastPage bool) bool {
+		for _, run := range p.JobRuns {
+			switch aws.StringValue(run.JobRunState) {
+			case glue.JobRunStateStarting, glue.JobRunStateRunning, glue.JobRunStateStopping, glue.JobRunStateWaiting:
+				ret++
+			}
+		}
+		return true // continue paging
+	})
+	return
+}
+
+func glueJobResourceId(job *glue.Job) string {
+	return fmt.Sprintf("AWS::Glue::Job::%s", aws.StringValue(job.Name))
+}
+
+func (c ServiceChecker) getGlueDatabasesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	databases, err := getGlueDatabases()
+	if err != nil {
+		fmt.Printf("failed to retrieve glue databases, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Databases per account", glueDefaultQuotas["Databases per account"])
+	quotaInfo.UsageValue = float64(len(databases))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getGlueTablesPerDatabaseUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	databases, err := getGlueDatabases()
+	if err != nil {
+		fmt.Printf("failed to retrieve glue databases, %v", err)
+		return
+	}
+
+	for _, database := range databases {
+		quotaInfo := c.getAppliedQuotaOrDefault("Tables per database", glueDefaultQuotas["Tables per database"])
+		tables := 0
+		errTables := conf.Glue.GetTablesPages(&glue.GetTablesInput{DatabaseName: database.Name}, func(p *glue.GetTablesOutput, lastPage bool) bool {
+			tables += len(p.TableList)
+			return true // continue paging
+		})
+		if errTables != nil {
+			fmt.Printf("failed to retrieve tables for glue database %s, %v", aws.StringValue(database.Name), errTables)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(tables)
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::Glue::Database::%s", aws.StringValue(database.Name))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getGlueJobsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	jobs, err := getGlueJobs()
+	if err != nil {
+		fmt.Printf("failed to retrieve glue jobs, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Jobs per account", glueDefaultQuotas["Jobs per account"])
+	quotaInfo.UsageValue = float64(len(jobs))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getGlueCrawlersUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	crawlers := 0
+	err := conf.Glue.GetCrawlersPages(&glue.GetCrawlersInput{}, func(p *glue.GetCrawlersOutput, lastPage bool) bool {
+		crawlers += len(p.Crawlers)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve glue crawlers, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Crawlers per account", glueDefaultQuotas["Crawlers per account"])
+	quotaInfo.UsageValue = float64(crawlers)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getGlueTriggersUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	triggers := 0
+	err := conf.Glue.GetTriggersPages(&glue.GetTriggersInput{}, func(p *glue.GetTriggersOutput, lastPage bool) bool {
+		triggers += len(p.Triggers)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve glue triggers, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Triggers per account", glueDefaultQuotas["Triggers per account"])
+	quotaInfo.UsageValue = float64(triggers)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getGlueConcurrentJobRunsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	jobs, err := getGlueJobs()
+	if err != nil {
+		fmt.Printf("failed to retrieve glue jobs, %v", err)
+		return
+	}
+
+	runs := 0
+	for _, job := range jobs {
+		jobRuns, errRuns := getGlueConcurrentJobRuns(job.Name)
+		if errRuns != nil {
+			fmt.Printf("failed to retrieve runs for glue job %s, %v", aws.StringValue(job.Name), errRuns)
+			return
+		}
+		runs += jobRuns
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Max concurrent job runs per account", glueDefaultQuotas["Max concurrent job runs per account"])
+	quotaInfo.UsageValue = float64(runs)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getGlueConcurrentJobRunsPerJobUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	jobs, err := getGlueJobs()
+	if err != nil {
+		fmt.Printf("failed to retrieve glue jobs, %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		quotaInfo := c.getAppliedQuotaOrDefault("Max concurrent job runs per job", glueDefaultQuotas["Max concurrent job runs per job"])
+		// a job cannot run more often in parallel than its own max concurrent
+		// runs, which is therefore the effective limit
+		if job.ExecutionProperty != nil && job.ExecutionProperty.MaxConcurrentRuns != nil {
+			quotaInfo = withServiceValue(quotaInfo, float64(aws.Int64Value(job.ExecutionProperty.MaxConcurrentRuns)))
+		}
+		runs, errRuns := getGlueConcurrentJobRuns(job.Name)
+		if errRuns != nil {
+			fmt.Printf("failed to retrieve runs for glue job %s, %v", aws.StringValue(job.Name), errRuns)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(runs)
+		quotaInfo.ResourceId = glueJobResourceId(job)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedGlueClient struct {
+	GlueClientInterface
+	GetDatabasesPagesResp  glue.GetDatabasesOutput
+	GetDatabasesPagesError error
+	GetTablesPagesResp     glue.GetTablesOutput
+	GetTablesPagesError    error
+	GetJobsPagesResp       glue.GetJobsOutput
+	GetJobsPagesError      error
+	GetJobRunsPagesResp    glue.GetJobRunsOutput
+	GetJobRunsPagesError   error
+	GetCrawlersPagesResp   glue.GetCrawlersOutput
+	GetCrawlersPagesError  error
+	GetTriggersPagesResp   glue.GetTriggersOutput
+	GetTriggersPagesError  error
+}
+
+func (m mockedGlueClient) GetDatabasesPages(input *glue.GetDatabasesInput, fn func(*glue.GetDatabasesOutput, bool) bool) error {
+	return mockPages(m.GetDatabasesPagesResp, m.GetDatabasesPagesError, fn)
+}
+
+func (m mockedGlueClient) GetTablesPages(input *glue.GetTablesInput, fn func(*glue.GetTablesOutput, bool) bool) error {
+	return mockPages(m.GetTablesPagesResp, m.GetTablesPagesError, fn)
+}
+
+func (m mockedGlueClient) GetJobsPages(input *glue.GetJobsInput, fn func(*glue.GetJobsOutput, bool) bool) error {
+	return mockPages(m.GetJobsPagesResp, m.GetJobsPagesError, fn)
+}
+
+func (m mockedGlueClient) GetJobRunsPages(input *glue.GetJobRunsInput, fn func(*glue.GetJobRunsOutput, bool) bool) error {
+	return mockPages(m.GetJobRunsPagesResp, m.GetJobRunsPagesError, fn)
+}
+
+func (m mockedGlueClient) GetCrawlersPages(input *glue.GetCrawlersInput, fn func(*glue.GetCrawlersOutput, bool) bool) error {
+	return mockPages(m.GetCrawlersPagesResp, m.GetCrawlersPagesError, fn)
+}
+
+func (m mockedGlueClient) GetTriggersPages(input *glue.GetTriggersInput, fn func(*glue.GetTriggersOutput, bool) bool) error {
+	return mockPages(m.GetTriggersPagesResp, m.GetTriggersPagesError, fn)
+}
+
+var mockedGlueJobs = glue.GetJobsOutput{
+	Jobs: []*glue.Job{
+		{Name: aws.String("foo"), ExecutionProperty: &glue.ExecutionProperty{MaxConcurrentRuns: aws.Int64(3)}},
+		{Name: aws.String("bar")},
+	},
+}
+
+var mockedGlueJobRuns = glue.GetJobRunsOutput{
+	JobRuns: []*glue.JobRun{
+		{JobRunState: aws.String(glue.JobRunStateRunning)},
+		{JobRunState: aws.String(glue.JobRunStateStarting)},
+		{JobRunState: aws.String(glue.JobRunStateSucceeded)},
+	},
+}
+
+func TestNewGlueCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewGlueChecker())
+}
+
+func TestGetGlueAccountUsage(t *testing.T) {
+	t.Cleanup(func() { glueJobs = []*glue.Job{} })
+	conf.Glue = mockedGlueClient{
+		GetDatabasesPagesResp: glue.GetDatabasesOutput{DatabaseList: []*glue.Database{{}, {}}},
+		GetJobsPagesResp:      mockedGlueJobs,
+		GetJobRunsPagesResp:   mockedGlueJobRuns,
+		GetCrawlersPagesResp:  glue.GetCrawlersOutput{Crawlers: []*glue.Crawler{{}}},
+		GetTriggersPagesResp:  glue.GetTriggersOutput{Triggers: []*glue.Trigger{{}, {}, {}}},
+	}
+	svcChecker := newTestServiceChecker(NewGlueChecker, NewQuota("glue", "Jobs per account", float64(2000), false))
+
+	databases := svcChecker.getGlueDatabasesUsage()
+	assert.Len(t, databases, 1)
+	assert.Equal(t, "glue", databases[0].Service)
+	assert.Equal(t, float64(10000), databases[0].QuotaValue)
+	assert.Equal(t, float64(2), databases[0].UsageValue)
+
+	jobs := svcChecker.getGlueJobsUsage()
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, float64(2000), jobs[0].QuotaValue)
+	assert.Equal(t, float64(2), jobs[0].UsageValue)
+
+	crawlers := svcChecker.getGlueCrawlersUsage()
+	assert.Len(t, crawlers, 1)
+	assert.Equal(t, float64(1), crawlers[0].UsageValue)
+
+	triggers := svcChecker.getGlueTriggersUsage()
+	assert.Len(t, triggers, 1)
+	assert.Equal(t, float64(3), triggers[0].UsageValue)
+
+	// both jobs report the same two in-progress runs
+	runs := svcChecker.getGlueConcurrentJobRunsUsage()
+	assert.Len(t, runs, 1)
+	assert.Equal(t, float64(50), runs[0].QuotaValue)
+	assert.Equal(t, float64(4), runs[0].UsageValue)
+}
+
+func TestGetGlueAccountUsageError(t *testing.T) {
+	t.Cleanup(func() { glueJobs = []*glue.Job{} })
+	conf.Glue = mockedGlueClient{
+		GetDatabasesPagesError: errors.New("test error"),
+		GetJobsPagesError:      errors.New("test error"),
+		GetCrawlersPagesError:  errors.New("test error"),
+		GetTriggersPagesError:  errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewGlueChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getGlueDatabasesUsage())
+	assert.Equal(t, expected, svcChecker.getGlueTablesPerDatabaseUsage())
+	assert.Equal(t, expected, svcChecker.getGlueJobsUsage())
+	assert.Equal(t, expected, svcChecker.getGlueCrawlersUsage())
+	assert.Equal(t, expected, svcChecker.getGlueTriggersUsage())
+	assert.Equal(t, expected, svcChecker.getGlueConcurrentJobRunsUsage())
+	assert.Equal(t, expected, svcChecker.getGlueConcurrentJobRunsPerJobUsage())
+}
+
+func TestGetGlueTablesPerDatabaseUsage(t *testing.T) {
+	conf.Glue = mockedGlueClient{
+		GetDatabasesPagesResp: glue.GetDatabasesOutput{DatabaseList: []*glue.Database{{Name: aws.String("foo")}}},
+		GetTablesPagesResp:    glue.GetTablesOutput{TableList: []*glue.TableData{{}, {}}},
+	}
+	svcChecker := newTestServiceChecker(NewGlueChecker)
+	actual := svcChecker.getGlueTablesPerDatabaseUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "AWS::Glue::Database::foo", actual[0].ResourceId)
+	assert.Equal(t, float64(200000), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+}
+
+func TestGetGlueTablesPerDatabaseUsageError(t *testing.T) {
+	conf.Glue = mockedGlueClient{
+		GetDatabasesPagesResp: glue.GetDatabasesOutput{DatabaseList: []*glue.Database{{Name: aws.String("foo")}}},
+		GetTablesPagesError:   errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewGlueChecker)
+	actual := svcChecker.getGlueTablesPerDatabaseUsage()
+
+	assert.Len(t, actual, 0)
+}
+
+func TestGetGlueConcurrentJobRunsPerJobUsage(t *testing.T) {
+	t.Cleanup(func() { glueJobs = []*glue.Job{} })
+	conf.Glue = mockedGlueClient{
+		GetJobsPagesResp:    mockedGlueJobs,
+		GetJobRunsPagesResp: mockedGlueJobRuns,
+	}
+	svcChecker := newTestServiceChecker(NewGlueChecker)
+	actual := svcChecker.getGlueConcurrentJobRunsPerJobUsage()
+
+	assert.Len(t, actual, 2)
+	assert.Equal(t, "AWS::Glue::Job::foo", actual[0].ResourceId)
+	assert.Equal(t, float64(3), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+	assert.Equal(t, "AWS::Glue::Job::bar", actual[1].ResourceId)
+	assert.Equal(t, float64(1), actual[1].QuotaValue)
+}
+
+func TestGetGlueConcurrentJobRunsUsageError(t *testing.T) {
+	t.Cleanup(func() { glueJobs = []*glue.Job{} })
+	conf.Glue = mockedGlueClient{
+		GetJobsPagesResp:     mockedGlueJobs,
+		GetJobRunsPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewGlueChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getGlueConcurrentJobRunsUsage())
+	assert.Equal(t, expected, svcChecker.getGlueConcurrentJobRunsPerJobUsage())
+}
@@ -2,12 +2,18 @@ package services
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/iam"
 )
 
 type IamClientInterface interface {
 	GetAccountSummary(input *iam.GetAccountSummaryInput) (*iam.GetAccountSummaryOutput, error)
+	GetRole(input *iam.GetRoleInput) (*iam.GetRoleOutput, error)
+	SimulatePrincipalPolicyPages(input *iam.SimulatePrincipalPolicyInput, fn func(*iam.SimulatePolicyResponse, bool) bool) error
 }
 
 func NewIamChecker() Svcquota {
@@ -58,7 +64,7 @@ func IamSummaryToAWSQuotaInfo(summaryName string, quotaName string) (ret AWSQuot
 		Global:    true,
 	}
 	if val, ok := quotas[summaryName+"Quota"]; ok {
-		ret.QuotaValue = float64(*val)
+		ret = withServiceValue(ret, float64(*val))
 	}
 	if val, ok := quotas[summaryName]; ok {
 		ret.UsageValue = float64(*val)
@@ -113,3 +119,81 @@ func (c ServiceChecker) getIamServerCertificatesUsage() (ret []AWSQuotaInfo) {
 		return []AWSQuotaInfo{quotaInfo}
 	}
 }
+
+// simulationBatchSize is the number of actions simulated per request
+const simulationBatchSize = 100
+
+// DeniedIamAction is an iam action the current principal is not allowed to call
+type DeniedIamAction struct {
+	Action   string // the iam action, e.g. eks:ListClusters
+	Decision string // the simulation decision, implicitDeny or explicitDeny
+}
+
+// GetPrincipalArn returns the arn of the iam principal behind the configured
+// session. Sessions of assumed roles are resolved to their role. Federated users
+// have no iam principal to simulate, and are rejected
+func GetPrincipalArn() (string, error) {
+	callerArn, err := GetCallerArn()
+	if err != nil {
+		return "", err
+	}
+	parsed, err := arn.Parse(callerArn)
+	if err != nil {
+		return "", fmt.Errorf("invalid caller arn %s, %v", callerArn, err)
+	}
+	// assumed-role/<role name>/<session name> or federated-user/<user name>
+	parts := strings.Split(parsed.Resource, "/")
+	if parsed.Service == "sts" && parts[0] == "federated-user" {
+		return "", fmt.Errorf("unable to simulate the policies of federated user %s, "+
+			"run the verification with the iam user or role the federation token was requested by", callerArn)
+	}
+	if parsed.Service != "sts" || parts[0] != "assumed-role" || len(parts) < 2 {
+		return callerArn, nil
+	}
+	// the path of the role is not part of the session arn, the role is looked
+	// up to retrieve it
+	role, err := conf.Iam.GetRole(&iam.GetRoleInput{RoleName: aws.String(parts[1])})
+	if err == nil && role.Role != nil && role.Role.Arn != nil {
+		return aws.StringValue(role.Role.Arn), nil
+	}
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", parsed.Partition, parsed.AccountID, parts[1]), nil
+}
+
+// SimulateIamActions simulates the given iam actions against the policies of
+// the current principal, and returns the denied ones
+func SimulateIamActions(actions []string) (ret []DeniedIamAction, err error) {
+	ret = []DeniedIamAction{}
+	principalArn, err := GetPrincipalArn()
+	if err != nil {
+		return
+	}
+
+	for start := 0; start < len(actions); start += simulationBatchSize {
+		end := start + simulationBatchSize
+		if end > len(actions) {
+			end = len(actions)
+		}
+		input := &iam.SimulatePrincipalPolicyInput{
+			PolicySourceArn: aws.String(principalArn),
+			ActionNames:     aws.StringSlice(actions[start:end]),
+		}
+		err = conf.Iam.SimulatePrincipalPolicyPages(input,
+			func(page *iam.SimulatePolicyResponse, lastPage bool) bool {
+				for _, result := range page.EvaluationResults {
+					if aws.StringValue(result.EvalDecision) != iam.PolicyEvaluationDecisionTypeAllowed {
+						ret = append(ret, DeniedIamAction{
+							Action:   aws.StringValue(result.EvalActionName),
+							Decision: aws.StringValue(result.EvalDecision),
+						})
+					}
+				}
+				return !lastPage
+			},
+		)
+		if err != nil {
+			return []DeniedIamAction{}, fmt.Errorf("unable to simulate the policies of %s, %v", principalArn, err)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Action < ret[j].Action })
+	return
+}
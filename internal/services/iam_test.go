@@ -2,10 +2,12 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -14,12 +16,33 @@ type mockedIamClient struct {
 	IamClientInterface
 	GetAccountSummaryResp  iam.GetAccountSummaryOutput
 	GetAccountSummaryError error
+	GetRoleResp            iam.GetRoleOutput
+	GetRoleError           error
+	SimulateResp           []iam.SimulatePolicyResponse
+	SimulateError          error
+	SimulatedActions       *[]string
 }
 
 func (m mockedIamClient) GetAccountSummary(input *iam.GetAccountSummaryInput) (*iam.GetAccountSummaryOutput, error) {
 	return &m.GetAccountSummaryResp, m.GetAccountSummaryError
 }
 
+func (m mockedIamClient) GetRole(input *iam.GetRoleInput) (*iam.GetRoleOutput, error) {
+	return &m.GetRoleResp, m.GetRoleError
+}
+
+func (m mockedIamClient) SimulatePrincipalPolicyPages(input *iam.SimulatePrincipalPolicyInput, fn func(*iam.SimulatePolicyResponse, bool) bool) error {
+	if m.SimulatedActions != nil {
+		*m.SimulatedActions = append(*m.SimulatedActions, aws.StringValueSlice(input.ActionNames)...)
+	}
+	for i := range m.SimulateResp {
+		if !fn(&m.SimulateResp[i], i == len(m.SimulateResp)-1) {
+			break
+		}
+	}
+	return m.SimulateError
+}
+
 func TestNewIamCheckerImpl(t *testing.T) {
 	require.Implements(t, (*Svcquota)(nil), NewIamChecker())
 }
@@ -308,3 +331,113 @@ func TestGetIamServerCertificatesUsageError(t *testing.T) {
 	assert.Equal(t, expected, actual)
 	t.Cleanup(func() { iamAccountQuota = map[string]*int64{} })
 }
+
+func TestGetIamRolesUsageOverride(t *testing.T) {
+	mockedGetAccountSummaryOutput := iam.GetAccountSummaryOutput{
+		SummaryMap: map[string]*int64{
+			"Roles":      aws.Int64(100),
+			"RolesQuota": aws.Int64(1000),
+		},
+	}
+	conf.Iam = mockedIamClient{GetAccountSummaryResp: mockedGetAccountSummaryOutput}
+
+	iamChecker := NewIamChecker()
+	svcChecker := iamChecker.(*ServiceChecker)
+	actual := ApplyQuotaOverrides(svcChecker.getIamRolesUsage(), []AWSQuotaOverride{
+		{Service: "iam", QuotaName: "Roles per Account", QuotaValue: float64(5000)}}, "")
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, float64(5000), actual[0].QuotaValue)
+	assert.Equal(t, float64(1000), actual[0].ServiceValue)
+	assert.Equal(t, QuotaSourceOverride, actual[0].Source)
+	t.Cleanup(func() { iamAccountQuota = map[string]*int64{} })
+}
+
+func TestGetPrincipalArnUser(t *testing.T) {
+	conf.Sts = mockedStsClient{GetCallerIdentityResp: sts.GetCallerIdentityOutput{Arn: aws.String("arn:aws:iam::123456789012:user/test")}}
+
+	actual, err := GetPrincipalArn()
+	assert.Nil(t, err)
+	assert.Equal(t, "arn:aws:iam::123456789012:user/test", actual)
+}
+
+func TestGetPrincipalArnAssumedRole(t *testing.T) {
+	conf.Sts = mockedStsClient{GetCallerIdentityResp: sts.GetCallerIdentityOutput{Arn: aws.String("arn:aws:sts::123456789012:assumed-role/admin/session")}}
+	conf.Iam = mockedIamClient{GetRoleResp: iam.GetRoleOutput{Role: &iam.Role{Arn: aws.String("arn:aws:iam::123456789012:role/path/admin")}}}
+
+	actual, err := GetPrincipalArn()
+	assert.Nil(t, err)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/path/admin", actual)
+}
+
+func TestGetPrincipalArnAssumedRoleGetRoleError(t *testing.T) {
+	conf.Sts = mockedStsClient{GetCallerIdentityResp: sts.GetCallerIdentityOutput{Arn: aws.String("arn:aws:sts::123456789012:assumed-role/admin/session")}}
+	conf.Iam = mockedIamClient{GetRoleError: errors.New("test error")}
+
+	actual, err := GetPrincipalArn()
+	assert.Nil(t, err)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/admin", actual)
+}
+
+func TestGetPrincipalArnFederatedUser(t *testing.T) {
+	conf.Sts = mockedStsClient{GetCallerIdentityResp: sts.GetCallerIdentityOutput{Arn: aws.String("arn:aws:sts::123456789012:federated-user/bob")}}
+
+	_, err := GetPrincipalArn()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "federated user arn:aws:sts::123456789012:federated-user/bob")
+}
+
+func TestGetPrincipalArnError(t *testing.T) {
+	conf.Sts = mockedStsClient{GetCallerIdentityError: errors.New("test error")}
+	_, err := GetPrincipalArn()
+	assert.Error(t, err)
+
+	conf.Sts = mockedStsClient{GetCallerIdentityResp: sts.GetCallerIdentityOutput{Arn: aws.String("invalid")}}
+	_, err = GetPrincipalArn()
+	assert.Error(t, err)
+}
+
+func TestSimulateIamActions(t *testing.T) {
+	conf.Sts = mockedStsClient{GetCallerIdentityResp: sts.GetCallerIdentityOutput{Arn: aws.String("arn:aws:iam::123456789012:user/test")}}
+	conf.Iam = mockedIamClient{SimulateResp: []iam.SimulatePolicyResponse{
+		{EvaluationResults: []*iam.EvaluationResult{
+			{EvalActionName: aws.String("s3:ListAllMyBuckets"), EvalDecision: aws.String("implicitDeny")},
+			{EvalActionName: aws.String("eks:ListClusters"), EvalDecision: aws.String("allowed")},
+		}},
+		{EvaluationResults: []*iam.EvaluationResult{
+			{EvalActionName: aws.String("iam:GetAccountSummary"), EvalDecision: aws.String("explicitDeny")},
+		}},
+	}}
+
+	actual, err := SimulateIamActions([]string{"s3:ListAllMyBuckets", "eks:ListClusters", "iam:GetAccountSummary"})
+	assert.Nil(t, err)
+	expected := []DeniedIamAction{
+		{Action: "iam:GetAccountSummary", Decision: "explicitDeny"},
+		{Action: "s3:ListAllMyBuckets", Decision: "implicitDeny"},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestSimulateIamActionsBatches(t *testing.T) {
+	conf.Sts = mockedStsClient{GetCallerIdentityResp: sts.GetCallerIdentityOutput{Arn: aws.String("arn:aws:iam::123456789012:user/test")}}
+	simulated := []string{}
+	conf.Iam = mockedIamClient{SimulatedActions: &simulated}
+
+	actions := []string{}
+	for i := 0; i < simulationBatchSize+10; i++ {
+		actions = append(actions, fmt.Sprintf("service:Action%d", i))
+	}
+	actual, err := SimulateIamActions(actions)
+	assert.Nil(t, err)
+	assert.Empty(t, actual)
+	assert.Equal(t, actions, simulated)
+}
+
+func TestSimulateIamActionsError(t *testing.T) {
+	conf.Sts = mockedStsClient{GetCallerIdentityResp: sts.GetCallerIdentityOutput{Arn: aws.String("arn:aws:iam::123456789012:user/test")}}
+	conf.Iam = mockedIamClient{SimulateError: errors.New("test error")}
+
+	actual, err := SimulateIamActions([]string{"s3:ListAllMyBuckets"})
+	assert.Error(t, err)
+	assert.Empty(t, actual)
+}
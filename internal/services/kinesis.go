@@ -24,7 +24,7 @@ func NewKinesisChecker() Svcquota {
 func (c ServiceChecker) getKinesisShardUsage() (ret []AWSQuotaInfo) {
 	ret = []AWSQuotaInfo{}
 	result, err := conf.Kinesis.DescribeLimits(nil)
-	quotaInfo := c.GetAllAppliedQuotas()["Shards per Region"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Shards per Region", quotaDefault{})
 
 	if err != nil {
 		fmt.Printf("Unable to retrieve kinesis limits, %v", err)
@@ -54,7 +54,7 @@ func (c ServiceChecker) getKinesisOnDemandStreamCountUsage() (ret []AWSQuotaInfo
 
 	// On-demand Data Streams per account is not in service quotas, so we will
 	// need to create its entry in the quota list
-	quotaInfo.QuotaValue = float64(*result.OnDemandStreamCountLimit)
+	quotaInfo = withServiceValue(quotaInfo, float64(*result.OnDemandStreamCountLimit))
 	quotaInfo.UsageValue = float64(*result.OnDemandStreamCount)
 
 	c.GetAllAppliedQuotas()[quotaInfo.QuotaName] = quotaInfo
@@ -69,6 +69,7 @@ func TestGetKinesisOnDemandStreamCountUsage(t *testing.T) {
 		OnDemandStreamCountLimit: aws.Int64(200),
 	}
 	conf.Kinesis = mockedKinesisDescribeLimitsMsg{Resp: mockedkinesisOutput, Error: nil}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
 
 	kinesisChecker := NewKinesisChecker()
 	svcChecker := kinesisChecker.(*ServiceChecker)
@@ -96,3 +97,21 @@ func TestGetKinesisOnDemandStreamCountUsageError(t *testing.T) {
 	expected := []AWSQuotaInfo{}
 	assert.Equal(t, expected, actual)
 }
+
+func TestGetKinesisOnDemandStreamCountUsageOverride(t *testing.T) {
+	mockedkinesisOutput := kinesis.DescribeLimitsOutput{
+		OnDemandStreamCount:      aws.Int64(10),
+		OnDemandStreamCountLimit: aws.Int64(200),
+	}
+	conf.Kinesis = mockedKinesisDescribeLimitsMsg{Resp: mockedkinesisOutput, Error: nil}
+
+	kinesisChecker := NewKinesisChecker()
+	svcChecker := kinesisChecker.(*ServiceChecker)
+	actual := ApplyQuotaOverrides(svcChecker.getKinesisOnDemandStreamCountUsage(), []AWSQuotaOverride{
+		{Service: "kinesis", QuotaName: "On-demand Data Streams per account", QuotaValue: float64(400)}}, "")
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, float64(400), actual[0].QuotaValue)
+	assert.Equal(t, float64(200), actual[0].ServiceValue)
+	assert.Equal(t, QuotaSourceOverride, actual[0].Source)
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+type KmsClientInterface interface {
+	ListKeysPages(input *kms.ListKeysInput, fn func(*kms.ListKeysOutput, bool) bool) error
+	DescribeKey(input *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error)
+	ListAliasesPages(input *kms.ListAliasesInput, fn func(*kms.ListAliasesOutput, bool) bool) error
+	ListGrantsPages(input *kms.ListGrantsInput, fn func(*kms.ListGrantsResponse, bool) bool) error
+}
+
+var kmsDefaultQuotas = map[string]quotaDefault{
+	"Customer Master Keys (CMKs) per Region": {Value: 100000},
+	"Aliases per KMS key":                    {Value: 50},
+	"Grants per KMS key":                     {Value: 50000},
+}
+
+func NewKmsChecker() Svcquota {
+	serviceCode := "kms"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Customer Master Keys (CMKs) per Region": ServiceChecker.getKmsCustomerManagedKeysUsage,
+		"Aliases per KMS key":                    ServiceChecker.getKmsAliasesPerKeyUsage,
+		"Grants per KMS key":                     ServiceChecker.getKmsGrantsPerKeyUsage,
+	}
+	requiredPermissions := []string{
+		"kms:ListKeys",
+		"kms:DescribeKey",
+		"kms:ListAliases",
+		"kms:ListGrants",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+var kmsCustomerManagedKeys []*kms.KeyMetadata = []*kms.KeyMetadata{}
+
+// getKmsCustomerManagedKeys lists the customer managed keys once and shares the
+// result between the different quotas. ListKeys also returns aws managed keys,
+// which do not count against the quota, so every key needs to be described.
+// Keys that cannot be described, e.g. when their key policy denies the caller,
+// are skipped
+func getKmsCustomerManagedKeys() (ret []*kms.KeyMetadata, err error) {
+	ret = kmsCustomerManagedKeys
+	if len(kmsCustomerManagedKeys) != 0 {
+		return
+	}
+
+	keys := []*kms.KeyListEntry{}
+	err = conf.Kms.ListKeysPages(&kms.ListKeysInput{}, func(p *kms.ListKeysOutput, lastPage bool) bool {
+		keys = append(keys, p.Keys...)
+		return true // continue paging
+	})
+	if err != nil {
+		return []*kms.KeyMetadata{}, err
+	}
+
+	for _, k := range keys {
+		result, errDescribe := conf.Kms.DescribeKey(&kms.DescribeKeyInput{KeyId: k.KeyId})
+		if errDescribe != nil {
+			fmt.Printf("failed to describe kms key %s, skipping it, %v\n", aws.StringValue(k.KeyId), errDescribe)
+			continue
+		}
+		if aws.StringValue(result.KeyMetadata.KeyManager) == kms.KeyManagerTypeCustomer {
+			kmsCustomerManagedKeys = append(kmsCustomerManagedKeys, result.KeyMetadata)
+		}
+	}
+	return kmsCustomerManagedKeys, nil
+}
+
+func (c ServiceChecker) getKmsCustomerManagedKeysUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	keys, err := getKmsCustomerManagedKeys()
+	if err != nil {
+		fmt.Printf("failed to retrieve kms keys, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Customer Master Keys (CMKs) per Region", kmsDefaultQuotas["Customer Master Keys (CMKs) per Region"])
+	quotaInfo.UsageValue = float64(len(keys))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getKmsAliasesPerKeyUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	keys := []string{}
+	aliases := map[string]int{}
+	err := conf.Kms.ListAliasesPages(&kms.ListAliasesInput{}, func(p *kms.ListAliasesOutput, lastPage bool) bool {
+		for _, a := range p.Aliases {
+			// aliases of aws managed keys do not count against the quota, and
+			// aliases not associated with a key do not count for any key
+			keyId := aws.StringValue(a.TargetKeyId)
+			if strings.HasPrefix(aws.StringValue(a.AliasName), "alias/aws/") || keyId == "" {
+				continue
+			}
+			if aliases[keyId] == 0 {
+				keys = append(keys, keyId)
+			}
+			aliases[keyId]++
+		}
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve kms aliases, %v", err)
+		return
+	}
+
+	for _, keyId := range keys {
+		quotaInfo := c.getAppliedQuotaOrDefault("Aliases per KMS key", kmsDefaultQuotas["Aliases per KMS key"])
+		quotaInfo.UsageValue = float64(aliases[keyId])
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::KMS::Key::%s", keyId)
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getKmsGrantsPerKeyUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	keys, err := getKmsCustomerManagedKeys()
+	if err != nil {
+		fmt.Printf("failed to retrieve kms keys, %v", err)
+		return
+	}
+
+	for _, k := range keys {
+		quotaInfo := c.getAppliedQuotaOrDefault("Grants per KMS key", kmsDefaultQuotas["Grants per KMS key"])
+		grants := 0
+		errGrants := conf.Kms.ListGrantsPages(&kms.ListGrantsInput{KeyId: k.KeyId}, func(p *kms.ListGrantsResponse, lastPage bool) bool {
+			grants += len(p.Grants)
+			return true // continue paging
+		})
+		if errGrants != nil {
+			fmt.Printf("failed to retrieve grants for kms key %s, %v", aws.StringValue(k.KeyId), errGrants)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(grants)
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::KMS::Key::%s", aws.StringValue(k.KeyId))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
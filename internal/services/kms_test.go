@@ -0,0 +1,173 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedKmsClient struct {
+	KmsClientInterface
+	ListKeysPagesResp     kms.ListKeysOutput
+	ListKeysPagesError    error
+	DescribeKeyResp       map[string]kms.DescribeKeyOutput
+	DescribeKeyError      error
+	DescribeKeyErrors     map[string]error
+	ListAliasesPagesResp  kms.ListAliasesOutput
+	ListAliasesPagesError error
+	ListGrantsPagesResp   kms.ListGrantsResponse
+	ListGrantsPagesError  error
+}
+
+func (m mockedKmsClient) ListKeysPages(input *kms.ListKeysInput, fn func(*kms.ListKeysOutput, bool) bool) error {
+	return mockPages(m.ListKeysPagesResp, m.ListKeysPagesError, fn)
+}
+
+func (m mockedKmsClient) DescribeKey(input *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+	resp := m.DescribeKeyResp[*input.KeyId]
+	if err, ok := m.DescribeKeyErrors[*input.KeyId]; ok {
+		return &resp, err
+	}
+	return &resp, m.DescribeKeyError
+}
+
+func (m mockedKmsClient) ListAliasesPages(input *kms.ListAliasesInput, fn func(*kms.ListAliasesOutput, bool) bool) error {
+	return mockPages(m.ListAliasesPagesResp, m.ListAliasesPagesError, fn)
+}
+
+func (m mockedKmsClient) ListGrantsPages(input *kms.ListGrantsInput, fn func(*kms.ListGrantsResponse, bool) bool) error {
+	return mockPages(m.ListGrantsPagesResp, m.ListGrantsPagesError, fn)
+}
+
+var mockedKmsKeys = kms.ListKeysOutput{
+	Keys: []*kms.KeyListEntry{{KeyId: aws.String("foo")}, {KeyId: aws.String("bar")}},
+}
+
+var mockedKmsKeysMetadata = map[string]kms.DescribeKeyOutput{
+	"foo": {KeyMetadata: &kms.KeyMetadata{KeyId: aws.String("foo"), KeyManager: aws.String(kms.KeyManagerTypeCustomer)}},
+	"bar": {KeyMetadata: &kms.KeyMetadata{KeyId: aws.String("bar"), KeyManager: aws.String(kms.KeyManagerTypeAws)}},
+}
+
+func TestNewKmsCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewKmsChecker())
+}
+
+func TestGetKmsCustomerManagedKeysUsage(t *testing.T) {
+	t.Cleanup(func() { kmsCustomerManagedKeys = []*kms.KeyMetadata{} })
+	conf.Kms = mockedKmsClient{ListKeysPagesResp: mockedKmsKeys, DescribeKeyResp: mockedKmsKeysMetadata}
+	svcChecker := newTestServiceChecker(NewKmsChecker, NewQuota("kms", "Customer Master Keys (CMKs) per Region", float64(1000), false))
+	actual := svcChecker.getKmsCustomerManagedKeysUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "kms", actual[0].Service)
+	assert.Equal(t, float64(1000), actual[0].QuotaValue)
+	assert.Equal(t, float64(1), actual[0].UsageValue)
+}
+
+func TestGetKmsCustomerManagedKeysUsageError(t *testing.T) {
+	t.Cleanup(func() { kmsCustomerManagedKeys = []*kms.KeyMetadata{} })
+	conf.Kms = mockedKmsClient{ListKeysPagesError: errors.New("test error")}
+	svcChecker := newTestServiceChecker(NewKmsChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getKmsCustomerManagedKeysUsage())
+	assert.Equal(t, expected, svcChecker.getKmsGrantsPerKeyUsage())
+}
+
+func TestGetKmsCustomerManagedKeysUsageDescribeError(t *testing.T) {
+	t.Cleanup(func() { kmsCustomerManagedKeys = []*kms.KeyMetadata{} })
+	conf.Kms = mockedKmsClient{ListKeysPagesResp: mockedKmsKeys, DescribeKeyError: errors.New("test error")}
+	svcChecker := newTestServiceChecker(NewKmsChecker)
+	actual := svcChecker.getKmsCustomerManagedKeysUsage()
+
+	// keys that cannot be described are skipped
+	assert.Len(t, actual, 1)
+	assert.Equal(t, float64(0), actual[0].UsageValue)
+}
+
+func TestGetKmsCustomerManagedKeysDescribeErrorSkipsKey(t *testing.T) {
+	t.Cleanup(func() { kmsCustomerManagedKeys = []*kms.KeyMetadata{} })
+	conf.Kms = mockedKmsClient{
+		ListKeysPagesResp: kms.ListKeysOutput{
+			Keys: []*kms.KeyListEntry{{KeyId: aws.String("denied")}, {KeyId: aws.String("foo")}, {KeyId: aws.String("bar")}},
+		},
+		DescribeKeyResp:     mockedKmsKeysMetadata,
+		DescribeKeyErrors:   map[string]error{"denied": errors.New("access denied")},
+		ListGrantsPagesResp: kms.ListGrantsResponse{Grants: []*kms.GrantListEntry{{}, {}}},
+	}
+	svcChecker := newTestServiceChecker(NewKmsChecker)
+
+	keysUsage := svcChecker.getKmsCustomerManagedKeysUsage()
+	assert.Len(t, keysUsage, 1)
+	assert.Equal(t, float64(1), keysUsage[0].UsageValue)
+
+	grantsUsage := svcChecker.getKmsGrantsPerKeyUsage()
+	assert.Len(t, grantsUsage, 1)
+	assert.Equal(t, "AWS::KMS::Key::foo", grantsUsage[0].ResourceId)
+	assert.Equal(t, float64(2), grantsUsage[0].UsageValue)
+}
+
+func TestGetKmsAliasesPerKeyUsage(t *testing.T) {
+	conf.Kms = mockedKmsClient{
+		ListAliasesPagesResp: kms.ListAliasesOutput{
+			Aliases: []*kms.AliasListEntry{
+				{AliasName: aws.String("alias/foo"), TargetKeyId: aws.String("foo")},
+				{AliasName: aws.String("alias/foo-bis"), TargetKeyId: aws.String("foo")},
+				{AliasName: aws.String("alias/bar"), TargetKeyId: aws.String("bar")},
+				{AliasName: aws.String("alias/unused")},
+				{AliasName: aws.String("alias/aws/s3"), TargetKeyId: aws.String("s3")},
+			},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewKmsChecker)
+	actual := svcChecker.getKmsAliasesPerKeyUsage()
+
+	assert.Len(t, actual, 2)
+	assert.Equal(t, "AWS::KMS::Key::foo", actual[0].ResourceId)
+	assert.Equal(t, float64(50), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+	assert.Equal(t, "AWS::KMS::Key::bar", actual[1].ResourceId)
+	assert.Equal(t, float64(1), actual[1].UsageValue)
+}
+
+func TestGetKmsAliasesPerKeyUsageError(t *testing.T) {
+	conf.Kms = mockedKmsClient{ListAliasesPagesError: errors.New("test error")}
+	svcChecker := newTestServiceChecker(NewKmsChecker)
+	actual := svcChecker.getKmsAliasesPerKeyUsage()
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, actual)
+}
+
+func TestGetKmsGrantsPerKeyUsage(t *testing.T) {
+	t.Cleanup(func() { kmsCustomerManagedKeys = []*kms.KeyMetadata{} })
+	conf.Kms = mockedKmsClient{
+		ListKeysPagesResp:   mockedKmsKeys,
+		DescribeKeyResp:     mockedKmsKeysMetadata,
+		ListGrantsPagesResp: kms.ListGrantsResponse{Grants: []*kms.GrantListEntry{{}, {}, {}}},
+	}
+	svcChecker := newTestServiceChecker(NewKmsChecker)
+	actual := svcChecker.getKmsGrantsPerKeyUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "AWS::KMS::Key::foo", actual[0].ResourceId)
+	assert.Equal(t, float64(50000), actual[0].QuotaValue)
+	assert.Equal(t, float64(3), actual[0].UsageValue)
+}
+
+func TestGetKmsGrantsPerKeyUsageError(t *testing.T) {
+	t.Cleanup(func() { kmsCustomerManagedKeys = []*kms.KeyMetadata{} })
+	conf.Kms = mockedKmsClient{
+		ListKeysPagesResp:    mockedKmsKeys,
+		DescribeKeyResp:      mockedKmsKeysMetadata,
+		ListGrantsPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewKmsChecker)
+	actual := svcChecker.getKmsGrantsPerKeyUsage()
+
+	assert.Len(t, actual, 0)
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mq"
+)
+
+type MqClientInterface interface {
+	ListBrokersPages(input *mq.ListBrokersInput, fn func(*mq.ListBrokersResponse, bool) bool) error
+	ListUsers(input *mq.ListUsersInput) (*mq.ListUsersResponse, error)
+}
+
+var mqDefaultQuotas = map[string]quotaDefault{
+	"Brokers per region": {Value: 50},
+	"Users per broker":   {Value: 250},
+}
+
+func NewMqChecker() Svcquota {
+	serviceCode := "mq"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Brokers per region": ServiceChecker.getMqBrokersUsage,
+		"Users per broker":   ServiceChecker.getMqUsersPerBrokerUsage,
+	}
+	requiredPermissions := []string{
+		"mq:ListBrokers",
+		"mq:ListUsers",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func getMqBrokers() (ret []*mq.BrokerSummary, err error) {
+	ret = []*mq.BrokerSummary{}
+	err = conf.Mq.ListBrokersPages(&mq.ListBrokersInput{}, func(p *mq.ListBrokersResponse, lastPage bool) bool {
+		ret = append(ret, p.BrokerSummaries...)
+		return true // continue paging
+	})
+	return
+}
+
+func (c ServiceChecker) getMqBrokersUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	brokers, err := getMqBrokers()
+	if err != nil {
+		fmt.Printf("failed to retrieve mq brokers, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Brokers per region", mqDefaultQuotas["Brokers per region"])
+	quotaInfo.UsageValue = float64(len(brokers))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+// getMqUsersPerBrokerUsage only covers ActiveMQ brokers, RabbitMQ users are
+// managed within the broker and not exposed by the mq api
+func (c ServiceChecker) getMqUsersPerBrokerUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	brokers, err := getMqBrokers()
+	if err != nil {
+		fmt.Printf("failed to retrieve mq brokers, %v", err)
+		return
+	}
+
+	for _, broker := range brokers {
+		if aws.StringValue(broker.EngineType) != mq.EngineTypeActivemq {
+			continue
+		}
+
+		quotaInfo := c.getAppliedQuotaOrDefault("Users per broker", mqDefaultQuotas["Users per broker"])
+		users := 0
+		input := &mq.ListUsersInput{BrokerId: broker.BrokerId}
+		var errUsers error
+		for {
+			result, errList := conf.Mq.ListUsers(input)
+			if errList != nil {
+				errUsers = errList
+				break
+			}
+			users += len(result.Users)
+			if result.NextToken == nil {
+				break
+			}
+			input.NextToken = result.NextToken
+		}
+		if errUsers != nil {
+			fmt.Printf("failed to retrieve users for broker %s, %v", aws.StringValue(broker.BrokerName), errUsers)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(users)
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::AmazonMQ::Broker::%s", aws.StringValue(broker.BrokerName))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
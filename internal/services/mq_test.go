@@ -0,0 +1,84 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/mq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedMqClient struct {
+	MqClientInterface
+	ListBrokersPagesResp  mq.ListBrokersResponse
+	ListBrokersPagesError error
+	ListUsersResp         mq.ListUsersResponse
+	ListUsersError        error
+}
+
+func (m mockedMqClient) ListBrokersPages(input *mq.ListBrokersInput, fn func(*mq.ListBrokersResponse, bool) bool) error {
+	return mockPages(m.ListBrokersPagesResp, m.ListBrokersPagesError, fn)
+}
+
+func (m mockedMqClient) ListUsers(input *mq.ListUsersInput) (*mq.ListUsersResponse, error) {
+	return &m.ListUsersResp, m.ListUsersError
+}
+
+func TestNewMqCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewMqChecker())
+}
+
+func TestGetMqUsage(t *testing.T) {
+	conf.Mq = mockedMqClient{
+		ListBrokersPagesResp: mq.ListBrokersResponse{
+			BrokerSummaries: []*mq.BrokerSummary{
+				{BrokerId: aws.String("b-1"), BrokerName: aws.String("orders"), EngineType: aws.String(mq.EngineTypeActivemq)},
+				{BrokerId: aws.String("b-2"), BrokerName: aws.String("events"), EngineType: aws.String(mq.EngineTypeRabbitmq)},
+			},
+		},
+		ListUsersResp: mq.ListUsersResponse{
+			Users: []*mq.UserSummary{{}, {}, {}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewMqChecker, NewQuota("mq", "Brokers per region", float64(100), false))
+
+	brokers := svcChecker.getMqBrokersUsage()
+	assert.Len(t, brokers, 1)
+	assert.Equal(t, "mq", brokers[0].Service)
+	assert.Equal(t, float64(100), brokers[0].QuotaValue)
+	assert.Equal(t, float64(2), brokers[0].UsageValue)
+
+	// rabbitmq brokers do not expose their users
+	users := svcChecker.getMqUsersPerBrokerUsage()
+	assert.Len(t, users, 1)
+	assert.Equal(t, "AWS::AmazonMQ::Broker::orders", users[0].ResourceId)
+	assert.Equal(t, float64(250), users[0].QuotaValue)
+	assert.Equal(t, float64(3), users[0].UsageValue)
+}
+
+func TestGetMqUsageError(t *testing.T) {
+	conf.Mq = mockedMqClient{
+		ListBrokersPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewMqChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getMqBrokersUsage())
+	assert.Equal(t, expected, svcChecker.getMqUsersPerBrokerUsage())
+}
+
+func TestGetMqUsersPerBrokerUsageError(t *testing.T) {
+	conf.Mq = mockedMqClient{
+		ListBrokersPagesResp: mq.ListBrokersResponse{
+			BrokerSummaries: []*mq.BrokerSummary{
+				{BrokerId: aws.String("b-1"), BrokerName: aws.String("orders"), EngineType: aws.String(mq.EngineTypeActivemq)},
+			},
+		},
+		ListUsersError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewMqChecker)
+
+	assert.Equal(t, []AWSQuotaInfo{}, svcChecker.getMqUsersPerBrokerUsage())
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kafka"
+)
+
+type KafkaClientInterface interface {
+	ListClustersPages(input *kafka.ListClustersInput, fn func(*kafka.ListClustersOutput, bool) bool) error
+	ListConfigurationsPages(input *kafka.ListConfigurationsInput, fn func(*kafka.ListConfigurationsOutput, bool) bool) error
+}
+
+var mskDefaultQuotas = map[string]quotaDefault{
+	"Brokers per account":        {Value: 90},
+	"Brokers per cluster":        {Value: 30},
+	"Configurations per account": {Value: 100},
+}
+
+func NewMskChecker() Svcquota {
+	serviceCode := "kafka"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Brokers per account":        ServiceChecker.getMskBrokersUsage,
+		"Brokers per cluster":        ServiceChecker.getMskBrokersPerClusterUsage,
+		"Configurations per account": ServiceChecker.getMskConfigurationsUsage,
+	}
+	requiredPermissions := []string{
+		"kafka:ListClusters",
+		"kafka:ListConfigurations",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+// getMskClusters returns the provisioned clusters, ignoring the ones being
+// deleted
+func getMskClusters() (ret []*kafka.ClusterInfo, err error) {
+	ret = []*kafka.ClusterInfo{}
+	err = conf.Kafka.ListClustersPages(&kafka.ListClustersInput{}, func(p *kafka.ListClustersOutput, lastPage bool) bool {
+		for _, cluster := range p.ClusterInfoList {
+			if aws.StringValue(cluster.State) != kafka.ClusterStateDeleting {
+				ret = append(ret, cluster)
+			}
+		}
+		return true // continue paging
+	})
+	return
+}
+
+func (c ServiceChecker) getMskBrokersUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	clusters, err := getMskClusters()
+	if err != nil {
+		fmt.Printf("failed to retrieve msk clusters, %v", err)
+		return
+	}
+
+	brokers := int64(0)
+	for _, cluster := range clusters {
+		brokers += aws.Int64Value(cluster.NumberOfBrokerNodes)
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Brokers per account", mskDefaultQuotas["Brokers per account"])
+	quotaInfo.UsageValue = float64(brokers)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getMskBrokersPerClusterUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	clusters, err := getMskClusters()
+	if err != nil {
+		fmt.Printf("failed to retrieve msk clusters, %v", err)
+		return
+	}
+
+	for _, cluster := range clusters {
+		quotaInfo := c.getAppliedQuotaOrDefault("Brokers per cluster", mskDefaultQuotas["Brokers per cluster"])
+		quotaInfo.UsageValue = float64(aws.Int64Value(cluster.NumberOfBrokerNodes))
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::MSK::Cluster::%s", aws.StringValue(cluster.ClusterName))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getMskConfigurationsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	configurations := 0
+	err := conf.Kafka.ListConfigurationsPages(&kafka.ListConfigurationsInput{}, func(p *kafka.ListConfigurationsOutput, lastPage bool) bool {
+		configurations += len(p.Configurations)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve msk configurations, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Configurations per account", mskDefaultQuotas["Configurations per account"])
+	quotaInfo.UsageValue = float64(configurations)
+	ret = append(ret, quotaInfo)
+	return
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedKafkaClient struct {
+	KafkaClientInterface
+	ListClustersPagesResp        kafka.ListClustersOutput
+	ListClustersPagesError       error
+	ListConfigurationsPagesResp  kafka.ListConfigurationsOutput
+	ListConfigurationsPagesError error
+}
+
+func (m mockedKafkaClient) ListClustersPages(input *kafka.ListClustersInput, fn func(*kafka.ListClustersOutput, bool) bool) error {
+	return mockPages(m.ListClustersPagesResp, m.ListClustersPagesError, fn)
+}
+
+func (m mockedKafkaClient) ListConfigurationsPages(input *kafka.ListConfigurationsInput, fn func(*kafka.ListConfigurationsOutput, bool) bool) error {
+	return mockPages(m.ListConfigurationsPagesResp, m.ListConfigurationsPagesError, fn)
+}
+
+func TestNewMskCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewMskChecker())
+}
+
+func TestGetMskUsage(t *testing.T) {
+	conf.Kafka = mockedKafkaClient{
+		ListClustersPagesResp: kafka.ListClustersOutput{
+			ClusterInfoList: []*kafka.ClusterInfo{
+				{ClusterName: aws.String("events"), NumberOfBrokerNodes: aws.Int64(6), State: aws.String(kafka.ClusterStateActive)},
+				{ClusterName: aws.String("logs"), NumberOfBrokerNodes: aws.Int64(3), State: aws.String(kafka.ClusterStateActive)},
+				{ClusterName: aws.String("old"), NumberOfBrokerNodes: aws.Int64(9), State: aws.String(kafka.ClusterStateDeleting)},
+			},
+		},
+		ListConfigurationsPagesResp: kafka.ListConfigurationsOutput{
+			Configurations: []*kafka.Configuration{{}, {}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewMskChecker, NewQuota("kafka", "Brokers per account", float64(120), false))
+
+	brokers := svcChecker.getMskBrokersUsage()
+	assert.Len(t, brokers, 1)
+	assert.Equal(t, "kafka", brokers[0].Service)
+	assert.Equal(t, float64(120), brokers[0].QuotaValue)
+	assert.Equal(t, float64(9), brokers[0].UsageValue)
+
+	brokersPerCluster := svcChecker.getMskBrokersPerClusterUsage()
+	assert.Len(t, brokersPerCluster, 2)
+	assert.Equal(t, "AWS::MSK::Cluster::events", brokersPerCluster[0].ResourceId)
+	assert.Equal(t, float64(30), brokersPerCluster[0].QuotaValue)
+	assert.Equal(t, float64(6), brokersPerCluster[0].UsageValue)
+
+	configurations := svcChecker.getMskConfigurationsUsage()
+	assert.Len(t, configurations, 1)
+	assert.Equal(t, float64(100), configurations[0].QuotaValue)
+	assert.Equal(t, float64(2), configurations[0].UsageValue)
+}
+
+func TestGetMskUsageError(t *testing.T) {
+	conf.Kafka = mockedKafkaClient{
+		ListClustersPagesError:       errors.New("test error"),
+		ListConfigurationsPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewMskChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getMskBrokersUsage())
+	assert.Equal(t, expected, svcChecker.getMskBrokersPerClusterUsage())
+	assert.Equal(t, expected, svcChecker.getMskConfigurationsUsage())
+}
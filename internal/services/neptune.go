@@ -0,0 +1,5 @@
+package services
+
+func NewNeptuneChecker() Svcquota {
+	return newRdsFamilyChecker("neptune", "AWS::Neptune::DBCluster")
+}
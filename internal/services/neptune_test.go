@@ -0,0 +1,11 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNeptuneCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewNeptuneChecker())
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/opensearchservice"
+)
+
+type OpenSearchClientInterface interface {
+	ListDomainNames(input *opensearchservice.ListDomainNamesInput) (*opensearchservice.ListDomainNamesOutput, error)
+	DescribeDomains(input *opensearchservice.DescribeDomainsInput) (*opensearchservice.DescribeDomainsOutput, error)
+}
+
+var openSearchDefaultQuotas = map[string]quotaDefault{
+	"Domains per Region":   {Value: 100},
+	"Instances per domain": {Value: 80},
+}
+
+// DescribeDomains accepts at most 5 domain names per call
+const openSearchDescribeDomainsBatchSize = 5
+
+func NewOpenSearchChecker() Svcquota {
+	serviceCode := "es"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Domains per Region":   ServiceChecker.getOpenSearchDomainsUsage,
+		"Instances per domain": ServiceChecker.getOpenSearchInstancesPerDomainUsage,
+	}
+	requiredPermissions := []string{
+		"es:ListDomainNames",
+		"es:DescribeDomains",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func getOpenSearchDomainNames() (ret []*string, err error) {
+	ret = []*string{}
+	result, err := conf.OpenSearch.ListDomainNames(&opensearchservice.ListDomainNamesInput{})
+	if err != nil {
+		return
+	}
+	for _, d := range result.DomainNames {
+		ret = append(ret, d.DomainName)
+	}
+	return
+}
+
+func (c ServiceChecker) getOpenSearchDomainsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	domainNames, err := getOpenSearchDomainNames()
+	if err != nil {
+		fmt.Printf("failed to retrieve opensearch domains, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Domains per Region", openSearchDefaultQuotas["Domains per Region"])
+	quotaInfo.UsageValue = float64(len(domainNames))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getOpenSearchInstancesPerDomainUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	domainNames, err := getOpenSearchDomainNames()
+	if err != nil {
+		fmt.Printf("failed to retrieve opensearch domains, %v", err)
+		return
+	}
+
+	for i := 0; i < len(domainNames); i += openSearchDescribeDomainsBatchSize {
+		end := i + openSearchDescribeDomainsBatchSize
+		if end > len(domainNames) {
+			end = len(domainNames)
+		}
+		result, errDescribe := conf.OpenSearch.DescribeDomains(&opensearchservice.DescribeDomainsInput{DomainNames: domainNames[i:end]})
+		if errDescribe != nil {
+			fmt.Printf("failed to describe opensearch domains, %v", errDescribe)
+			continue
+		}
+
+		for _, domain := range result.DomainStatusList {
+			quotaInfo := c.getAppliedQuotaOrDefault("Instances per domain", openSearchDefaultQuotas["Instances per domain"])
+			if domain.ClusterConfig != nil {
+				quotaInfo.UsageValue = float64(aws.Int64Value(domain.ClusterConfig.InstanceCount))
+			}
+			quotaInfo.ResourceId = fmt.Sprintf("AWS::OpenSearchService::Domain::%s", aws.StringValue(domain.DomainName))
+			ret = append(ret, quotaInfo)
+		}
+	}
+	return
+}
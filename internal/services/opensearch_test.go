@@ -0,0 +1,90 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/opensearchservice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedOpenSearchClient struct {
+	OpenSearchClientInterface
+	ListDomainNamesResp  opensearchservice.ListDomainNamesOutput
+	ListDomainNamesError error
+	DescribeDomainsError error
+}
+
+func (m mockedOpenSearchClient) ListDomainNames(input *opensearchservice.ListDomainNamesInput) (*opensearchservice.ListDomainNamesOutput, error) {
+	return &m.ListDomainNamesResp, m.ListDomainNamesError
+}
+
+// DescribeDomains returns a domain with 3 instances for every requested name
+func (m mockedOpenSearchClient) DescribeDomains(input *opensearchservice.DescribeDomainsInput) (*opensearchservice.DescribeDomainsOutput, error) {
+	domains := []*opensearchservice.DomainStatus{}
+	for _, name := range input.DomainNames {
+		domains = append(domains, &opensearchservice.DomainStatus{
+			DomainName:    name,
+			ClusterConfig: &opensearchservice.ClusterConfig{InstanceCount: aws.Int64(3)},
+		})
+	}
+	return &opensearchservice.DescribeDomainsOutput{DomainStatusList: domains}, m.DescribeDomainsError
+}
+
+func newMockedOpenSearchDomainNames(count int) opensearchservice.ListDomainNamesOutput {
+	domainNames := []*opensearchservice.DomainInfo{}
+	for i := 0; i < count; i++ {
+		domainNames = append(domainNames, &opensearchservice.DomainInfo{DomainName: aws.String(fmt.Sprintf("domain-%d", i))})
+	}
+	return opensearchservice.ListDomainNamesOutput{DomainNames: domainNames}
+}
+
+func TestNewOpenSearchCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewOpenSearchChecker())
+}
+
+func TestGetOpenSearchDomainsUsage(t *testing.T) {
+	conf.OpenSearch = mockedOpenSearchClient{ListDomainNamesResp: newMockedOpenSearchDomainNames(2)}
+	svcChecker := newTestServiceChecker(NewOpenSearchChecker, NewQuota("es", "Domains per Region", float64(200), false))
+	actual := svcChecker.getOpenSearchDomainsUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "es", actual[0].Service)
+	assert.Equal(t, float64(200), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+}
+
+func TestGetOpenSearchDomainsUsageError(t *testing.T) {
+	conf.OpenSearch = mockedOpenSearchClient{ListDomainNamesError: errors.New("test error")}
+	svcChecker := newTestServiceChecker(NewOpenSearchChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getOpenSearchDomainsUsage())
+	assert.Equal(t, expected, svcChecker.getOpenSearchInstancesPerDomainUsage())
+}
+
+func TestGetOpenSearchInstancesPerDomainUsage(t *testing.T) {
+	// more domains than DescribeDomains accepts in a single call
+	conf.OpenSearch = mockedOpenSearchClient{ListDomainNamesResp: newMockedOpenSearchDomainNames(7)}
+	svcChecker := newTestServiceChecker(NewOpenSearchChecker)
+	actual := svcChecker.getOpenSearchInstancesPerDomainUsage()
+
+	assert.Len(t, actual, 7)
+	assert.Equal(t, "AWS::OpenSearchService::Domain::domain-6", actual[6].ResourceId)
+	assert.Equal(t, float64(80), actual[6].QuotaValue)
+	assert.Equal(t, float64(3), actual[6].UsageValue)
+}
+
+func TestGetOpenSearchInstancesPerDomainUsageError(t *testing.T) {
+	conf.OpenSearch = mockedOpenSearchClient{
+		ListDomainNamesResp:  newMockedOpenSearchDomainNames(2),
+		DescribeDomainsError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewOpenSearchChecker)
+	actual := svcChecker.getOpenSearchInstancesPerDomainUsage()
+
+	assert.Len(t, actual, 0)
+}
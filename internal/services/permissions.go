@@ -0,0 +1,199 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// accessDeniedCodes are the error codes aws services return when the principal
+// lacks a permission
+var accessDeniedCodes = map[string]bool{
+	"AccessDenied":          true,
+	"AccessDeniedException": true,
+	"AuthorizationError":    true, // sns
+	"UnauthorizedOperation": true, // ec2
+}
+
+// iamActionPrefixes maps the signing names of the services whose iam actions
+// use another prefix
+var iamActionPrefixes = map[string]string{
+	"monitoring": "cloudwatch",
+}
+
+// operationIamActions maps the operations whose iam action is not named after
+// them, when no checker declares a matching permission
+var operationIamActions = map[string]string{
+	"s3:ListBuckets": "s3:ListAllMyBuckets",
+}
+
+// httpMethodActionPrefixes are the services whose iam actions are the http
+// methods of their rest api, e.g. apigateway:GET
+var httpMethodActionPrefixes = map[string]bool{
+	"apigateway": true,
+}
+
+// apiVersionSuffix matches the api version some operation names end with, e.g.
+// cloudfront ListDistributions2020_05_31
+var apiVersionSuffix = regexp.MustCompile(`\d{4}_\d{2}_\d{2}$`)
+
+// declaredPermissions are the permissions required by the checkers created so
+// far, used to resolve the iam action of their calls
+var declaredPermissions = struct {
+	sync.Mutex
+	permissions map[string]bool
+}{permissions: map[string]bool{}}
+
+// declarePermissions records the permissions a checker requires
+func declarePermissions(permissions []string) {
+	declaredPermissions.Lock()
+	defer declaredPermissions.Unlock()
+	for _, permission := range permissions {
+		declaredPermissions.permissions[permission] = true
+	}
+}
+
+func isDeclaredPermission(permission string) bool {
+	declaredPermissions.Lock()
+	defer declaredPermissions.Unlock()
+	return declaredPermissions.permissions[permission]
+}
+
+type deniedActionsRecorder struct {
+	sync.Mutex
+	actions      map[string]bool // the iam actions denied so far
+	denials      int             // the number of denied calls, including the skipped ones
+	usageDenials int             // the number of denied calls retrieving usage, quota lookups excluded
+	skip         bool            // whether calls of denied actions fail without being sent
+}
+
+var deniedActions = &deniedActionsRecorder{actions: map[string]bool{}}
+
+// IsAccessDenied returns whether the error is due to a missing permission
+func IsAccessDenied(err error) bool {
+	var aerr awserr.Error
+	return errors.As(err, &aerr) && accessDeniedCodes[aerr.Code()]
+}
+
+// SetSkipUnauthorized sets whether calls of actions already denied fail without
+// being sent, and whether quotas whose usage hit a denied action are skipped
+func SetSkipUnauthorized(skip bool) {
+	deniedActions.Lock()
+	defer deniedActions.Unlock()
+	deniedActions.skip = skip
+}
+
+// ResetDeniedActions forgets the actions denied so far
+func ResetDeniedActions() {
+	deniedActions.Lock()
+	defer deniedActions.Unlock()
+	deniedActions.actions = map[string]bool{}
+	deniedActions.denials = 0
+	deniedActions.usageDenials = 0
+}
+
+// GetDeniedActions returns the iam actions denied so far, sorted
+func GetDeniedActions() (ret []string) {
+	deniedActions.Lock()
+	defer deniedActions.Unlock()
+	for action := range deniedActions.actions {
+		ret = append(ret, action)
+	}
+	sort.Strings(ret)
+	return
+}
+
+// GetDeniedCalls returns the number of calls denied so far, including the
+// skipped ones
+func GetDeniedCalls() int {
+	deniedActions.Lock()
+	defer deniedActions.Unlock()
+	return deniedActions.denials
+}
+
+// getDeniedUsageCalls returns the number of calls retrieving usage denied so
+// far. Denied quota lookups are left out, quotas then fall back to their
+// defaults
+func getDeniedUsageCalls() int {
+	deniedActions.Lock()
+	defer deniedActions.Unlock()
+	return deniedActions.usageDenials
+}
+
+func isSkippingUnauthorized() bool {
+	deniedActions.Lock()
+	defer deniedActions.Unlock()
+	return deniedActions.skip
+}
+
+// iamAction returns the iam action of the request, e.g. eks:ListClusters. The
+// candidate actions of the operation are matched against the permissions the
+// checkers declare, the first candidate is used if none is declared
+func iamAction(r *request.Request) string {
+	prefix := r.ClientInfo.SigningName
+	if prefix == "" {
+		prefix = strings.ToLower(r.ClientInfo.ServiceName)
+	}
+	if iamPrefix, ok := iamActionPrefixes[prefix]; ok {
+		prefix = iamPrefix
+	}
+	operation := prefix + ":" + apiVersionSuffix.ReplaceAllString(r.Operation.Name, "")
+
+	candidates := []string{}
+	if httpMethodActionPrefixes[prefix] && r.Operation.HTTPMethod != "" {
+		candidates = append(candidates, prefix+":"+r.Operation.HTTPMethod)
+	}
+	if action, ok := operationIamActions[operation]; ok {
+		candidates = append(candidates, action)
+	}
+	candidates = append(candidates, operation)
+	for _, candidate := range candidates {
+		if isDeclaredPermission(candidate) {
+			return candidate
+		}
+	}
+	return candidates[0]
+}
+
+// recordAccessDenied records the action of the completed request if it was
+// denied. Each missing permission is reported once
+func recordAccessDenied(r *request.Request) {
+	if !IsAccessDenied(r.Error) {
+		return
+	}
+	action := iamAction(r)
+	deniedActions.Lock()
+	defer deniedActions.Unlock()
+	if !deniedActions.actions[action] {
+		fmt.Printf("access denied, missing permission %s\n", action)
+	}
+	deniedActions.actions[action] = true
+	deniedActions.denials++
+	if !strings.HasPrefix(action, "servicequotas:") {
+		deniedActions.usageDenials++
+	}
+}
+
+// skipDeniedAction fails the request without sending it if its action was
+// already denied and unauthorized calls are skipped
+func skipDeniedAction(r *request.Request) {
+	action := iamAction(r)
+	deniedActions.Lock()
+	defer deniedActions.Unlock()
+	if deniedActions.skip && deniedActions.actions[action] {
+		r.Error = awserr.New("AccessDenied", fmt.Sprintf("%s was denied, skipping", action), nil)
+	}
+}
+
+// addAccessDeniedHandlers adds the handlers recording and skipping denied
+// actions to the given handlers, shared by every client of a session
+func addAccessDeniedHandlers(handlers *request.Handlers) {
+	handlers.Validate.PushFrontNamed(request.NamedHandler{Name: "awslimitchecker.SkipDeniedAction", Fn: skipDeniedAction})
+	handlers.Complete.PushBackNamed(request.NamedHandler{Name: "awslimitchecker.RecordAccessDenied", Fn: recordAccessDenied})
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDeniedSession returns a session whose requests are all denied, without
+// being sent
+func newDeniedSession(t *testing.T) (sess *session.Session, sent *int) {
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String("eu-west-1"),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+	})
+	require.Nil(t, err)
+	addAccessDeniedHandlers(&sess.Handlers)
+
+	sent = new(int)
+	sess.Handlers.Send.Clear()
+	sess.Handlers.Send.PushBack(func(r *request.Request) {
+		*sent++
+		r.Error = awserr.New("AccessDeniedException", "denied", nil)
+	})
+	sess.Handlers.UnmarshalError.Clear()
+	t.Cleanup(func() {
+		ResetDeniedActions()
+		SetSkipUnauthorized(false)
+	})
+	return
+}
+
+func TestIsAccessDenied(t *testing.T) {
+	assert.True(t, IsAccessDenied(awserr.New("AccessDeniedException", "denied", nil)))
+	assert.True(t, IsAccessDenied(awserr.New("UnauthorizedOperation", "denied", nil)))
+	assert.True(t, IsAccessDenied(fmt.Errorf("wrapped, %w", awserr.New("AccessDenied", "denied", nil))))
+	assert.False(t, IsAccessDenied(awserr.New("ThrottlingException", "slow down", nil)))
+	assert.False(t, IsAccessDenied(errors.New("test error")))
+	assert.False(t, IsAccessDenied(nil))
+}
+
+func TestRecordAccessDenied(t *testing.T) {
+	sess, sent := newDeniedSession(t)
+
+	_, err := eks.New(sess).ListClusters(&eks.ListClustersInput{})
+	assert.True(t, IsAccessDenied(err))
+	_, err = eks.New(sess).ListClusters(&eks.ListClustersInput{})
+	assert.True(t, IsAccessDenied(err))
+	_, err = cloudwatch.New(sess).DescribeAlarms(&cloudwatch.DescribeAlarmsInput{})
+	assert.True(t, IsAccessDenied(err))
+
+	assert.Equal(t, []string{"cloudwatch:DescribeAlarms", "eks:ListClusters"}, GetDeniedActions())
+	assert.Equal(t, 3, *sent)
+	assert.Equal(t, 3, GetDeniedCalls())
+
+	ResetDeniedActions()
+	assert.Empty(t, GetDeniedActions())
+	assert.Equal(t, 0, GetDeniedCalls())
+}
+
+func TestRecordAccessDeniedIamActions(t *testing.T) {
+	sess, _ := newDeniedSession(t)
+
+	_, _ = cloudfront.New(sess).ListDistributions(&cloudfront.ListDistributionsInput{})
+	_, _ = apigateway.New(sess).GetRestApis(&apigateway.GetRestApisInput{})
+	_, _ = s3.New(sess).ListBuckets(&s3.ListBucketsInput{})
+
+	assert.Equal(t, []string{"apigateway:GET", "cloudfront:ListDistributions", "s3:ListAllMyBuckets"}, GetDeniedActions())
+}
+
+func TestIamActionUndeclared(t *testing.T) {
+	sess, _ := newDeniedSession(t)
+
+	// operations without declared permission are named after the operation
+	req, _ := s3.New(sess).ListObjectsV2Request(&s3.ListObjectsV2Input{})
+	assert.Equal(t, "s3:ListObjectsV2", iamAction(req))
+	req, _ = cloudfront.New(sess).ListFunctionsRequest(&cloudfront.ListFunctionsInput{})
+	assert.Equal(t, "cloudfront:ListFunctions", iamAction(req))
+}
+
+func TestSkipDeniedAction(t *testing.T) {
+	sess, sent := newDeniedSession(t)
+	SetSkipUnauthorized(true)
+
+	_, err := eks.New(sess).ListClusters(&eks.ListClustersInput{})
+	assert.True(t, IsAccessDenied(err))
+	// the action is known to be denied, it is not sent again
+	_, err = eks.New(sess).ListClusters(&eks.ListClustersInput{})
+	assert.True(t, IsAccessDenied(err))
+
+	assert.Equal(t, 1, *sent)
+	assert.Equal(t, []string{"eks:ListClusters"}, GetDeniedActions())
+	assert.Equal(t, 2, GetDeniedCalls())
+}
+
+func TestGetUsageSkipUnauthorized(t *testing.T) {
+	sess, _ := newDeniedSession(t)
+	denied := func(c ServiceChecker) (ret []AWSQuotaInfo) {
+		_, _ = eks.New(sess).ListClusters(&eks.ListClustersInput{})
+		return []AWSQuotaInfo{{Service: c.ServiceCode, QuotaName: "denied"}}
+	}
+	allowed := func(c ServiceChecker) (ret []AWSQuotaInfo) {
+		return []AWSQuotaInfo{{Service: c.ServiceCode, QuotaName: "allowed"}}
+	}
+	testChecker := NewTestChecker(map[string]func(ServiceChecker) (ret []AWSQuotaInfo){"denied": denied, "allowed": allowed})
+
+	assert.Len(t, testChecker.GetUsage(), 2)
+
+	SetSkipUnauthorized(true)
+	actual := testChecker.GetUsage()
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "allowed", actual[0].QuotaName)
+}
+
+func TestGetUsageSkipUnauthorizedQuotaLookup(t *testing.T) {
+	sess, _ := newDeniedSession(t)
+	lookupDenied := func(c ServiceChecker) (ret []AWSQuotaInfo) {
+		_, _ = servicequotas.New(sess).ListServiceQuotas(&servicequotas.ListServiceQuotasInput{ServiceCode: aws.String(c.ServiceCode)})
+		return []AWSQuotaInfo{{Service: c.ServiceCode, QuotaName: "lookupDenied"}}
+	}
+	testChecker := NewTestChecker(map[string]func(ServiceChecker) (ret []AWSQuotaInfo){"lookupDenied": lookupDenied})
+
+	// the usage call itself was allowed, the quota is reported
+	SetSkipUnauthorized(true)
+	actual := testChecker.GetUsage()
+	assert.Len(t, actual, 1)
+	assert.Equal(t, []string{"servicequotas:ListServiceQuotas"}, GetDeniedActions())
+}
@@ -9,6 +9,7 @@ import (
 
 type RdsClientInterface interface {
 	DescribeAccountAttributes(input *rds.DescribeAccountAttributesInput) (*rds.DescribeAccountAttributesOutput, error)
+	DescribeDBClustersPages(input *rds.DescribeDBClustersInput, fn func(*rds.DescribeDBClustersOutput, bool) bool) error
 }
 
 func NewRdsChecker() Svcquota {
@@ -43,32 +44,93 @@ func (c ServiceChecker) getRdsAccountQuotas() (ret map[string]*rds.AccountQuota)
 	return
 }
 
-func (c ServiceChecker) getRdsInstancesCountUsage() (ret []AWSQuotaInfo) {
+// getRdsAccountAttributeUsage builds the quota info for the given rds account
+// attribute, with the maximum reported by rds when servicequotas does not
+// return the quota. DocumentDB and Neptune are managed through rds and share
+// its account attributes: the DB clusters and DB instances quotas are only
+// reported under rds, and count their clusters and instances too
+func (c ServiceChecker) getRdsAccountAttributeUsage(quotaName string, attributeName string) (ret []AWSQuotaInfo) {
 	ret = []AWSQuotaInfo{}
-	quotaInfo := c.GetAllAppliedQuotas()["DB instances"]
-	if val, ok := c.getRdsAccountQuotas()["DBInstances"]; ok {
-		quotaInfo.UsageValue = float64(*val.Used)
+	attribute, ok := c.getRdsAccountQuotas()[attributeName]
+	_, known := c.GetAllAppliedQuotas()[quotaName]
+
+	quotaInfo := c.getAppliedQuotaOrDefault(quotaName, quotaDefault{})
+	if ok {
+		if known {
+			// servicequotas' value is kept, the one reported by rds is only
+			// recorded so that differences show up
+			quotaInfo.ServiceValue = float64(aws.Int64Value(attribute.Max))
+		} else {
+			quotaInfo = withServiceValue(quotaInfo, float64(aws.Int64Value(attribute.Max)))
+		}
+		quotaInfo.UsageValue = float64(aws.Int64Value(attribute.Used))
 	}
 	ret = append(ret, quotaInfo)
 	return
 }
 
-func (c ServiceChecker) getRdsClusterCountUsage() (ret []AWSQuotaInfo) {
-	ret = []AWSQuotaInfo{}
-	quotaInfo := c.GetAllAppliedQuotas()["DB clusters"]
-	if val, ok := c.getRdsAccountQuotas()["DBClusters"]; ok {
-		quotaInfo.UsageValue = float64(*val.Used)
+// getRdsEngineClusters returns the clusters of the given engine. DescribeDBClusters
+// returns the clusters of every engine built on rds unless filtered
+func getRdsEngineClusters(engine string) (ret []*rds.DBCluster, err error) {
+	ret = []*rds.DBCluster{}
+	input := &rds.DescribeDBClustersInput{
+		Filters: []*rds.Filter{{Name: aws.String("engine"), Values: aws.StringSlice([]string{engine})}},
 	}
-	ret = append(ret, quotaInfo)
+	err = conf.Rds.DescribeDBClustersPages(input, func(p *rds.DescribeDBClustersOutput, lastPage bool) bool {
+		for _, cluster := range p.DBClusters {
+			if aws.StringValue(cluster.Engine) == engine {
+				ret = append(ret, cluster)
+			}
+		}
+		return true // continue paging
+	})
 	return
 }
 
-func (c ServiceChecker) getRdsReservedDbCountUsage() (ret []AWSQuotaInfo) {
+var rdsFamilyDefaultQuotas = map[string]quotaDefault{
+	"Instances per cluster": {Value: 16}, // one primary and up to 15 replicas
+}
+
+// newRdsFamilyChecker returns the checker of a service managed through the rds
+// api (documentdb, neptune), whose service code is also its rds engine. The
+// clusters and instances quotas they share with rds are reported by the rds
+// checker
+func newRdsFamilyChecker(serviceCode string, clusterResourceType string) Svcquota {
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Instances per cluster": func(c ServiceChecker) []AWSQuotaInfo {
+			return c.getRdsFamilyInstancesPerClusterUsage(clusterResourceType)
+		},
+	}
+	requiredPermissions := []string{"rds:DescribeDBClusters"}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func (c ServiceChecker) getRdsFamilyInstancesPerClusterUsage(clusterResourceType string) (ret []AWSQuotaInfo) {
 	ret = []AWSQuotaInfo{}
-	quotaInfo := c.GetAllAppliedQuotas()["Reserved DB instances"]
-	if val, ok := c.getRdsAccountQuotas()["ReservedDBInstances"]; ok {
-		quotaInfo.UsageValue = float64(*val.Used)
+	clusters, err := getRdsEngineClusters(c.ServiceCode)
+	if err != nil {
+		fmt.Printf("failed to retrieve %s clusters, %v", c.ServiceCode, err)
+		return
+	}
+
+	for _, cluster := range clusters {
+		quotaInfo := c.getAppliedQuotaOrDefault("Instances per cluster", rdsFamilyDefaultQuotas["Instances per cluster"])
+		quotaInfo.UsageValue = float64(len(cluster.DBClusterMembers))
+		quotaInfo.ResourceId = fmt.Sprintf("%s::%s", clusterResourceType, aws.StringValue(cluster.DBClusterIdentifier))
+		ret = append(ret, quotaInfo)
 	}
-	ret = append(ret, quotaInfo)
 	return
 }
+
+func (c ServiceChecker) getRdsInstancesCountUsage() (ret []AWSQuotaInfo) {
+	return c.getRdsAccountAttributeUsage("DB instances", "DBInstances")
+}
+
+func (c ServiceChecker) getRdsClusterCountUsage() (ret []AWSQuotaInfo) {
+	return c.getRdsAccountAttributeUsage("DB clusters", "DBClusters")
+}
+
+func (c ServiceChecker) getRdsReservedDbCountUsage() (ret []AWSQuotaInfo) {
+	return c.getRdsAccountAttributeUsage("Reserved DB instances", "ReservedDBInstances")
+}
@@ -15,12 +15,19 @@ type mockedRdsClient struct {
 	RdsClientInterface
 	DescribeAccountAttributesResp  rds.DescribeAccountAttributesOutput
 	DescribeAccountAttributesError error
+	DescribeDBClustersPagesResp    rds.DescribeDBClustersOutput
+	DescribeDBClustersPagesError   error
 }
 
 func (m mockedRdsClient) DescribeAccountAttributes(input *rds.DescribeAccountAttributesInput) (*rds.DescribeAccountAttributesOutput, error) {
 	return &m.DescribeAccountAttributesResp, m.DescribeAccountAttributesError
 }
 
+func (m mockedRdsClient) DescribeDBClustersPages(input *rds.DescribeDBClustersInput, fn func(*rds.DescribeDBClustersOutput, bool) bool) error {
+	fn(&m.DescribeDBClustersPagesResp, false)
+	return m.DescribeDBClustersPagesError
+}
+
 func TestNewRdsCheckerImpl(t *testing.T) {
 	require.Implements(t, (*Svcquota)(nil), NewRdsChecker())
 }
@@ -85,6 +92,8 @@ func TestGetRdsInstancesCountUsage(t *testing.T) {
 	quota := actual[0]
 	assert.Equal(t, "rds", quota.Service)
 	assert.Equal(t, float64(20), quota.QuotaValue)
+	assert.Equal(t, float64(10), quota.ServiceValue)
+	assert.Equal(t, QuotaSourceApplied, quota.Source)
 	assert.Equal(t, float64(1), quota.UsageValue)
 	t.Cleanup(func() { rdsAccountQuota = map[string]*rds.AccountQuota{} })
 }
@@ -132,3 +141,98 @@ func TestGetRdsReservedDbCountUsage(t *testing.T) {
 	assert.Equal(t, float64(10), quota.UsageValue)
 	t.Cleanup(func() { rdsAccountQuota = map[string]*rds.AccountQuota{} })
 }
+
+func TestGetRdsAccountAttributeUsageDefault(t *testing.T) {
+	mockedDescribeAccountAttributesOutput := rds.DescribeAccountAttributesOutput{
+		AccountQuotas: []*rds.AccountQuota{{AccountQuotaName: aws.String("DBClusters"), Max: aws.Int64(40), Used: aws.Int64(3)}},
+	}
+	conf.Rds = mockedRdsClient{DescribeAccountAttributesResp: mockedDescribeAccountAttributesOutput, DescribeAccountAttributesError: nil}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	rdsChecker := NewRdsChecker()
+	svcChecker := rdsChecker.(*ServiceChecker)
+	actual := svcChecker.getRdsAccountAttributeUsage("DB clusters", "DBClusters")
+
+	assert.Len(t, actual, 1)
+	quota := actual[0]
+	assert.Equal(t, "rds", quota.Service)
+	assert.Equal(t, "DB clusters", quota.QuotaName)
+	assert.Equal(t, float64(40), quota.QuotaValue)
+	assert.Equal(t, QuotaSourceServiceAPI, quota.Source)
+	assert.Equal(t, float64(3), quota.UsageValue)
+	t.Cleanup(func() { rdsAccountQuota = map[string]*rds.AccountQuota{} })
+}
+
+func TestGetRdsEngineClusters(t *testing.T) {
+	conf.Rds = mockedRdsClient{
+		DescribeDBClustersPagesResp: rds.DescribeDBClustersOutput{
+			DBClusters: []*rds.DBCluster{
+				{DBClusterIdentifier: aws.String("foo"), Engine: aws.String("docdb")},
+				{DBClusterIdentifier: aws.String("bar"), Engine: aws.String("aurora-mysql")},
+			},
+		},
+	}
+
+	actual, err := getRdsEngineClusters("docdb")
+	assert.Nil(t, err)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "foo", aws.StringValue(actual[0].DBClusterIdentifier))
+}
+
+// rdsFamilyCheckers are the checkers built with newRdsFamilyChecker, with the
+// resource type of their clusters
+var rdsFamilyCheckers = map[string]struct {
+	checker             func() Svcquota
+	clusterResourceType string
+}{
+	"docdb":   {NewDocDbChecker, "AWS::DocDB::DBCluster"},
+	"neptune": {NewNeptuneChecker, "AWS::Neptune::DBCluster"},
+}
+
+func TestRdsFamilyCheckersSkipSharedQuotas(t *testing.T) {
+	// clusters and instances are counted by the rds account attributes, for
+	// every engine, and only reported by the rds checker
+	for serviceCode, family := range rdsFamilyCheckers {
+		svcChecker := family.checker().(*ServiceChecker)
+		assert.NotContains(t, svcChecker.SupportedQuotas, "DB clusters", serviceCode)
+		assert.NotContains(t, svcChecker.SupportedQuotas, "DB instances", serviceCode)
+		assert.NotContains(t, svcChecker.GetRequiredPermissions(), "rds:DescribeAccountAttributes", serviceCode)
+	}
+}
+
+func TestGetRdsFamilyInstancesPerClusterUsage(t *testing.T) {
+	for serviceCode, family := range rdsFamilyCheckers {
+		t.Run(serviceCode, func(t *testing.T) {
+			conf.Rds = mockedRdsClient{
+				DescribeDBClustersPagesResp: rds.DescribeDBClustersOutput{
+					DBClusters: []*rds.DBCluster{
+						{
+							DBClusterIdentifier: aws.String("foo"),
+							Engine:              aws.String(serviceCode),
+							DBClusterMembers:    []*rds.DBClusterMember{{}, {}, {}},
+						},
+						{DBClusterIdentifier: aws.String("bar"), Engine: aws.String("aurora-postgresql")},
+					},
+				},
+			}
+			conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+			svcChecker := family.checker().(*ServiceChecker)
+			actual := svcChecker.SupportedQuotas["Instances per cluster"](*svcChecker)
+
+			assert.Len(t, actual, 1)
+			assert.Equal(t, family.clusterResourceType+"::foo", actual[0].ResourceId)
+			assert.Equal(t, float64(16), actual[0].QuotaValue)
+			assert.Equal(t, float64(3), actual[0].UsageValue)
+		})
+	}
+}
+
+func TestGetRdsFamilyInstancesPerClusterUsageError(t *testing.T) {
+	conf.Rds = mockedRdsClient{DescribeDBClustersPagesError: errors.New("test error")}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{}, nil)
+
+	svcChecker := NewDocDbChecker().(*ServiceChecker)
+	actual := svcChecker.getRdsFamilyInstancesPerClusterUsage("AWS::DocDB::DBCluster")
+
+	assert.Equal(t, []AWSQuotaInfo{}, actual)
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/redshift"
+)
+
+type RedshiftClientInterface interface {
+	DescribeClustersPages(input *redshift.DescribeClustersInput, fn func(*redshift.DescribeClustersOutput, bool) bool) error
+	DescribeClusterSnapshotsPages(input *redshift.DescribeClusterSnapshotsInput, fn func(*redshift.DescribeClusterSnapshotsOutput, bool) bool) error
+	DescribeClusterSubnetGroupsPages(input *redshift.DescribeClusterSubnetGroupsInput, fn func(*redshift.DescribeClusterSubnetGroupsOutput, bool) bool) error
+	DescribeClusterParameterGroupsPages(input *redshift.DescribeClusterParameterGroupsInput, fn func(*redshift.DescribeClusterParameterGroupsOutput, bool) bool) error
+}
+
+var redshiftDefaultQuotas = map[string]quotaDefault{
+	"Nodes":             {Value: 200},
+	"Nodes per cluster": {Value: 128},
+	"Manual snapshots":  {Value: 20},
+	"Subnet groups":     {Value: 20},
+	"Parameter groups":  {Value: 20},
+}
+
+func NewRedshiftChecker() Svcquota {
+	serviceCode := "redshift"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Nodes":             ServiceChecker.getRedshiftNodesUsage,
+		"Nodes per cluster": ServiceChecker.getRedshiftNodesPerClusterUsage,
+		"Manual snapshots":  ServiceChecker.getRedshiftManualSnapshotsUsage,
+		"Subnet groups":     ServiceChecker.getRedshiftSubnetGroupsUsage,
+		"Parameter groups":  ServiceChecker.getRedshiftParameterGroupsUsage,
+	}
+	requiredPermissions := []string{
+		"redshift:DescribeClusters",
+		"redshift:DescribeClusterSnapshots",
+		"redshift:DescribeClusterSubnetGroups",
+		"redshift:DescribeClusterParameterGroups",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func getRedshiftClusters() (ret []*redshift.Cluster, err error) {
+	ret = []*redshift.Cluster{}
+	err = conf.Redshift.DescribeClustersPages(&redshift.DescribeClustersInput{}, func(p *redshift.DescribeClustersOutput, lastPage bool) bool {
+		ret = append(ret, p.Clusters...)
+		return true // continue paging
+	})
+	return
+}
+
+func (c ServiceChecker) getRedshiftNodesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	clusters, err := getRedshiftClusters()
+	if err != nil {
+		fmt.Printf("failed to retrieve redshift clusters, %v", err)
+		return
+	}
+
+	nodes := int64(0)
+	for _, cluster := range clusters {
+		nodes += aws.Int64Value(cluster.NumberOfNodes)
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Nodes", redshiftDefaultQuotas["Nodes"])
+	quotaInfo.UsageValue = float64(nodes)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getRedshiftNodesPerClusterUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	clusters, err := getRedshiftClusters()
+	if err != nil {
+		fmt.Printf("failed to retrieve redshift clusters, %v", err)
+		return
+	}
+
+	for _, cluster := range clusters {
+		quotaInfo := c.getAppliedQuotaOrDefault("Nodes per cluster", redshiftDefaultQuotas["Nodes per cluster"])
+		quotaInfo.UsageValue = float64(aws.Int64Value(cluster.NumberOfNodes))
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::Redshift::Cluster::%s", aws.StringValue(cluster.ClusterIdentifier))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getRedshiftManualSnapshotsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	snapshots := 0
+	input := &redshift.DescribeClusterSnapshotsInput{SnapshotType: aws.String("manual")}
+	err := conf.Redshift.DescribeClusterSnapshotsPages(input, func(p *redshift.DescribeClusterSnapshotsOutput, lastPage bool) bool {
+		for _, s := range p.Snapshots {
+			if aws.StringValue(s.SnapshotType) == "manual" {
+				snapshots++
+			}
+		}
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve redshift snapshots, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Manual snapshots", redshiftDefaultQuotas["Manual snapshots"])
+	quotaInfo.UsageValue = float64(snapshots)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getRedshiftSubnetGroupsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	subnetGroups := 0
+	err := conf.Redshift.DescribeClusterSubnetGroupsPages(&redshift.DescribeClusterSubnetGroupsInput{}, func(p *redshift.DescribeClusterSubnetGroupsOutput, lastPage bool) bool {
+		subnetGroups += len(p.ClusterSubnetGroups)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve redshift subnet groups, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Subnet groups", redshiftDefaultQuotas["Subnet groups"])
+	quotaInfo.UsageValue = float64(subnetGroups)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getRedshiftParameterGroupsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	parameterGroups := 0
+	err := conf.Redshift.DescribeClusterParameterGroupsPages(&redshift.DescribeClusterParameterGroupsInput{}, func(p *redshift.DescribeClusterParameterGroupsOutput, lastPage bool) bool {
+		for _, g := range p.ParameterGroups {
+			// default parameter groups do not count against the quota
+			if !strings.HasPrefix(aws.StringValue(g.ParameterGroupName), "default.") {
+				parameterGroups++
+			}
+		}
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve redshift parameter groups, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Parameter groups", redshiftDefaultQuotas["Parameter groups"])
+	quotaInfo.UsageValue = float64(parameterGroups)
+	ret = append(ret, quotaInfo)
+	return
+}
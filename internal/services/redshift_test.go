@@ -0,0 +1,126 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/redshift"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedRedshiftClient struct {
+	RedshiftClientInterface
+	DescribeClustersPagesResp                redshift.DescribeClustersOutput
+	DescribeClustersPagesError               error
+	DescribeClusterSnapshotsPagesResp        redshift.DescribeClusterSnapshotsOutput
+	DescribeClusterSnapshotsPagesError       error
+	DescribeClusterSubnetGroupsPagesResp     redshift.DescribeClusterSubnetGroupsOutput
+	DescribeClusterSubnetGroupsPagesError    error
+	DescribeClusterParameterGroupsPagesResp  redshift.DescribeClusterParameterGroupsOutput
+	DescribeClusterParameterGroupsPagesError error
+}
+
+func (m mockedRedshiftClient) DescribeClustersPages(input *redshift.DescribeClustersInput, fn func(*redshift.DescribeClustersOutput, bool) bool) error {
+	return mockPages(m.DescribeClustersPagesResp, m.DescribeClustersPagesError, fn)
+}
+
+func (m mockedRedshiftClient) DescribeClusterSnapshotsPages(input *redshift.DescribeClusterSnapshotsInput, fn func(*redshift.DescribeClusterSnapshotsOutput, bool) bool) error {
+	return mockPages(m.DescribeClusterSnapshotsPagesResp, m.DescribeClusterSnapshotsPagesError, fn)
+}
+
+func (m mockedRedshiftClient) DescribeClusterSubnetGroupsPages(input *redshift.DescribeClusterSubnetGroupsInput, fn func(*redshift.DescribeClusterSubnetGroupsOutput, bool) bool) error {
+	return mockPages(m.DescribeClusterSubnetGroupsPagesResp, m.DescribeClusterSubnetGroupsPagesError, fn)
+}
+
+func (m mockedRedshiftClient) DescribeClusterParameterGroupsPages(input *redshift.DescribeClusterParameterGroupsInput, fn func(*redshift.DescribeClusterParameterGroupsOutput, bool) bool) error {
+	return mockPages(m.DescribeClusterParameterGroupsPagesResp, m.DescribeClusterParameterGroupsPagesError, fn)
+}
+
+var mockedRedshiftClusters = redshift.DescribeClustersOutput{
+	Clusters: []*redshift.Cluster{
+		{ClusterIdentifier: aws.String("foo"), NumberOfNodes: aws.Int64(4)},
+		{ClusterIdentifier: aws.String("bar"), NumberOfNodes: aws.Int64(2)},
+	},
+}
+
+func TestNewRedshiftCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewRedshiftChecker())
+}
+
+func TestGetRedshiftNodesUsage(t *testing.T) {
+	conf.Redshift = mockedRedshiftClient{DescribeClustersPagesResp: mockedRedshiftClusters}
+	svcChecker := newTestServiceChecker(NewRedshiftChecker, NewQuota("redshift", "Nodes", float64(300), false))
+
+	nodes := svcChecker.getRedshiftNodesUsage()
+	assert.Len(t, nodes, 1)
+	assert.Equal(t, "redshift", nodes[0].Service)
+	assert.Equal(t, float64(300), nodes[0].QuotaValue)
+	assert.Equal(t, float64(6), nodes[0].UsageValue)
+
+	nodesPerCluster := svcChecker.getRedshiftNodesPerClusterUsage()
+	assert.Len(t, nodesPerCluster, 2)
+	assert.Equal(t, "AWS::Redshift::Cluster::foo", nodesPerCluster[0].ResourceId)
+	assert.Equal(t, float64(128), nodesPerCluster[0].QuotaValue)
+	assert.Equal(t, float64(4), nodesPerCluster[0].UsageValue)
+}
+
+func TestGetRedshiftNodesUsageError(t *testing.T) {
+	conf.Redshift = mockedRedshiftClient{DescribeClustersPagesError: errors.New("test error")}
+	svcChecker := newTestServiceChecker(NewRedshiftChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getRedshiftNodesUsage())
+	assert.Equal(t, expected, svcChecker.getRedshiftNodesPerClusterUsage())
+}
+
+func TestGetRedshiftGroupsAndSnapshotsUsage(t *testing.T) {
+	conf.Redshift = mockedRedshiftClient{
+		DescribeClusterSnapshotsPagesResp: redshift.DescribeClusterSnapshotsOutput{
+			Snapshots: []*redshift.Snapshot{
+				{SnapshotType: aws.String("manual")},
+				{SnapshotType: aws.String("automated")},
+			},
+		},
+		DescribeClusterSubnetGroupsPagesResp: redshift.DescribeClusterSubnetGroupsOutput{
+			ClusterSubnetGroups: []*redshift.ClusterSubnetGroup{{}, {}},
+		},
+		DescribeClusterParameterGroupsPagesResp: redshift.DescribeClusterParameterGroupsOutput{
+			ParameterGroups: []*redshift.ClusterParameterGroup{
+				{ParameterGroupName: aws.String("default.redshift-1.0")},
+				{ParameterGroupName: aws.String("foo")},
+				{ParameterGroupName: aws.String("bar")},
+				{ParameterGroupName: aws.String("baz")},
+			},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewRedshiftChecker)
+
+	snapshots := svcChecker.getRedshiftManualSnapshotsUsage()
+	assert.Len(t, snapshots, 1)
+	assert.Equal(t, float64(20), snapshots[0].QuotaValue)
+	assert.Equal(t, float64(1), snapshots[0].UsageValue)
+
+	subnetGroups := svcChecker.getRedshiftSubnetGroupsUsage()
+	assert.Len(t, subnetGroups, 1)
+	assert.Equal(t, float64(2), subnetGroups[0].UsageValue)
+
+	parameterGroups := svcChecker.getRedshiftParameterGroupsUsage()
+	assert.Len(t, parameterGroups, 1)
+	assert.Equal(t, float64(3), parameterGroups[0].UsageValue)
+}
+
+func TestGetRedshiftGroupsAndSnapshotsUsageError(t *testing.T) {
+	conf.Redshift = mockedRedshiftClient{
+		DescribeClusterSnapshotsPagesError:       errors.New("test error"),
+		DescribeClusterSubnetGroupsPagesError:    errors.New("test error"),
+		DescribeClusterParameterGroupsPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewRedshiftChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getRedshiftManualSnapshotsUsage())
+	assert.Equal(t, expected, svcChecker.getRedshiftSubnetGroupsUsage())
+	assert.Equal(t, expected, svcChecker.getRedshiftParameterGroupsUsage())
+}
@@ -23,7 +23,7 @@ func NewS3Checker() Svcquota {
 func (c ServiceChecker) getS3BucketUsage() (ret []AWSQuotaInfo) {
 	ret = []AWSQuotaInfo{}
 	result, err := conf.S3.ListBuckets(nil)
-	quota := c.GetAllAppliedQuotas()["Buckets"]
+	quota := c.getAppliedQuotaOrDefault("Buckets", quotaDefault{Code: "L-DC2B2D3D"})
 	if err != nil {
 		fmt.Printf("Unable to list buckets, %v", err)
 		return
@@ -55,6 +55,7 @@ func TestGetS3BucketUsageError(t *testing.T) {
 			NewQuota("s3", "Buckets", float64(300), false),
 		},
 	}
+	resetServiceQuotas()
 	conf.ServiceQuotas = mockedScvQuotaClient{
 		ListServiceQuotasOutputResp: mockedSvcQuotaOutput,
 	}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+type SecretsManagerClientInterface interface {
+	ListSecretsPages(input *secretsmanager.ListSecretsInput, fn func(*secretsmanager.ListSecretsOutput, bool) bool) error
+	ListSecretVersionIdsPages(input *secretsmanager.ListSecretVersionIdsInput, fn func(*secretsmanager.ListSecretVersionIdsOutput, bool) bool) error
+}
+
+var secretsManagerDefaultQuotas = map[string]quotaDefault{
+	"Secrets per Region":  {Value: 500000},
+	"Versions per secret": {Value: 100},
+}
+
+func NewSecretsManagerChecker() Svcquota {
+	serviceCode := "secretsmanager"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Secrets per Region":  ServiceChecker.getSecretsManagerSecretsUsage,
+		"Versions per secret": ServiceChecker.getSecretsManagerVersionsPerSecretUsage,
+	}
+	requiredPermissions := []string{
+		"secretsmanager:ListSecrets",
+		"secretsmanager:ListSecretVersionIds",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func getSecretsManagerSecrets() (ret []*secretsmanager.SecretListEntry, err error) {
+	ret = []*secretsmanager.SecretListEntry{}
+	err = conf.SecretsManager.ListSecretsPages(&secretsmanager.ListSecretsInput{}, func(p *secretsmanager.ListSecretsOutput, lastPage bool) bool {
+		ret = append(ret, p.SecretList...)
+		return true // continue paging
+	})
+	return
+}
+
+func (c ServiceChecker) getSecretsManagerSecretsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	secrets, err := getSecretsManagerSecrets()
+	if err != nil {
+		fmt.Printf("failed to retrieve secrets, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Secrets per Region", secretsManagerDefaultQuotas["Secrets per Region"])
+	quotaInfo.UsageValue = float64(len(secrets))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getSecretsManagerVersionsPerSecretUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	secrets, err := getSecretsManagerSecrets()
+	if err != nil {
+		fmt.Printf("failed to retrieve secrets, %v", err)
+		return
+	}
+
+	for _, s := range secrets {
+		quotaInfo := c.getAppliedQuotaOrDefault("Versions per secret", secretsManagerDefaultQuotas["Versions per secret"])
+		versions := 0
+		// deprecated versions (without staging labels) count until secrets
+		// manager removes them
+		input := &secretsmanager.ListSecretVersionIdsInput{SecretId: s.ARN, IncludeDeprecated: aws.Bool(true)}
+		errVersions := conf.SecretsManager.ListSecretVersionIdsPages(input, func(p *secretsmanager.ListSecretVersionIdsOutput, lastPage bool) bool {
+			versions += len(p.Versions)
+			return true // continue paging
+		})
+		if errVersions != nil {
+			fmt.Printf("failed to retrieve versions for secret %s, %v", aws.StringValue(s.Name), errVersions)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(versions)
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::SecretsManager::Secret::%s", aws.StringValue(s.Name))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedSecretsManagerClient struct {
+	SecretsManagerClientInterface
+	ListSecretsPagesResp           secretsmanager.ListSecretsOutput
+	ListSecretsPagesError          error
+	ListSecretVersionIdsPagesResp  secretsmanager.ListSecretVersionIdsOutput
+	ListSecretVersionIdsPagesError error
+}
+
+func (m mockedSecretsManagerClient) ListSecretsPages(input *secretsmanager.ListSecretsInput, fn func(*secretsmanager.ListSecretsOutput, bool) bool) error {
+	return mockPages(m.ListSecretsPagesResp, m.ListSecretsPagesError, fn)
+}
+
+func (m mockedSecretsManagerClient) ListSecretVersionIdsPages(input *secretsmanager.ListSecretVersionIdsInput, fn func(*secretsmanager.ListSecretVersionIdsOutput, bool) bool) error {
+	return mockPages(m.ListSecretVersionIdsPagesResp, m.ListSecretVersionIdsPagesError, fn)
+}
+
+var mockedSecrets = secretsmanager.ListSecretsOutput{
+	SecretList: []*secretsmanager.SecretListEntry{
+		{Name: aws.String("foo"), ARN: aws.String("arn:foo")},
+		{Name: aws.String("bar"), ARN: aws.String("arn:bar")},
+	},
+}
+
+func TestNewSecretsManagerCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewSecretsManagerChecker())
+}
+
+func TestGetSecretsManagerSecretsUsage(t *testing.T) {
+	conf.SecretsManager = mockedSecretsManagerClient{ListSecretsPagesResp: mockedSecrets}
+	svcChecker := newTestServiceChecker(NewSecretsManagerChecker, NewQuota("secretsmanager", "Secrets per Region", float64(1000), false))
+	actual := svcChecker.getSecretsManagerSecretsUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "secretsmanager", actual[0].Service)
+	assert.Equal(t, float64(1000), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+}
+
+func TestGetSecretsManagerSecretsUsageError(t *testing.T) {
+	conf.SecretsManager = mockedSecretsManagerClient{ListSecretsPagesError: errors.New("test error")}
+	svcChecker := newTestServiceChecker(NewSecretsManagerChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getSecretsManagerSecretsUsage())
+	assert.Equal(t, expected, svcChecker.getSecretsManagerVersionsPerSecretUsage())
+}
+
+func TestGetSecretsManagerVersionsPerSecretUsage(t *testing.T) {
+	conf.SecretsManager = mockedSecretsManagerClient{
+		ListSecretsPagesResp: mockedSecrets,
+		ListSecretVersionIdsPagesResp: secretsmanager.ListSecretVersionIdsOutput{
+			Versions: []*secretsmanager.SecretVersionsListEntry{{}, {}, {}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewSecretsManagerChecker)
+	actual := svcChecker.getSecretsManagerVersionsPerSecretUsage()
+
+	assert.Len(t, actual, 2)
+	assert.Equal(t, "AWS::SecretsManager::Secret::foo", actual[0].ResourceId)
+	assert.Equal(t, float64(100), actual[0].QuotaValue)
+	assert.Equal(t, float64(3), actual[0].UsageValue)
+}
+
+func TestGetSecretsManagerVersionsPerSecretUsageError(t *testing.T) {
+	conf.SecretsManager = mockedSecretsManagerClient{
+		ListSecretsPagesResp:           mockedSecrets,
+		ListSecretVersionIdsPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewSecretsManagerChecker)
+	actual := svcChecker.getSecretsManagerVersionsPerSecretUsage()
+
+	assert.Len(t, actual, 0)
+}
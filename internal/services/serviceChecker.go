@@ -2,6 +2,8 @@ package services
 
 import (
 	"fmt"
+	"path"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/servicequotas"
@@ -34,6 +36,7 @@ func NewServiceChecker(
 		region = *conf.Session.Config.Region
 	}
 
+	declarePermissions(permissions)
 	c := &ServiceChecker{
 		ServiceCode:         serviceCode,
 		Region:              region,
@@ -47,7 +50,14 @@ func NewServiceChecker(
 
 func (c ServiceChecker) GetUsage() (ret []AWSQuotaInfo) {
 	for _, q := range c.SupportedQuotas {
+		deniedCalls := getDeniedUsageCalls()
 		quotaInfo := q(c)
+		// quotas whose usage could not be fully retrieved are not reported
+		// when skipping unauthorized checks, see GetDeniedActions. A denied
+		// servicequotas lookup only loses the applied value
+		if isSkippingUnauthorized() && getDeniedUsageCalls() != deniedCalls {
+			continue
+		}
 		ret = append(ret, quotaInfo...)
 	}
 	return
@@ -59,8 +69,16 @@ func (c ServiceChecker) GetAllAppliedQuotas() map[string]AWSQuotaInfo {
 		// sometimes applied quotas does not include all default quotas, so we need
 		// to make a union between applied and default - taking applied as source
 		// of truth
-		for name, quota := range c.GetAllDefaultQuotas() {
+		defaultQuotas := c.GetAllDefaultQuotas()
+		for name, quota := range temp {
+			if defaultQuota, ok := defaultQuotas[name]; ok {
+				quota.DefaultValue = defaultQuota.QuotaValue
+				temp[name] = quota
+			}
+		}
+		for name, quota := range defaultQuotas {
 			if _, ok := temp[name]; !ok {
+				quota.AppliedValue = quota.QuotaValue
 				temp[name] = quota
 			}
 		}
@@ -72,10 +90,70 @@ func (c ServiceChecker) GetAllAppliedQuotas() map[string]AWSQuotaInfo {
 	return c.AppliedQuotas
 }
 
+// quotaDefault is the documented default value of a quota, used when
+// servicequotas does not return the quota. Each checker keeps its defaults in
+// a <service>DefaultQuotas map keyed by quota name. Code is the servicequotas
+// code when servicequotas knows the quota, so that overrides and filters by
+// code still apply to the default. Quotas without documented default are
+// Unknown, and only reported once overridden
+type quotaDefault struct {
+	Value   float64
+	Code    string
+	Unknown bool
+}
+
+// getAppliedQuotaOrDefault returns the applied quota with the given name. Some
+// quotas are not (yet) exposed by servicequotas, in which case a quota using
+// the documented default is created and added to the applied quotas
+func (c ServiceChecker) getAppliedQuotaOrDefault(quotaName string, defaultQuota quotaDefault) AWSQuotaInfo {
+	if quota, ok := c.GetAllAppliedQuotas()[quotaName]; ok {
+		return quota
+	}
+	quota := AWSQuotaInfo{
+		Service:      c.ServiceCode,
+		QuotaName:    quotaName,
+		Region:       c.Region,
+		Quotacode:    defaultQuota.Code,
+		QuotaValue:   defaultQuota.Value,
+		DefaultValue: defaultQuota.Value,
+		AppliedValue: defaultQuota.Value,
+		Source:       QuotaSourceDefault,
+	}
+	if defaultQuota.Unknown {
+		quota.Source = QuotaSourceUnknown
+	}
+	c.AppliedQuotas[quotaName] = quota
+	return quota
+}
+
+// servicequotas results are shared by the checkers of a same service code (e.g.
+// ec2, ebs, vpn and transit gateways), so they are only retrieved once per
+// session
+var serviceAppliedQuotas = map[string]map[string]AWSQuotaInfo{}
+var serviceDefaultQuotas = map[string]map[string]AWSQuotaInfo{}
+
+func resetServiceQuotas() {
+	serviceAppliedQuotas = map[string]map[string]AWSQuotaInfo{}
+	serviceDefaultQuotas = map[string]map[string]AWSQuotaInfo{}
+}
+
+// copyQuotas returns a copy of quotas, so that the shared results are not
+// modified by the checkers
+func copyQuotas(quotas map[string]AWSQuotaInfo) (ret map[string]AWSQuotaInfo) {
+	ret = map[string]AWSQuotaInfo{}
+	for name, quota := range quotas {
+		ret[name] = quota
+	}
+	return
+}
+
 func (c ServiceChecker) getServiceAppliedQuotas() (ret map[string]AWSQuotaInfo) {
+	if quotas, ok := serviceAppliedQuotas[c.ServiceCode]; ok {
+		return copyQuotas(quotas)
+	}
 	ret = map[string]AWSQuotaInfo{}
 	serviceQuotas := []*servicequotas.ServiceQuota{}
-	err := conf.ServiceQuotas.ListServiceQuotasPages(&servicequotas.ListServiceQuotasInput{
+	err := getServiceQuotasClient(c.ServiceCode).ListServiceQuotasPages(&servicequotas.ListServiceQuotasInput{
 		ServiceCode: &c.ServiceCode,
 	}, func(p *servicequotas.ListServiceQuotasOutput, lastPage bool) bool {
 		serviceQuotas = append(serviceQuotas, p.Quotas...)
@@ -88,8 +166,12 @@ func (c ServiceChecker) getServiceAppliedQuotas() (ret map[string]AWSQuotaInfo)
 
 	// we then convert to our data model
 	for _, q := range serviceQuotas {
-		ret[aws.StringValue(q.QuotaName)] = svcQuotaToQuotaInfo(q)
+		quota := svcQuotaToQuotaInfo(q)
+		quota.AppliedValue = quota.QuotaValue
+		quota.Source = QuotaSourceApplied
+		ret[aws.StringValue(q.QuotaName)] = quota
 	}
+	serviceAppliedQuotas[c.ServiceCode] = copyQuotas(ret)
 	return
 }
 
@@ -104,9 +186,12 @@ func (c ServiceChecker) GetAllDefaultQuotas() map[string]AWSQuotaInfo {
 }
 
 func (c ServiceChecker) getServiceDefaultQuotas() (ret map[string]AWSQuotaInfo) {
+	if quotas, ok := serviceDefaultQuotas[c.ServiceCode]; ok {
+		return copyQuotas(quotas)
+	}
 	ret = map[string]AWSQuotaInfo{}
 	serviceQuotas := []*servicequotas.ServiceQuota{}
-	err := conf.ServiceQuotas.ListAWSDefaultServiceQuotasPages(&servicequotas.ListAWSDefaultServiceQuotasInput{
+	err := getServiceQuotasClient(c.ServiceCode).ListAWSDefaultServiceQuotasPages(&servicequotas.ListAWSDefaultServiceQuotasInput{
 		ServiceCode: &c.ServiceCode,
 	}, func(p *servicequotas.ListAWSDefaultServiceQuotasOutput, lastPage bool) bool {
 		serviceQuotas = append(serviceQuotas, p.Quotas...)
@@ -119,11 +204,26 @@ func (c ServiceChecker) getServiceDefaultQuotas() (ret map[string]AWSQuotaInfo)
 
 	// we then convert to our data model
 	for _, q := range serviceQuotas {
-		ret[aws.StringValue(q.QuotaName)] = svcQuotaToQuotaInfo(q)
+		quota := svcQuotaToQuotaInfo(q)
+		quota.DefaultValue = quota.QuotaValue
+		quota.Source = QuotaSourceDefault
+		ret[aws.StringValue(q.QuotaName)] = quota
 	}
+	serviceDefaultQuotas[c.ServiceCode] = copyQuotas(ret)
 	return
 }
 
+// withServiceValue records the limit reported by the service api, which is
+// then used as the quota value. Overridden quotas keep the user's value
+func withServiceValue(quota AWSQuotaInfo, value float64) AWSQuotaInfo {
+	quota.ServiceValue = value
+	if quota.Source != QuotaSourceOverride {
+		quota.QuotaValue = value
+		quota.Source = QuotaSourceServiceAPI
+	}
+	return quota
+}
+
 func svcQuotaToQuotaInfo(i *servicequotas.ServiceQuota) (ret AWSQuotaInfo) {
 	ret = AWSQuotaInfo{
 		Service:    aws.StringValue(i.ServiceCode),
@@ -133,22 +233,135 @@ func svcQuotaToQuotaInfo(i *servicequotas.ServiceQuota) (ret AWSQuotaInfo) {
 		UsageValue: 0.0,
 		Unit:       aws.StringValue(i.Unit),
 		Global:     aws.BoolValue(i.GlobalQuota),
+		Adjustable: aws.BoolValue(i.Adjustable),
+		Period:     quotaPeriod(i.Period),
+	}
+	if i.UsageMetric != nil && i.UsageMetric.MetricName != nil {
+		ret.UsageMetric = &AWSQuotaUsageMetric{
+			Namespace:  aws.StringValue(i.UsageMetric.MetricNamespace),
+			Name:       aws.StringValue(i.UsageMetric.MetricName),
+			Dimensions: aws.StringValueMap(i.UsageMetric.MetricDimensions),
+			Statistic:  aws.StringValue(i.UsageMetric.MetricStatisticRecommendation),
+		}
 	}
 	return
 }
 
+var quotaPeriodUnits = map[string]time.Duration{
+	servicequotas.PeriodUnitMicrosecond: time.Microsecond,
+	servicequotas.PeriodUnitMillisecond: time.Millisecond,
+	servicequotas.PeriodUnitSecond:      time.Second,
+	servicequotas.PeriodUnitMinute:      time.Minute,
+	servicequotas.PeriodUnitHour:        time.Hour,
+	servicequotas.PeriodUnitDay:         24 * time.Hour,
+	servicequotas.PeriodUnitWeek:        7 * 24 * time.Hour,
+}
+
+// quotaPeriod returns the period of a rate quota, 0 if the quota has none
+func quotaPeriod(p *servicequotas.QuotaPeriod) time.Duration {
+	if p == nil {
+		return 0
+	}
+	return time.Duration(aws.Int64Value(p.PeriodValue)) * quotaPeriodUnits[aws.StringValue(p.PeriodUnit)]
+}
+
+// SetQuotasOverride applies the overrides of the checker's service to its
+// applied quotas. Quotas built from the service api or bound to resources are
+// only known once the usage is retrieved, see ApplyQuotaOverrides
 func (c ServiceChecker) SetQuotasOverride(quotasOverride []AWSQuotaOverride) {
 	for _, override := range quotasOverride {
-		if c.ServiceCode != override.Service {
-			return
+		if c.ServiceCode != override.Service || override.ResourceId != "" {
+			continue
+		}
+		account := ""
+		if override.Account != "" {
+			var err error
+			if account, err = GetAccountId(); err != nil {
+				fmt.Printf("unable to apply override of %s, %v", override.Service, err)
+				continue
+			}
+		}
+		for name, quota := range c.GetAllAppliedQuotas() {
+			if override.Matches(quota, c.Region, account) {
+				c.AppliedQuotas[name] = ApplyQuotaOverride(quota, override)
+			}
+		}
+	}
+}
+
+// Matches returns whether the override applies to the given quota, retrieved
+// in the given region and account
+func (o AWSQuotaOverride) Matches(quota AWSQuotaInfo, region string, account string) bool {
+	if o.Service != quota.Service {
+		return false
+	}
+	if o.QuotaName != "" && o.QuotaName != quota.QuotaName {
+		return false
+	}
+	if o.QuotaCode != "" && o.QuotaCode != quota.Quotacode {
+		return false
+	}
+	if o.Region != "" && o.Region != region {
+		return false
+	}
+	if o.Account != "" && o.Account != account {
+		return false
+	}
+	if o.ResourceId != "" {
+		matched, err := path.Match(o.ResourceId, quota.ResourceId)
+		return err == nil && matched && quota.ResourceId != ""
+	}
+	return true
+}
+
+// ApplyQuotaOverrides applies the overrides to the quotas retrieved in the given
+// region. It runs once the usage is collected, so that overrides take effect
+// on every quota, whether its value comes from servicequotas, a documented
+// default or the service api, and whether it is bound to a resource or not
+func ApplyQuotaOverrides(quotas []AWSQuotaInfo, overrides []AWSQuotaOverride, region string) []AWSQuotaInfo {
+	for _, override := range overrides {
+		account := ""
+		if override.Account != "" {
+			var err error
+			if account, err = GetAccountId(); err != nil {
+				fmt.Printf("unable to apply override of %s, %v", override.Service, err)
+				continue
+			}
 		}
-		if quota, ok := c.GetAllAppliedQuotas()[override.QuotaName]; ok {
-			quota.QuotaValue = override.QuotaValue
-			c.AppliedQuotas[override.QuotaName] = quota
+		for i, quota := range quotas {
+			if override.Matches(quota, region, account) {
+				quotas[i] = ApplyQuotaOverride(quota, override)
+			}
 		}
 	}
+	return quotas
+}
+
+// ApplyQuotaOverride returns the quota with the override value
+func ApplyQuotaOverride(quota AWSQuotaInfo, override AWSQuotaOverride) AWSQuotaInfo {
+	quota.QuotaValue = override.QuotaValue
+	quota.OverrideValue = override.QuotaValue
+	quota.Source = QuotaSourceOverride
+	return quota
+}
+
+// serviceQuotasPermissions are required by every checker, to retrieve the
+// applied and default quotas of its service
+var serviceQuotasPermissions = []string{
+	"servicequotas:ListServiceQuotas",
+	"servicequotas:ListAWSDefaultServiceQuotas",
 }
 
-func (c ServiceChecker) GetRequiredPermissions() []string {
-	return c.RequiredPermissions
+func (c ServiceChecker) GetRequiredPermissions() (ret []string) {
+	listed := map[string]bool{}
+	for _, permission := range c.RequiredPermissions {
+		listed[permission] = true
+	}
+	ret = append(ret, c.RequiredPermissions...)
+	for _, permission := range serviceQuotasPermissions {
+		if !listed[permission] {
+			ret = append(ret, permission)
+		}
+	}
+	return
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -52,6 +53,7 @@ func TestGetAllAppliedQuotasFallback(t *testing.T) {
 		Quotas: []*servicequotas.ServiceQuota{NewQuota("servicename2", "testQuotaName2", float64(100), false)},
 	}
 
+	resetServiceQuotas()
 	conf.ServiceQuotas = mockedScvQuotaClient{
 		ListServiceQuotasOutputResp:           mockedListServiceQuotasOutput,
 		ListAWSDefaultServiceQuotasOutputResp: mockedListAWSDefaultServiceQuotasOutput,
@@ -69,6 +71,49 @@ func TestGetAllAppliedQuotasFallback(t *testing.T) {
 	assert.Contains(t, appliedQuotasInternal, "testQuotaName2")
 }
 
+func TestGetAllAppliedQuotasSharedByServiceCode(t *testing.T) {
+	calls := 0
+	mock := NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{NewQuota("ec2", "Transit gateways per account", float64(5), false)}, nil)
+	mock.ListServiceQuotasCalls = &calls
+	conf.ServiceQuotas = mock
+
+	// checkers of a same service code query servicequotas once
+	transitGateway := NewTransitGatewayChecker().(*ServiceChecker)
+	vpn := NewVpnChecker().(*ServiceChecker)
+	assert.Contains(t, transitGateway.GetAllAppliedQuotas(), "Transit gateways per account")
+	assert.Contains(t, vpn.GetAllAppliedQuotas(), "Transit gateways per account")
+	assert.Equal(t, 1, calls)
+
+	// and do not see the quotas added by each other
+	transitGateway.getAppliedQuotaOrDefault("documented", quotaDefault{Value: 1})
+	assert.NotContains(t, NewVpnChecker().GetAllAppliedQuotas(), "documented")
+	assert.Equal(t, 1, calls)
+}
+
+func TestGetAllAppliedQuotasSources(t *testing.T) {
+	resetServiceQuotas()
+	conf.ServiceQuotas = mockedScvQuotaClient{
+		ListServiceQuotasOutputResp: servicequotas.ListServiceQuotasOutput{
+			Quotas: []*servicequotas.ServiceQuota{NewQuota("testService", "raisedQuota", float64(500), false)},
+		},
+		ListAWSDefaultServiceQuotasOutputResp: servicequotas.ListAWSDefaultServiceQuotasOutput{
+			Quotas: []*servicequotas.ServiceQuota{
+				NewQuota("testService", "raisedQuota", float64(100), false),
+				NewQuota("testService", "defaultQuota", float64(20), false)},
+		},
+	}
+
+	appliedQuotas := NewTestChecker(nil).GetAllAppliedQuotas()
+	raised := appliedQuotas["raisedQuota"]
+	assert.Equal(t, QuotaSourceApplied, raised.Source)
+	assert.Equal(t, float64(500), raised.AppliedValue)
+	assert.Equal(t, float64(100), raised.DefaultValue)
+	notRaised := appliedQuotas["defaultQuota"]
+	assert.Equal(t, QuotaSourceDefault, notRaised.Source)
+	assert.Equal(t, float64(20), notRaised.AppliedValue)
+	assert.Equal(t, float64(20), notRaised.DefaultValue)
+}
+
 func TestGetAllAppliedQuotasError(t *testing.T) {
 	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
 		[]*servicequotas.ServiceQuota{NewQuota("testServiceNam2e", "testQuotaName2", float64(100), false)},
@@ -100,7 +145,11 @@ func TestGetAllDefaultQuotasError(t *testing.T) {
 
 func TestServiceCheckerGetRequiredPermissions(t *testing.T) {
 	testChecker := NewTestChecker(nil)
-	assert.Equal(t, 1, len(testChecker.GetRequiredPermissions()))
+	assert.Equal(t, []string{
+		"test:ListTestIAM",
+		"servicequotas:ListServiceQuotas",
+		"servicequotas:ListAWSDefaultServiceQuotas",
+	}, testChecker.GetRequiredPermissions())
 }
 
 func TestSvcQuotaToQuotaInfo(t *testing.T) {
@@ -112,6 +161,7 @@ func TestSvcQuotaToQuotaInfo(t *testing.T) {
 		Value:       aws.Float64(float64(10)),
 		Unit:        aws.String("myUnit"),
 		GlobalQuota: aws.Bool(true),
+		Adjustable:  aws.Bool(true),
 	}
 
 	expected := AWSQuotaInfo{
@@ -122,10 +172,22 @@ func TestSvcQuotaToQuotaInfo(t *testing.T) {
 		UsageValue: 0.0,
 		Unit:       "myUnit",
 		Global:     true,
+		Adjustable: true,
 	}
 	assert.Equal(t, expected, svcQuotaToQuotaInfo(&svcQuota))
 }
 
+func TestSvcQuotaToQuotaInfoUsageMetric(t *testing.T) {
+	svcQuota := NewQuotaWithUsageMetric("testService", "quotaName", float64(10), "ResourceCount")
+
+	actual := svcQuotaToQuotaInfo(svcQuota)
+	require.NotNil(t, actual.UsageMetric)
+	assert.Equal(t, "AWS/Usage", actual.UsageMetric.Namespace)
+	assert.Equal(t, "ResourceCount", actual.UsageMetric.Name)
+	assert.Equal(t, map[string]string{"Service": "testService", "Resource": "quotaName"}, actual.UsageMetric.Dimensions)
+	assert.Equal(t, "Maximum", actual.UsageMetric.Statistic)
+}
+
 func TestSetQuotaOverride(t *testing.T) {
 	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
 		[]*servicequotas.ServiceQuota{
@@ -143,6 +205,32 @@ func TestSetQuotaOverride(t *testing.T) {
 	assert.Equal(t, float64(200), appliedQuotasInternal["testQuotaName2"].QuotaValue)
 }
 
+func TestSetQuotaOverrideSource(t *testing.T) {
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{NewQuota("testService", "testQuotaName", float64(100), false)},
+		nil)
+	testChecker := NewTestChecker(nil)
+	testChecker.SetQuotasOverride([]AWSQuotaOverride{{Service: "testService", QuotaName: "testQuotaName", QuotaValue: float64(500)}})
+	quota := testChecker.GetAllAppliedQuotas()["testQuotaName"]
+	assert.Equal(t, QuotaSourceOverride, quota.Source)
+	assert.Equal(t, float64(500), quota.OverrideValue)
+	assert.Equal(t, float64(100), quota.AppliedValue)
+}
+
+func TestWithServiceValue(t *testing.T) {
+	quota := withServiceValue(AWSQuotaInfo{QuotaValue: 100, AppliedValue: 100, Source: QuotaSourceApplied}, 200)
+	assert.Equal(t, float64(200), quota.QuotaValue)
+	assert.Equal(t, float64(200), quota.ServiceValue)
+	assert.Equal(t, float64(100), quota.AppliedValue)
+	assert.Equal(t, QuotaSourceServiceAPI, quota.Source)
+
+	// overrides win over the service value
+	quota = withServiceValue(AWSQuotaInfo{QuotaValue: 500, OverrideValue: 500, Source: QuotaSourceOverride}, 200)
+	assert.Equal(t, float64(500), quota.QuotaValue)
+	assert.Equal(t, float64(200), quota.ServiceValue)
+	assert.Equal(t, QuotaSourceOverride, quota.Source)
+}
+
 func TestSetQuotaOverrideWrongService(t *testing.T) {
 	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
 		[]*servicequotas.ServiceQuota{
@@ -177,3 +265,112 @@ func TestSetQuotaOverrideWrongQuotaName(t *testing.T) {
 	assert.Equal(t, float64(100), appliedQuotasInternal["testQuotaName"].QuotaValue)
 	assert.Equal(t, float64(200), appliedQuotasInternal["testQuotaName2"].QuotaValue)
 }
+
+func TestSetQuotaOverrideOtherServiceFirst(t *testing.T) {
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{NewQuota("testService", "testQuotaName", float64(100), false)},
+		nil)
+	testChecker := NewTestChecker(nil)
+	testChecker.SetQuotasOverride([]AWSQuotaOverride{
+		{Service: "otherService", QuotaName: "testQuotaName", QuotaValue: float64(300)},
+		{Service: "testService", QuotaName: "testQuotaName", QuotaValue: float64(500)}})
+	assert.Equal(t, float64(500), testChecker.GetAllAppliedQuotas()["testQuotaName"].QuotaValue)
+}
+
+func TestSetQuotaOverrideByQuotaCode(t *testing.T) {
+	quota := NewQuota("testService", "testQuotaName", float64(100), false)
+	quota.QuotaCode = aws.String("L-1234")
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{quota, NewQuota("testService", "testQuotaName2", float64(200), false)},
+		nil)
+	testChecker := NewTestChecker(nil)
+	testChecker.SetQuotasOverride([]AWSQuotaOverride{{Service: "testService", QuotaCode: "L-1234", QuotaValue: float64(500)}})
+	appliedQuotas := testChecker.GetAllAppliedQuotas()
+	assert.Equal(t, float64(500), appliedQuotas["testQuotaName"].QuotaValue)
+	assert.Equal(t, float64(200), appliedQuotas["testQuotaName2"].QuotaValue)
+}
+
+func TestSetQuotaOverrideRegionAndAccount(t *testing.T) {
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{
+			NewQuota("testService", "testQuotaName", float64(100), false),
+			NewQuota("testService", "testQuotaName2", float64(200), false)},
+		nil)
+	conf.Sts = mockedStsClient{GetCallerIdentityResp: sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}}
+	t.Cleanup(func() { accountId = "" })
+
+	testChecker := NewTestChecker(nil)
+	testChecker.(*ServiceChecker).Region = "eu-west-1"
+	testChecker.SetQuotasOverride([]AWSQuotaOverride{
+		{Service: "testService", QuotaName: "testQuotaName", Region: "us-east-1", QuotaValue: float64(300)},
+		{Service: "testService", QuotaName: "testQuotaName", Region: "eu-west-1", Account: "123456789012", QuotaValue: float64(500)},
+		{Service: "testService", QuotaName: "testQuotaName2", Account: "210987654321", QuotaValue: float64(600)}})
+	appliedQuotas := testChecker.GetAllAppliedQuotas()
+	assert.Equal(t, float64(500), appliedQuotas["testQuotaName"].QuotaValue)
+	assert.Equal(t, float64(200), appliedQuotas["testQuotaName2"].QuotaValue)
+}
+
+func TestSetQuotaOverrideResourceSkipped(t *testing.T) {
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{NewQuota("testService", "testQuotaName", float64(100), false)},
+		nil)
+	testChecker := NewTestChecker(nil)
+	testChecker.SetQuotasOverride([]AWSQuotaOverride{{Service: "testService", QuotaName: "testQuotaName", ResourceId: "*", QuotaValue: float64(500)}})
+	assert.Equal(t, float64(100), testChecker.GetAllAppliedQuotas()["testQuotaName"].QuotaValue)
+}
+
+func TestAWSQuotaOverrideMatches(t *testing.T) {
+	quota := AWSQuotaInfo{Service: "eks", QuotaName: "Nodes per managed node group", Quotacode: "L-BD136A63", ResourceId: "AWS::EKS::Nodegroup::prod/workers"}
+
+	assert.True(t, AWSQuotaOverride{Service: "eks"}.Matches(quota, "eu-west-1", ""))
+	assert.True(t, AWSQuotaOverride{Service: "eks", QuotaCode: "L-BD136A63"}.Matches(quota, "eu-west-1", ""))
+	assert.True(t, AWSQuotaOverride{Service: "eks", ResourceId: "AWS::EKS::Nodegroup::prod/*"}.Matches(quota, "eu-west-1", ""))
+	assert.True(t, AWSQuotaOverride{Service: "eks", Region: "eu-west-1", Account: "123456789012"}.Matches(quota, "eu-west-1", "123456789012"))
+	assert.False(t, AWSQuotaOverride{Service: "ec2"}.Matches(quota, "eu-west-1", ""))
+	assert.False(t, AWSQuotaOverride{Service: "eks", QuotaName: "Clusters"}.Matches(quota, "eu-west-1", ""))
+	assert.False(t, AWSQuotaOverride{Service: "eks", QuotaCode: "L-1234"}.Matches(quota, "eu-west-1", ""))
+	assert.False(t, AWSQuotaOverride{Service: "eks", ResourceId: "AWS::EKS::Nodegroup::dev/*"}.Matches(quota, "eu-west-1", ""))
+	assert.False(t, AWSQuotaOverride{Service: "eks", Region: "us-east-1"}.Matches(quota, "eu-west-1", ""))
+	assert.False(t, AWSQuotaOverride{Service: "eks", Account: "123456789012"}.Matches(quota, "eu-west-1", "210987654321"))
+
+	// resource overrides never match quotas not bound to a resource
+	quota.ResourceId = ""
+	assert.False(t, AWSQuotaOverride{Service: "eks", ResourceId: "*"}.Matches(quota, "eu-west-1", ""))
+}
+
+func TestApplyQuotaOverrides(t *testing.T) {
+	conf.Sts = mockedStsClient{GetCallerIdentityResp: sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}}
+	t.Cleanup(func() { accountId = "" })
+
+	quotas := []AWSQuotaInfo{
+		{Service: "kafka", QuotaName: "Brokers per cluster", ResourceId: "AWS::MSK::Cluster::prod-1", QuotaValue: 30},
+		{Service: "kafka", QuotaName: "Brokers per cluster", ResourceId: "AWS::MSK::Cluster::dev-1", QuotaValue: 30},
+		{Service: "kafka", QuotaName: "Brokers per account", QuotaValue: 90},
+		{Service: "kafka", QuotaName: "Configurations per account", QuotaValue: 100},
+	}
+	actual := ApplyQuotaOverrides(quotas, []AWSQuotaOverride{
+		{Service: "kafka", QuotaName: "Brokers per cluster", ResourceId: "AWS::MSK::Cluster::prod-*", QuotaValue: 60},
+		{Service: "kafka", QuotaName: "Brokers per account", Account: "123456789012", QuotaValue: 120},
+		{Service: "kafka", QuotaName: "Configurations per account", Region: "us-east-1", QuotaValue: 200},
+	}, "eu-west-1")
+	assert.Equal(t, float64(60), actual[0].QuotaValue)
+	assert.Equal(t, QuotaSourceOverride, actual[0].Source)
+	assert.Equal(t, float64(30), actual[1].QuotaValue)
+	assert.Equal(t, float64(120), actual[2].QuotaValue)
+	assert.Equal(t, float64(100), actual[3].QuotaValue)
+}
+
+func TestGetAppliedQuotaOrDefault(t *testing.T) {
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{NewQuota("testService", "testQuotaName", float64(100), false)},
+		nil)
+	testChecker := NewTestChecker(nil)
+	svcChecker := testChecker.(*ServiceChecker)
+
+	assert.Equal(t, float64(100), svcChecker.getAppliedQuotaOrDefault("testQuotaName", quotaDefault{Value: 5}).QuotaValue)
+	missing := svcChecker.getAppliedQuotaOrDefault("missingQuotaName", quotaDefault{Value: 5, Code: "L-1234"})
+	assert.Equal(t, "testService", missing.Service)
+	assert.Equal(t, float64(5), missing.QuotaValue)
+	assert.Equal(t, "L-1234", missing.Quotacode)
+	assert.Contains(t, svcChecker.AppliedQuotas, "missingQuotaName")
+}
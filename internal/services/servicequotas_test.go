@@ -11,6 +11,7 @@ type mockedScvQuotaClient struct {
 	ListAWSDefaultServiceQuotasOutputError error
 	ListServiceQuotasOutputResp            servicequotas.ListServiceQuotasOutput
 	ListServiceQuotasOutputError           error
+	ListServiceQuotasCalls                 *int
 }
 
 func (m mockedScvQuotaClient) ListAWSDefaultServiceQuotasPages(
@@ -23,6 +24,9 @@ func (m mockedScvQuotaClient) ListAWSDefaultServiceQuotasPages(
 func (m mockedScvQuotaClient) ListServiceQuotasPages(
 	input *servicequotas.ListServiceQuotasInput,
 	fn func(*servicequotas.ListServiceQuotasOutput, bool) bool) error {
+	if m.ListServiceQuotasCalls != nil {
+		*m.ListServiceQuotasCalls++
+	}
 	fn(&m.ListServiceQuotasOutputResp, false)
 	return m.ListServiceQuotasOutputError
 }
@@ -37,6 +41,7 @@ func NewQuota(svcName string, quotaName string, quotaValue float64, isGlobal boo
 }
 
 func NewSvcQuotaMockListServiceQuotas(quotas []*servicequotas.ServiceQuota, err error) (ret mockedScvQuotaClient) {
+	resetServiceQuotas()
 	mockedSvcQuotaOutput := servicequotas.ListServiceQuotasOutput{
 		Quotas: quotas,
 	}
@@ -47,6 +52,7 @@ func NewSvcQuotaMockListServiceQuotas(quotas []*servicequotas.ServiceQuota, err
 	return
 }
 func NewSvcQuotaMockListAWSDefaultServiceQuotas(quotas []*servicequotas.ServiceQuota, err error) (ret mockedScvQuotaClient) {
+	resetServiceQuotas()
 	mockedSvcQuotaOutput := servicequotas.ListAWSDefaultServiceQuotasOutput{
 		Quotas: quotas,
 	}
@@ -56,3 +62,16 @@ func NewSvcQuotaMockListAWSDefaultServiceQuotas(quotas []*servicequotas.ServiceQ
 	}
 	return
 }
+
+// newTestServiceChecker builds the checker under test, with servicequotas
+// returning the given quotas
+func newTestServiceChecker(newChecker func() Svcquota, quotas ...*servicequotas.ServiceQuota) *ServiceChecker {
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(quotas, nil)
+	return newChecker().(*ServiceChecker)
+}
+
+// mockPages hands resp to a paginated call's callback as its only page
+func mockPages[T any](resp T, err error, fn func(*T, bool) bool) error {
+	fn(&resp, false)
+	return err
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ses"
+)
+
+type SesClientInterface interface {
+	GetSendQuota(input *ses.GetSendQuotaInput) (*ses.GetSendQuotaOutput, error)
+	GetSendStatistics(input *ses.GetSendStatisticsInput) (*ses.GetSendStatisticsOutput, error)
+	ListIdentitiesPages(input *ses.ListIdentitiesInput, fn func(*ses.ListIdentitiesOutput, bool) bool) error
+	ListConfigurationSets(input *ses.ListConfigurationSetsInput) (*ses.ListConfigurationSetsOutput, error)
+}
+
+var sesDefaultQuotas = map[string]quotaDefault{
+	"Sending quota":                  {Value: 200, Code: "L-804C8AE8"}, // sandbox
+	"Maximum send rate":              {Value: 1, Code: "L-CDEF9B6B"},   // sandbox
+	"Verified identities per Region": {Value: 10000},
+	"Configuration sets per Region":  {Value: 10000},
+}
+
+// duration, in seconds, of each data point returned by GetSendStatistics
+const sesSendDataPointPeriod = 15 * 60
+
+func NewSesChecker() Svcquota {
+	serviceCode := "ses"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Sending quota":                  ServiceChecker.getSesSendingQuotaUsage,
+		"Maximum send rate":              ServiceChecker.getSesMaxSendRateUsage,
+		"Verified identities per Region": ServiceChecker.getSesIdentitiesUsage,
+		"Configuration sets per Region":  ServiceChecker.getSesConfigurationSetsUsage,
+	}
+	requiredPermissions := []string{
+		"ses:GetSendQuota",
+		"ses:GetSendStatistics",
+		"ses:ListIdentities",
+		"ses:ListConfigurationSets",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+// getSesSendingQuotaUsage uses the limit returned by GetSendQuota, which is the
+// one ses enforces (including while in the sandbox)
+func (c ServiceChecker) getSesSendingQuotaUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	result, err := conf.Ses.GetSendQuota(&ses.GetSendQuotaInput{})
+	if err != nil {
+		fmt.Printf("failed to retrieve ses send quota, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Sending quota", sesDefaultQuotas["Sending quota"])
+	quotaInfo = withServiceValue(quotaInfo, aws.Float64Value(result.Max24HourSend))
+	quotaInfo.UsageValue = aws.Float64Value(result.SentLast24Hours)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+// getSesMaxSendRateUsage estimates the send rate from the busiest 15 minutes
+// interval of the last two weeks, ses does not expose the peak rate
+func (c ServiceChecker) getSesMaxSendRateUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	sendQuota, err := conf.Ses.GetSendQuota(&ses.GetSendQuotaInput{})
+	if err != nil {
+		fmt.Printf("failed to retrieve ses send quota, %v", err)
+		return
+	}
+	statistics, err := conf.Ses.GetSendStatistics(&ses.GetSendStatisticsInput{})
+	if err != nil {
+		fmt.Printf("failed to retrieve ses send statistics, %v", err)
+		return
+	}
+
+	maxDeliveryAttempts := int64(0)
+	for _, dataPoint := range statistics.SendDataPoints {
+		if aws.Int64Value(dataPoint.DeliveryAttempts) > maxDeliveryAttempts {
+			maxDeliveryAttempts = aws.Int64Value(dataPoint.DeliveryAttempts)
+		}
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Maximum send rate", sesDefaultQuotas["Maximum send rate"])
+	quotaInfo = withServiceValue(quotaInfo, aws.Float64Value(sendQuota.MaxSendRate))
+	quotaInfo.UsageValue = float64(maxDeliveryAttempts) / sesSendDataPointPeriod
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getSesIdentitiesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	identities := 0
+	err := conf.Ses.ListIdentitiesPages(&ses.ListIdentitiesInput{}, func(p *ses.ListIdentitiesOutput, lastPage bool) bool {
+		identities += len(p.Identities)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve ses identities, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Verified identities per Region", sesDefaultQuotas["Verified identities per Region"])
+	quotaInfo.UsageValue = float64(identities)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getSesConfigurationSetsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	configurationSets := 0
+	input := &ses.ListConfigurationSetsInput{}
+	for {
+		result, err := conf.Ses.ListConfigurationSets(input)
+		if err != nil {
+			fmt.Printf("failed to retrieve ses configuration sets, %v", err)
+			return
+		}
+		configurationSets += len(result.ConfigurationSets)
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Configuration sets per Region", sesDefaultQuotas["Configuration sets per Region"])
+	quotaInfo.UsageValue = float64(configurationSets)
+	ret = append(ret, quotaInfo)
+	return
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedSesClient struct {
+	SesClientInterface
+	GetSendQuotaResp           ses.GetSendQuotaOutput
+	GetSendQuotaError          error
+	GetSendStatisticsResp      ses.GetSendStatisticsOutput
+	GetSendStatisticsError     error
+	ListIdentitiesPagesResp    ses.ListIdentitiesOutput
+	ListIdentitiesPagesError   error
+	ListConfigurationSetsResp  ses.ListConfigurationSetsOutput
+	ListConfigurationSetsError error
+}
+
+func (m mockedSesClient) GetSendQuota(input *ses.GetSendQuotaInput) (*ses.GetSendQuotaOutput, error) {
+	return &m.GetSendQuotaResp, m.GetSendQuotaError
+}
+
+func (m mockedSesClient) GetSendStatistics(input *ses.GetSendStatisticsInput) (*ses.GetSendStatisticsOutput, error) {
+	return &m.GetSendStatisticsResp, m.GetSendStatisticsError
+}
+
+func (m mockedSesClient) ListIdentitiesPages(input *ses.ListIdentitiesInput, fn func(*ses.ListIdentitiesOutput, bool) bool) error {
+	return mockPages(m.ListIdentitiesPagesResp, m.ListIdentitiesPagesError, fn)
+}
+
+func (m mockedSesClient) ListConfigurationSets(input *ses.ListConfigurationSetsInput) (*ses.ListConfigurationSetsOutput, error) {
+	return &m.ListConfigurationSetsResp, m.ListConfigurationSetsError
+}
+
+func TestNewSesCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewSesChecker())
+}
+
+func TestGetSesUsage(t *testing.T) {
+	conf.Ses = mockedSesClient{
+		GetSendQuotaResp: ses.GetSendQuotaOutput{
+			Max24HourSend:   aws.Float64(50000),
+			MaxSendRate:     aws.Float64(14),
+			SentLast24Hours: aws.Float64(1234),
+		},
+		GetSendStatisticsResp: ses.GetSendStatisticsOutput{
+			SendDataPoints: []*ses.SendDataPoint{
+				{DeliveryAttempts: aws.Int64(900)},
+				{DeliveryAttempts: aws.Int64(1800)},
+				{DeliveryAttempts: aws.Int64(90)},
+			},
+		},
+		ListIdentitiesPagesResp: ses.ListIdentitiesOutput{
+			Identities: []*string{aws.String("example.com"), aws.String("me@example.com")},
+		},
+		ListConfigurationSetsResp: ses.ListConfigurationSetsOutput{
+			ConfigurationSets: []*ses.ConfigurationSet{{}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewSesChecker, NewQuota("ses", "Sending quota", float64(200), false))
+
+	// the limit returned by ses takes precedence over servicequotas
+	sendingQuota := svcChecker.getSesSendingQuotaUsage()
+	assert.Len(t, sendingQuota, 1)
+	assert.Equal(t, "ses", sendingQuota[0].Service)
+	assert.Equal(t, float64(50000), sendingQuota[0].QuotaValue)
+	assert.Equal(t, float64(1234), sendingQuota[0].UsageValue)
+
+	sendRate := svcChecker.getSesMaxSendRateUsage()
+	assert.Len(t, sendRate, 1)
+	assert.Equal(t, float64(14), sendRate[0].QuotaValue)
+	assert.Equal(t, float64(2), sendRate[0].UsageValue)
+
+	identities := svcChecker.getSesIdentitiesUsage()
+	assert.Len(t, identities, 1)
+	assert.Equal(t, float64(10000), identities[0].QuotaValue)
+	assert.Equal(t, float64(2), identities[0].UsageValue)
+
+	configurationSets := svcChecker.getSesConfigurationSetsUsage()
+	assert.Len(t, configurationSets, 1)
+	assert.Equal(t, float64(1), configurationSets[0].UsageValue)
+}
+
+func TestGetSesUsageError(t *testing.T) {
+	conf.Ses = mockedSesClient{
+		GetSendQuotaError:          errors.New("test error"),
+		ListIdentitiesPagesError:   errors.New("test error"),
+		ListConfigurationSetsError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewSesChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getSesSendingQuotaUsage())
+	assert.Equal(t, expected, svcChecker.getSesMaxSendRateUsage())
+	assert.Equal(t, expected, svcChecker.getSesIdentitiesUsage())
+	assert.Equal(t, expected, svcChecker.getSesConfigurationSetsUsage())
+}
+
+func TestGetSesMaxSendRateUsageStatisticsError(t *testing.T) {
+	conf.Ses = mockedSesClient{
+		GetSendQuotaResp:       ses.GetSendQuotaOutput{MaxSendRate: aws.Float64(14)},
+		GetSendStatisticsError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewSesChecker)
+
+	assert.Equal(t, []AWSQuotaInfo{}, svcChecker.getSesMaxSendRateUsage())
+}
+
+func TestGetSesSendingQuotaUsageOverride(t *testing.T) {
+	conf.Ses = mockedSesClient{
+		GetSendQuotaResp: ses.GetSendQuotaOutput{
+			Max24HourSend:   aws.Float64(50000),
+			SentLast24Hours: aws.Float64(1234),
+		},
+	}
+	svcChecker := newTestServiceChecker(NewSesChecker)
+	actual := ApplyQuotaOverrides(svcChecker.getSesSendingQuotaUsage(), []AWSQuotaOverride{
+		{Service: "ses", QuotaName: "Sending quota", QuotaValue: float64(100000)}}, "")
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, float64(100000), actual[0].QuotaValue)
+	assert.Equal(t, float64(50000), actual[0].ServiceValue)
+	assert.Equal(t, QuotaSourceOverride, actual[0].Source)
+}
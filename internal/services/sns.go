@@ -24,7 +24,7 @@ func NewSnsChecker() Svcquota {
 
 func (c ServiceChecker) getSnsTopicsUsage() (ret []AWSQuotaInfo) {
 	ret = []AWSQuotaInfo{}
-	quotaInfo := c.GetAllAppliedQuotas()["Topics per Account"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Topics per Account", quotaDefault{})
 
 	topics := []*sns.Topic{}
 	err := conf.Sns.ListTopicsPages(&sns.ListTopicsInput{}, func(p *sns.ListTopicsOutput, lastPage bool) bool {
@@ -43,7 +43,7 @@ func (c ServiceChecker) getSnsTopicsUsage() (ret []AWSQuotaInfo) {
 
 func (c ServiceChecker) getSnsPendingSubsUsage() (ret []AWSQuotaInfo) {
 	ret = []AWSQuotaInfo{}
-	quotaInfo := c.GetAllAppliedQuotas()["Pending Subscriptions per Account"]
+	quotaInfo := c.getAppliedQuotaOrDefault("Pending Subscriptions per Account", quotaDefault{})
 
 	subscriptions := []*sns.Subscription{}
 	err := conf.Sns.ListSubscriptionsPages(&sns.ListSubscriptionsInput{}, func(p *sns.ListSubscriptionsOutput, lastPage bool) bool {
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+type SsmClientInterface interface {
+	DescribeParametersPages(input *ssm.DescribeParametersInput, fn func(*ssm.DescribeParametersOutput, bool) bool) error
+}
+
+var ssmDefaultQuotas = map[string]quotaDefault{
+	"Standard parameters": {Value: 10000},
+	"Advanced parameters": {Value: 100000},
+}
+
+func NewSsmChecker() Svcquota {
+	serviceCode := "ssm"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Standard parameters": ServiceChecker.getSsmStandardParametersUsage,
+		"Advanced parameters": ServiceChecker.getSsmAdvancedParametersUsage,
+	}
+	requiredPermissions := []string{"ssm:DescribeParameters"}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+var ssmParameters []*ssm.ParameterMetadata = []*ssm.ParameterMetadata{}
+
+// getSsmParameters lists the parameters once and shares the result between
+// the different quotas
+func getSsmParameters() (ret []*ssm.ParameterMetadata, err error) {
+	ret = ssmParameters
+	if len(ssmParameters) != 0 {
+		return
+	}
+
+	err = conf.Ssm.DescribeParametersPages(&ssm.DescribeParametersInput{}, func(p *ssm.DescribeParametersOutput, lastPage bool) bool {
+		ssmParameters = append(ssmParameters, p.Parameters...)
+		return true // continue paging
+	})
+	if err != nil {
+		ssmParameters = []*ssm.ParameterMetadata{}
+		return ssmParameters, err
+	}
+	return ssmParameters, nil
+}
+
+func (c ServiceChecker) getSsmParametersUsage(quotaName string, tier string) (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	parameters, err := getSsmParameters()
+	if err != nil {
+		fmt.Printf("failed to retrieve ssm parameters, %v", err)
+		return
+	}
+
+	count := 0
+	for _, p := range parameters {
+		if aws.StringValue(p.Tier) == tier {
+			count++
+		}
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault(quotaName, ssmDefaultQuotas[quotaName])
+	quotaInfo.UsageValue = float64(count)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getSsmStandardParametersUsage() (ret []AWSQuotaInfo) {
+	return c.getSsmParametersUsage("Standard parameters", ssm.ParameterTierStandard)
+}
+
+func (c ServiceChecker) getSsmAdvancedParametersUsage() (ret []AWSQuotaInfo) {
+	return c.getSsmParametersUsage("Advanced parameters", ssm.ParameterTierAdvanced)
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedSsmClient struct {
+	SsmClientInterface
+	DescribeParametersPagesResp  ssm.DescribeParametersOutput
+	DescribeParametersPagesError error
+}
+
+func (m mockedSsmClient) DescribeParametersPages(input *ssm.DescribeParametersInput, fn func(*ssm.DescribeParametersOutput, bool) bool) error {
+	return mockPages(m.DescribeParametersPagesResp, m.DescribeParametersPagesError, fn)
+}
+
+func TestNewSsmCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewSsmChecker())
+}
+
+func TestGetSsmParametersUsage(t *testing.T) {
+	t.Cleanup(func() { ssmParameters = []*ssm.ParameterMetadata{} })
+	conf.Ssm = mockedSsmClient{
+		DescribeParametersPagesResp: ssm.DescribeParametersOutput{
+			Parameters: []*ssm.ParameterMetadata{
+				{Name: aws.String("foo"), Tier: aws.String(ssm.ParameterTierStandard)},
+				{Name: aws.String("bar"), Tier: aws.String(ssm.ParameterTierStandard)},
+				{Name: aws.String("baz"), Tier: aws.String(ssm.ParameterTierAdvanced)},
+			},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewSsmChecker, NewQuota("ssm", "Advanced parameters", float64(200000), false))
+
+	standard := svcChecker.getSsmStandardParametersUsage()
+	assert.Len(t, standard, 1)
+	assert.Equal(t, "ssm", standard[0].Service)
+	assert.Equal(t, float64(10000), standard[0].QuotaValue)
+	assert.Equal(t, float64(2), standard[0].UsageValue)
+
+	advanced := svcChecker.getSsmAdvancedParametersUsage()
+	assert.Len(t, advanced, 1)
+	assert.Equal(t, float64(200000), advanced[0].QuotaValue)
+	assert.Equal(t, float64(1), advanced[0].UsageValue)
+}
+
+func TestGetSsmParametersUsageError(t *testing.T) {
+	t.Cleanup(func() { ssmParameters = []*ssm.ParameterMetadata{} })
+	conf.Ssm = mockedSsmClient{DescribeParametersPagesError: errors.New("test error")}
+	svcChecker := newTestServiceChecker(NewSsmChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getSsmStandardParametersUsage())
+	assert.Equal(t, expected, svcChecker.getSsmAdvancedParametersUsage())
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/sfn"
+)
+
+type SfnClientInterface interface {
+	ListStateMachinesPages(input *sfn.ListStateMachinesInput, fn func(*sfn.ListStateMachinesOutput, bool) bool) error
+	ListActivitiesPages(input *sfn.ListActivitiesInput, fn func(*sfn.ListActivitiesOutput, bool) bool) error
+}
+
+var sfnDefaultQuotas = map[string]quotaDefault{
+	"Registered state machines": {Value: 10000},
+	"Registered activities":     {Value: 10000},
+}
+
+func NewStepFunctionsChecker() Svcquota {
+	serviceCode := "states"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Registered state machines": ServiceChecker.getStepFunctionsStateMachinesUsage,
+		"Registered activities":     ServiceChecker.getStepFunctionsActivitiesUsage,
+	}
+	requiredPermissions := []string{
+		"states:ListStateMachines",
+		"states:ListActivities",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func (c ServiceChecker) getStepFunctionsStateMachinesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	stateMachines := 0
+	err := conf.Sfn.ListStateMachinesPages(&sfn.ListStateMachinesInput{}, func(p *sfn.ListStateMachinesOutput, lastPage bool) bool {
+		stateMachines += len(p.StateMachines)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve step functions state machines, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Registered state machines", sfnDefaultQuotas["Registered state machines"])
+	quotaInfo.UsageValue = float64(stateMachines)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getStepFunctionsActivitiesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	activities := 0
+	err := conf.Sfn.ListActivitiesPages(&sfn.ListActivitiesInput{}, func(p *sfn.ListActivitiesOutput, lastPage bool) bool {
+		activities += len(p.Activities)
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve step functions activities, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Registered activities", sfnDefaultQuotas["Registered activities"])
+	quotaInfo.UsageValue = float64(activities)
+	ret = append(ret, quotaInfo)
+	return
+}
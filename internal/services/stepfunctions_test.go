@@ -0,0 +1,61 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedSfnClient struct {
+	SfnClientInterface
+	ListStateMachinesPagesResp  sfn.ListStateMachinesOutput
+	ListStateMachinesPagesError error
+	ListActivitiesPagesResp     sfn.ListActivitiesOutput
+	ListActivitiesPagesError    error
+}
+
+func (m mockedSfnClient) ListStateMachinesPages(input *sfn.ListStateMachinesInput, fn func(*sfn.ListStateMachinesOutput, bool) bool) error {
+	return mockPages(m.ListStateMachinesPagesResp, m.ListStateMachinesPagesError, fn)
+}
+
+func (m mockedSfnClient) ListActivitiesPages(input *sfn.ListActivitiesInput, fn func(*sfn.ListActivitiesOutput, bool) bool) error {
+	return mockPages(m.ListActivitiesPagesResp, m.ListActivitiesPagesError, fn)
+}
+
+func TestNewStepFunctionsCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewStepFunctionsChecker())
+}
+
+func TestGetStepFunctionsUsage(t *testing.T) {
+	conf.Sfn = mockedSfnClient{
+		ListStateMachinesPagesResp: sfn.ListStateMachinesOutput{StateMachines: []*sfn.StateMachineListItem{{}, {}}},
+		ListActivitiesPagesResp:    sfn.ListActivitiesOutput{Activities: []*sfn.ActivityListItem{{}}},
+	}
+	svcChecker := newTestServiceChecker(NewStepFunctionsChecker, NewQuota("states", "Registered state machines", float64(20000), false))
+
+	stateMachines := svcChecker.getStepFunctionsStateMachinesUsage()
+	assert.Len(t, stateMachines, 1)
+	assert.Equal(t, "states", stateMachines[0].Service)
+	assert.Equal(t, float64(20000), stateMachines[0].QuotaValue)
+	assert.Equal(t, float64(2), stateMachines[0].UsageValue)
+
+	activities := svcChecker.getStepFunctionsActivitiesUsage()
+	assert.Len(t, activities, 1)
+	assert.Equal(t, float64(10000), activities[0].QuotaValue)
+	assert.Equal(t, float64(1), activities[0].UsageValue)
+}
+
+func TestGetStepFunctionsUsageError(t *testing.T) {
+	conf.Sfn = mockedSfnClient{
+		ListStateMachinesPagesError: errors.New("test error"),
+		ListActivitiesPagesError:    errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewStepFunctionsChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getStepFunctionsStateMachinesUsage())
+	assert.Equal(t, expected, svcChecker.getStepFunctionsActivitiesUsage())
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/storagegateway"
+)
+
+type StorageGatewayClientInterface interface {
+	ListGatewaysPages(input *storagegateway.ListGatewaysInput, fn func(*storagegateway.ListGatewaysOutput, bool) bool) error
+	ListVolumesPages(input *storagegateway.ListVolumesInput, fn func(*storagegateway.ListVolumesOutput, bool) bool) error
+}
+
+// storage gateway documents no quota on the number of gateways, it is only
+// reported when servicequotas returns it or when overridden
+var storageGatewayDefaultQuotas = map[string]quotaDefault{
+	"Gateways per account": {Unknown: true},
+	"Volumes per gateway":  {Value: 32},
+}
+
+func NewStorageGatewayChecker() Svcquota {
+	serviceCode := "storagegateway"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Gateways per account": ServiceChecker.getStorageGatewayGatewaysUsage,
+		"Volumes per gateway":  ServiceChecker.getStorageGatewayVolumesPerGatewayUsage,
+	}
+	requiredPermissions := []string{
+		"storagegateway:ListGateways",
+		"storagegateway:ListVolumes",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func getStorageGateways() (ret []*storagegateway.GatewayInfo, err error) {
+	ret = []*storagegateway.GatewayInfo{}
+	err = conf.StorageGateway.ListGatewaysPages(&storagegateway.ListGatewaysInput{}, func(p *storagegateway.ListGatewaysOutput, lastPage bool) bool {
+		ret = append(ret, p.Gateways...)
+		return true // continue paging
+	})
+	return
+}
+
+func (c ServiceChecker) getStorageGatewayGatewaysUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	gateways, err := getStorageGateways()
+	if err != nil {
+		fmt.Printf("failed to retrieve storage gateways, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Gateways per account", storageGatewayDefaultQuotas["Gateways per account"])
+	quotaInfo.UsageValue = float64(len(gateways))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getStorageGatewayVolumesPerGatewayUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	gateways, err := getStorageGateways()
+	if err != nil {
+		fmt.Printf("failed to retrieve storage gateways, %v", err)
+		return
+	}
+
+	for _, gateway := range gateways {
+		quotaInfo := c.getAppliedQuotaOrDefault("Volumes per gateway", storageGatewayDefaultQuotas["Volumes per gateway"])
+		volumes := 0
+		errVolumes := conf.StorageGateway.ListVolumesPages(&storagegateway.ListVolumesInput{GatewayARN: gateway.GatewayARN}, func(p *storagegateway.ListVolumesOutput, lastPage bool) bool {
+			volumes += len(p.VolumeInfos)
+			return true // continue paging
+		})
+		if errVolumes != nil {
+			fmt.Printf("failed to retrieve volumes for gateway %s, %v", aws.StringValue(gateway.GatewayName), errVolumes)
+			continue
+		}
+
+		quotaInfo.UsageValue = float64(volumes)
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::StorageGateway::Gateway::%s", aws.StringValue(gateway.GatewayId))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/storagegateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedStorageGatewayClient struct {
+	StorageGatewayClientInterface
+	ListGatewaysPagesResp  storagegateway.ListGatewaysOutput
+	ListGatewaysPagesError error
+	ListVolumesPagesResp   storagegateway.ListVolumesOutput
+	ListVolumesPagesError  error
+}
+
+func (m mockedStorageGatewayClient) ListGatewaysPages(input *storagegateway.ListGatewaysInput, fn func(*storagegateway.ListGatewaysOutput, bool) bool) error {
+	return mockPages(m.ListGatewaysPagesResp, m.ListGatewaysPagesError, fn)
+}
+
+func (m mockedStorageGatewayClient) ListVolumesPages(input *storagegateway.ListVolumesInput, fn func(*storagegateway.ListVolumesOutput, bool) bool) error {
+	return mockPages(m.ListVolumesPagesResp, m.ListVolumesPagesError, fn)
+}
+
+func TestNewStorageGatewayCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewStorageGatewayChecker())
+}
+
+func TestGetStorageGatewayUsage(t *testing.T) {
+	conf.StorageGateway = mockedStorageGatewayClient{
+		ListGatewaysPagesResp: storagegateway.ListGatewaysOutput{
+			Gateways: []*storagegateway.GatewayInfo{
+				{GatewayId: aws.String("sgw-1")},
+				{GatewayId: aws.String("sgw-2")},
+			},
+		},
+		ListVolumesPagesResp: storagegateway.ListVolumesOutput{
+			VolumeInfos: []*storagegateway.VolumeInfo{{}, {}, {}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewStorageGatewayChecker)
+
+	gateways := svcChecker.getStorageGatewayGatewaysUsage()
+	assert.Len(t, gateways, 1)
+	assert.Equal(t, "storagegateway", gateways[0].Service)
+	assert.Equal(t, QuotaSourceUnknown, gateways[0].Source)
+	assert.Equal(t, float64(2), gateways[0].UsageValue)
+
+	svcChecker = newTestServiceChecker(NewStorageGatewayChecker, NewQuota("storagegateway", "Gateways per account", float64(10), false))
+	gateways = svcChecker.getStorageGatewayGatewaysUsage()
+	assert.Equal(t, QuotaSourceApplied, gateways[0].Source)
+	assert.Equal(t, float64(10), gateways[0].QuotaValue)
+
+	volumes := svcChecker.getStorageGatewayVolumesPerGatewayUsage()
+	assert.Len(t, volumes, 2)
+	assert.Equal(t, "AWS::StorageGateway::Gateway::sgw-1", volumes[0].ResourceId)
+	assert.Equal(t, float64(32), volumes[0].QuotaValue)
+	assert.Equal(t, float64(3), volumes[0].UsageValue)
+}
+
+func TestGetStorageGatewayUsageError(t *testing.T) {
+	conf.StorageGateway = mockedStorageGatewayClient{
+		ListGatewaysPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewStorageGatewayChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getStorageGatewayGatewaysUsage())
+	assert.Equal(t, expected, svcChecker.getStorageGatewayVolumesPerGatewayUsage())
+}
+
+func TestGetStorageGatewayVolumesPerGatewayUsageError(t *testing.T) {
+	conf.StorageGateway = mockedStorageGatewayClient{
+		ListGatewaysPagesResp: storagegateway.ListGatewaysOutput{
+			Gateways: []*storagegateway.GatewayInfo{{GatewayId: aws.String("sgw-1")}},
+		},
+		ListVolumesPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewStorageGatewayChecker)
+
+	assert.Equal(t, []AWSQuotaInfo{}, svcChecker.getStorageGatewayVolumesPerGatewayUsage())
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+type StsClientInterface interface {
+	GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error)
+}
+
+var accountId string
+
+// GetAccountId returns the id of the account the configured session runs
+// against. sts:GetCallerIdentity does not require any permission
+func GetAccountId() (string, error) {
+	if accountId != "" {
+		return accountId, nil
+	}
+	if conf.Sts == nil {
+		return "", fmt.Errorf("sts client not initialized")
+	}
+	result, err := conf.Sts.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve the account id, %v", err)
+	}
+	accountId = aws.StringValue(result.Account)
+	return accountId, nil
+}
+
+// GetCallerArn returns the arn of the principal the configured session runs as
+func GetCallerArn() (string, error) {
+	if conf.Sts == nil {
+		return "", fmt.Errorf("sts client not initialized")
+	}
+	result, err := conf.Sts.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve the caller identity, %v", err)
+	}
+	return aws.StringValue(result.Arn), nil
+}
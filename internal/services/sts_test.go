@@ -0,0 +1,56 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedStsClient struct {
+	StsClientInterface
+	GetCallerIdentityResp  sts.GetCallerIdentityOutput
+	GetCallerIdentityError error
+}
+
+func (m mockedStsClient) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+	return &m.GetCallerIdentityResp, m.GetCallerIdentityError
+}
+
+func TestGetAccountId(t *testing.T) {
+	conf.Sts = mockedStsClient{GetCallerIdentityResp: sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}}
+	t.Cleanup(func() { accountId = "" })
+
+	actual, err := GetAccountId()
+	assert.Nil(t, err)
+	assert.Equal(t, "123456789012", actual)
+
+	// the account id is cached
+	conf.Sts = mockedStsClient{GetCallerIdentityError: errors.New("test error")}
+	actual, err = GetAccountId()
+	assert.Nil(t, err)
+	assert.Equal(t, "123456789012", actual)
+}
+
+func TestGetAccountIdError(t *testing.T) {
+	conf.Sts = mockedStsClient{GetCallerIdentityError: errors.New("test error")}
+	_, err := GetAccountId()
+	assert.Error(t, err)
+	assert.Empty(t, accountId)
+}
+
+func TestGetCallerArn(t *testing.T) {
+	conf.Sts = mockedStsClient{GetCallerIdentityResp: sts.GetCallerIdentityOutput{Arn: aws.String("arn:aws:iam::123456789012:user/test")}}
+
+	actual, err := GetCallerArn()
+	assert.Nil(t, err)
+	assert.Equal(t, "arn:aws:iam::123456789012:user/test", actual)
+}
+
+func TestGetCallerArnError(t *testing.T) {
+	conf.Sts = mockedStsClient{GetCallerIdentityError: errors.New("test error")}
+	_, err := GetCallerArn()
+	assert.Error(t, err)
+}
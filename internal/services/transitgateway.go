@@ -0,0 +1,191 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+var transitGatewayDefaultQuotas = map[string]quotaDefault{
+	"Transit gateways per account":           {Value: 5},
+	"Attachments per transit gateway":        {Value: 5000},
+	"Routes per transit gateway route table": {Value: 10000},
+}
+
+func NewTransitGatewayChecker() Svcquota {
+	// transit gateway quotas are reported by servicequotas under ec2
+	serviceCode := "ec2"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Transit gateways per account":           ServiceChecker.getTransitGatewaysUsage,
+		"Attachments per transit gateway":        ServiceChecker.getTransitGatewayAttachmentsUsage,
+		"Routes per transit gateway route table": ServiceChecker.getTransitGatewayRoutesUsage,
+	}
+	requiredPermissions := []string{
+		"ec2:DescribeTransitGateways",
+		"ec2:DescribeTransitGatewayAttachments",
+		"ec2:DescribeTransitGatewayRouteTables",
+		"ec2:SearchTransitGatewayRoutes",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func getTransitGateways() (ret []*ec2.TransitGateway, err error) {
+	ret = []*ec2.TransitGateway{}
+	err = conf.Ec2.DescribeTransitGatewaysPages(&ec2.DescribeTransitGatewaysInput{}, func(p *ec2.DescribeTransitGatewaysOutput, lastPage bool) bool {
+		for _, tgw := range p.TransitGateways {
+			// deleted transit gateways remain visible for a while
+			if aws.StringValue(tgw.State) != ec2.TransitGatewayStateDeleted {
+				ret = append(ret, tgw)
+			}
+		}
+		return true // continue paging
+	})
+	return
+}
+
+func (c ServiceChecker) getTransitGatewaysUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	transitGateways, err := getTransitGateways()
+	if err != nil {
+		fmt.Printf("failed to retrieve transit gateways, %v", err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Transit gateways per account", transitGatewayDefaultQuotas["Transit gateways per account"])
+	quotaInfo.UsageValue = float64(len(transitGateways))
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getTransitGatewayAttachmentsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	transitGateways, err := getTransitGateways()
+	if err != nil {
+		fmt.Printf("failed to retrieve transit gateways, %v", err)
+		return
+	}
+
+	// attachments are listed for the whole region at once and grouped by
+	// transit gateway
+	attachmentsPerTgw := map[string]int{}
+	err = conf.Ec2.DescribeTransitGatewayAttachmentsPages(&ec2.DescribeTransitGatewayAttachmentsInput{}, func(p *ec2.DescribeTransitGatewayAttachmentsOutput, lastPage bool) bool {
+		for _, a := range p.TransitGatewayAttachments {
+			switch aws.StringValue(a.State) {
+			case ec2.TransitGatewayAttachmentStateDeleted,
+				ec2.TransitGatewayAttachmentStateFailed,
+				ec2.TransitGatewayAttachmentStateRejected:
+				continue
+			}
+			attachmentsPerTgw[aws.StringValue(a.TransitGatewayId)]++
+		}
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve transit gateway attachments, %v", err)
+		return
+	}
+
+	for _, tgw := range transitGateways {
+		quotaInfo := c.getAppliedQuotaOrDefault("Attachments per transit gateway", transitGatewayDefaultQuotas["Attachments per transit gateway"])
+		quotaInfo.UsageValue = float64(attachmentsPerTgw[aws.StringValue(tgw.TransitGatewayId)])
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::EC2::TransitGateway::%s", aws.StringValue(tgw.TransitGatewayId))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+func (c ServiceChecker) getTransitGatewayRoutesUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	routeTables := []*ec2.TransitGatewayRouteTable{}
+	err := conf.Ec2.DescribeTransitGatewayRouteTablesPages(&ec2.DescribeTransitGatewayRouteTablesInput{}, func(p *ec2.DescribeTransitGatewayRouteTablesOutput, lastPage bool) bool {
+		for _, rt := range p.TransitGatewayRouteTables {
+			if aws.StringValue(rt.State) != ec2.TransitGatewayRouteTableStateDeleted {
+				routeTables = append(routeTables, rt)
+			}
+		}
+		return true // continue paging
+	})
+	if err != nil {
+		fmt.Printf("failed to retrieve transit gateway route tables, %v", err)
+		return
+	}
+
+	for _, rt := range routeTables {
+		quotaInfo := c.getAppliedQuotaOrDefault("Routes per transit gateway route table", transitGatewayDefaultQuotas["Routes per transit gateway route table"])
+		routes, complete, errRoutes := c.countTransitGatewayRoutes(aws.StringValue(rt.TransitGatewayRouteTableId), nil)
+		if errRoutes != nil {
+			fmt.Printf("failed to retrieve routes for transit gateway route table %s, %v", aws.StringValue(rt.TransitGatewayRouteTableId), errRoutes)
+			continue
+		}
+		if !complete {
+			fmt.Printf("too many routes to count in transit gateway route table %s, usage is a lower bound\n", aws.StringValue(rt.TransitGatewayRouteTableId))
+		}
+
+		quotaInfo.UsageValue = float64(routes)
+		quotaInfo.ResourceId = fmt.Sprintf("AWS::EC2::TransitGatewayRouteTable::%s", aws.StringValue(rt.TransitGatewayRouteTableId))
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
+
+// transitGatewayRoutesMaxResults is the maximum number of routes a
+// SearchTransitGatewayRoutes call returns. The call is not paginated
+const transitGatewayRoutesMaxResults = 1000
+
+// countTransitGatewayRoutes counts the active and blackhole routes of a route
+// table. Searches are sharded by route type and state, then by attachment
+// resource type, until each shard holds less routes than a search returns.
+// complete is false if a shard still holds more
+func (c ServiceChecker) countTransitGatewayRoutes(routeTableId string, filters []*ec2.Filter) (count int, complete bool, err error) {
+	shards := [][]*ec2.Filter{}
+	switch len(filters) {
+	case 0:
+		for _, routeType := range ec2.TransitGatewayRouteType_Values() {
+			for _, state := range []string{ec2.TransitGatewayRouteStateActive, ec2.TransitGatewayRouteStateBlackhole} {
+				shards = append(shards, []*ec2.Filter{
+					{Name: aws.String("type"), Values: aws.StringSlice([]string{routeType})},
+					{Name: aws.String("state"), Values: aws.StringSlice([]string{state})},
+				})
+			}
+		}
+	default:
+		for _, resourceType := range ec2.TransitGatewayAttachmentResourceType_Values() {
+			shard := append([]*ec2.Filter{}, filters...)
+			shards = append(shards, append(shard, &ec2.Filter{
+				Name:   aws.String("attachment.resource-type"),
+				Values: aws.StringSlice([]string{resourceType}),
+			}))
+		}
+	}
+
+	complete = true
+	for _, shard := range shards {
+		result, errSearch := conf.Ec2.SearchTransitGatewayRoutes(&ec2.SearchTransitGatewayRoutesInput{
+			TransitGatewayRouteTableId: aws.String(routeTableId),
+			Filters:                    shard,
+			MaxResults:                 aws.Int64(transitGatewayRoutesMaxResults),
+		})
+		if errSearch != nil {
+			return 0, false, errSearch
+		}
+		if !aws.BoolValue(result.AdditionalRoutesAvailable) {
+			count += len(result.Routes)
+			continue
+		}
+		// the shard is split further once, by attachment resource type
+		if len(filters) == 0 {
+			shardCount, shardComplete, errShard := c.countTransitGatewayRoutes(routeTableId, shard)
+			if errShard != nil {
+				return 0, false, errShard
+			}
+			count += shardCount
+			complete = complete && shardComplete
+			continue
+		}
+		count += len(result.Routes)
+		complete = false
+	}
+	return
+}
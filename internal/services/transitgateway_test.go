@@ -0,0 +1,173 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mockedTransitGateways = ec2.DescribeTransitGatewaysOutput{
+	TransitGateways: []*ec2.TransitGateway{
+		{TransitGatewayId: aws.String("tgw-foo"), State: aws.String(ec2.TransitGatewayStateAvailable)},
+		{TransitGatewayId: aws.String("tgw-bar"), State: aws.String(ec2.TransitGatewayStateAvailable)},
+		{TransitGatewayId: aws.String("tgw-baz"), State: aws.String(ec2.TransitGatewayStateDeleted)},
+	},
+}
+
+func TestNewTransitGatewayCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewTransitGatewayChecker())
+}
+
+func TestGetTransitGatewaysUsage(t *testing.T) {
+	conf.Ec2 = mockedEc2Client{DescribeTransitGatewaysPagesResp: mockedTransitGateways}
+	svcChecker := newTestServiceChecker(NewTransitGatewayChecker, NewQuota("ec2", "Transit gateways per account", float64(10), false))
+	actual := svcChecker.getTransitGatewaysUsage()
+
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "ec2", actual[0].Service)
+	assert.Equal(t, float64(10), actual[0].QuotaValue)
+	assert.Equal(t, float64(2), actual[0].UsageValue)
+}
+
+func TestGetTransitGatewaysUsageError(t *testing.T) {
+	conf.Ec2 = mockedEc2Client{
+		DescribeTransitGatewaysPagesError:           errors.New("test error"),
+		DescribeTransitGatewayRouteTablesPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewTransitGatewayChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getTransitGatewaysUsage())
+	assert.Equal(t, expected, svcChecker.getTransitGatewayAttachmentsUsage())
+	assert.Equal(t, expected, svcChecker.getTransitGatewayRoutesUsage())
+}
+
+func TestGetTransitGatewayAttachmentsUsage(t *testing.T) {
+	conf.Ec2 = mockedEc2Client{
+		DescribeTransitGatewaysPagesResp: mockedTransitGateways,
+		DescribeTransitGatewayAttachmentsPagesResp: ec2.DescribeTransitGatewayAttachmentsOutput{
+			TransitGatewayAttachments: []*ec2.TransitGatewayAttachment{
+				{TransitGatewayId: aws.String("tgw-bar"), State: aws.String(ec2.TransitGatewayAttachmentStateAvailable)},
+				{TransitGatewayId: aws.String("tgw-bar"), State: aws.String(ec2.TransitGatewayAttachmentStatePending)},
+				{TransitGatewayId: aws.String("tgw-bar"), State: aws.String(ec2.TransitGatewayAttachmentStateDeleted)},
+			},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewTransitGatewayChecker)
+	actual := svcChecker.getTransitGatewayAttachmentsUsage()
+
+	assert.Len(t, actual, 2)
+	assert.Equal(t, "AWS::EC2::TransitGateway::tgw-foo", actual[0].ResourceId)
+	assert.Equal(t, float64(0), actual[0].UsageValue)
+	assert.Equal(t, "AWS::EC2::TransitGateway::tgw-bar", actual[1].ResourceId)
+	assert.Equal(t, float64(5000), actual[1].QuotaValue)
+	assert.Equal(t, float64(2), actual[1].UsageValue)
+}
+
+func TestGetTransitGatewayAttachmentsUsageError(t *testing.T) {
+	conf.Ec2 = mockedEc2Client{
+		DescribeTransitGatewaysPagesResp:            mockedTransitGateways,
+		DescribeTransitGatewayAttachmentsPagesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewTransitGatewayChecker)
+	actual := svcChecker.getTransitGatewayAttachmentsUsage()
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, actual)
+}
+
+func TestGetTransitGatewayRoutesUsage(t *testing.T) {
+	conf.Ec2 = mockedEc2Client{
+		DescribeTransitGatewayRouteTablesPagesResp: ec2.DescribeTransitGatewayRouteTablesOutput{
+			TransitGatewayRouteTables: []*ec2.TransitGatewayRouteTable{
+				{TransitGatewayRouteTableId: aws.String("tgw-rtb-foo"), State: aws.String(ec2.TransitGatewayRouteTableStateAvailable)},
+				{TransitGatewayRouteTableId: aws.String("tgw-rtb-bar"), State: aws.String(ec2.TransitGatewayRouteTableStateDeleted)},
+			},
+		},
+		SearchTransitGatewayRoutesResp: ec2.SearchTransitGatewayRoutesOutput{
+			Routes: []*ec2.TransitGatewayRoute{{}, {}, {}},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewTransitGatewayChecker)
+	actual := svcChecker.getTransitGatewayRoutesUsage()
+
+	// routes are searched by type and state: static/propagated, active/blackhole
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "AWS::EC2::TransitGatewayRouteTable::tgw-rtb-foo", actual[0].ResourceId)
+	assert.Equal(t, float64(10000), actual[0].QuotaValue)
+	assert.Equal(t, float64(12), actual[0].UsageValue)
+}
+
+func TestCountTransitGatewayRoutesSharded(t *testing.T) {
+	routes := func(n int) (ret []*ec2.TransitGatewayRoute) {
+		for i := 0; i < n; i++ {
+			ret = append(ret, &ec2.TransitGatewayRoute{})
+		}
+		return
+	}
+	filterValue := func(input *ec2.SearchTransitGatewayRoutesInput, name string) string {
+		for _, f := range input.Filters {
+			if aws.StringValue(f.Name) == name {
+				return aws.StringValue(f.Values[0])
+			}
+		}
+		return ""
+	}
+	// 1500 propagated active routes from vpcs, 1200 from vpns, 10 static ones
+	conf.Ec2 = mockedEc2Client{SearchTransitGatewayRoutesFn: func(input *ec2.SearchTransitGatewayRoutesInput) *ec2.SearchTransitGatewayRoutesOutput {
+		if filterValue(input, "state") != ec2.TransitGatewayRouteStateActive {
+			return &ec2.SearchTransitGatewayRoutesOutput{}
+		}
+		if filterValue(input, "type") == ec2.TransitGatewayRouteTypeStatic {
+			return &ec2.SearchTransitGatewayRoutesOutput{Routes: routes(10)}
+		}
+		switch filterValue(input, "attachment.resource-type") {
+		case "", ec2.TransitGatewayAttachmentResourceTypeVpc, ec2.TransitGatewayAttachmentResourceTypeVpn:
+			return &ec2.SearchTransitGatewayRoutesOutput{Routes: routes(1000), AdditionalRoutesAvailable: aws.Bool(true)}
+		}
+		return &ec2.SearchTransitGatewayRoutesOutput{}
+	}}
+
+	svcChecker := newTestServiceChecker(NewTransitGatewayChecker)
+	count, complete, err := svcChecker.countTransitGatewayRoutes("tgw-rtb-foo", nil)
+	assert.Nil(t, err)
+	// the vpc and vpn shards are still truncated, the count is a lower bound
+	assert.False(t, complete)
+	assert.Equal(t, 2010, count)
+
+	conf.Ec2 = mockedEc2Client{SearchTransitGatewayRoutesFn: func(input *ec2.SearchTransitGatewayRoutesInput) *ec2.SearchTransitGatewayRoutesOutput {
+		if filterValue(input, "state") != ec2.TransitGatewayRouteStateActive || filterValue(input, "type") != ec2.TransitGatewayRouteTypePropagated {
+			return &ec2.SearchTransitGatewayRoutesOutput{}
+		}
+		switch filterValue(input, "attachment.resource-type") {
+		case "":
+			return &ec2.SearchTransitGatewayRoutesOutput{Routes: routes(1000), AdditionalRoutesAvailable: aws.Bool(true)}
+		case ec2.TransitGatewayAttachmentResourceTypeVpc:
+			return &ec2.SearchTransitGatewayRoutesOutput{Routes: routes(900)}
+		case ec2.TransitGatewayAttachmentResourceTypeVpn:
+			return &ec2.SearchTransitGatewayRoutesOutput{Routes: routes(800)}
+		}
+		return &ec2.SearchTransitGatewayRoutesOutput{}
+	}}
+	count, complete, err = svcChecker.countTransitGatewayRoutes("tgw-rtb-foo", nil)
+	assert.Nil(t, err)
+	assert.True(t, complete)
+	assert.Equal(t, 1700, count)
+}
+
+func TestGetTransitGatewayRoutesUsageError(t *testing.T) {
+	conf.Ec2 = mockedEc2Client{
+		DescribeTransitGatewayRouteTablesPagesResp: ec2.DescribeTransitGatewayRouteTablesOutput{
+			TransitGatewayRouteTables: []*ec2.TransitGatewayRouteTable{{TransitGatewayRouteTableId: aws.String("tgw-rtb-foo")}},
+		},
+		SearchTransitGatewayRoutesError: errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewTransitGatewayChecker)
+	actual := svcChecker.getTransitGatewayRoutesUsage()
+
+	assert.Len(t, actual, 0)
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+)
+
+// maximum number of queries accepted by a single GetMetricData call
+const usageMetricsMaxQueries = 500
+
+// usage metrics are queried by one minute data points, as the servicequotas
+// console does, over the last hour, and the usage is the highest data point
+const (
+	usageMetricsPeriod = time.Minute
+	usageMetricsWindow = time.Hour
+)
+
+// UsageMetricChecker retrieves the usage of any quota servicequotas associates
+// with a cloudwatch usage metric (AWS/Usage namespace), without a dedicated
+// function per quota
+type UsageMetricChecker struct {
+	*ServiceChecker
+	// quotas covered by hand-written checkers, which take precedence
+	HandledQuotas map[string]bool
+	// tells whether a quota is selected, quotas not selected are not queried
+	QuotaFilter func(quotaName string) bool
+}
+
+// NewUsageMetricChecker returns a checker of the usage metrics of the given
+// service code, for the quotas the filter selects (all of them if nil)
+func NewUsageMetricChecker(serviceCode string, handledQuotas []string, quotaFilter func(string) bool) Svcquota {
+	requiredPermissions := []string{
+		"servicequotas:ListServiceQuotas",
+		"servicequotas:ListAWSDefaultServiceQuotas",
+		"cloudwatch:GetMetricData",
+	}
+	c := &UsageMetricChecker{
+		ServiceChecker: NewServiceChecker(serviceCode, nil, requiredPermissions).(*ServiceChecker),
+		HandledQuotas:  map[string]bool{},
+		QuotaFilter:    quotaFilter,
+	}
+	for _, quotaName := range handledQuotas {
+		c.HandledQuotas[quotaName] = true
+	}
+	return c
+}
+
+// getUsageMetricQuotas returns the selected quotas with a usage metric not
+// handled by a hand-written checker, sorted by name
+func (c UsageMetricChecker) getUsageMetricQuotas() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	for name, quota := range c.GetAllAppliedQuotas() {
+		if quota.UsageMetric == nil || c.HandledQuotas[name] || (c.QuotaFilter != nil && !c.QuotaFilter(name)) {
+			continue
+		}
+		ret = append(ret, quota)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].QuotaName < ret[j].QuotaName
+	})
+	return
+}
+
+// usageMetricPeriod returns the period to query the usage metric of a quota
+// at, and the factor to apply to its data points. The sums of rate quotas are
+// queried over the quota period, or normalised to it when it is shorter than
+// the shortest cloudwatch period
+func usageMetricPeriod(quota AWSQuotaInfo) (period time.Duration, factor float64) {
+	period, factor = usageMetricsPeriod, 1
+	if quota.Period == 0 || quota.UsageMetric.Statistic != cloudwatch.StatisticSum {
+		return
+	}
+	if quota.Period < usageMetricsPeriod {
+		factor = float64(quota.Period) / float64(usageMetricsPeriod)
+		return
+	}
+	// cloudwatch periods are multiples of a minute
+	period = quota.Period.Truncate(usageMetricsPeriod)
+	return
+}
+
+func usageMetricQuery(id string, metric *AWSQuotaUsageMetric, period time.Duration) *cloudwatch.MetricDataQuery {
+	dimensions := []*cloudwatch.Dimension{}
+	for name, value := range metric.Dimensions {
+		dimensions = append(dimensions, &cloudwatch.Dimension{Name: aws.String(name), Value: aws.String(value)})
+	}
+	sort.Slice(dimensions, func(i, j int) bool {
+		return aws.StringValue(dimensions[i].Name) < aws.StringValue(dimensions[j].Name)
+	})
+
+	statistic := metric.Statistic
+	if statistic == "" {
+		statistic = cloudwatch.StatisticMaximum
+	}
+	return &cloudwatch.MetricDataQuery{
+		Id: aws.String(id),
+		MetricStat: &cloudwatch.MetricStat{
+			Metric: &cloudwatch.Metric{
+				Namespace:  aws.String(metric.Namespace),
+				MetricName: aws.String(metric.Name),
+				Dimensions: dimensions,
+			},
+			Period: aws.Int64(int64(period.Seconds())),
+			Stat:   aws.String(statistic),
+		},
+		ReturnData: aws.Bool(true),
+	}
+}
+
+// getUsageMetricValues queries the usage metrics of the given quotas, by
+// batches, and returns the highest value of each, indexed like the quotas.
+// Metrics without data points have no usage
+func getUsageMetricValues(quotas []AWSQuotaInfo) (ret []float64, err error) {
+	ret = make([]float64, len(quotas))
+	endTime := time.Now()
+
+	for start := 0; start < len(quotas); start += usageMetricsMaxQueries {
+		end := start + usageMetricsMaxQueries
+		if end > len(quotas) {
+			end = len(quotas)
+		}
+
+		queries := []*cloudwatch.MetricDataQuery{}
+		indexes := map[string]int{}
+		factors := map[string]float64{}
+		window := usageMetricsWindow
+		for i := start; i < end; i++ {
+			id := fmt.Sprintf("q%d", i)
+			period, factor := usageMetricPeriod(quotas[i])
+			if period > window {
+				window = period
+			}
+			queries = append(queries, usageMetricQuery(id, quotas[i].UsageMetric, period))
+			indexes[id] = i
+			factors[id] = factor
+		}
+
+		input := &cloudwatch.GetMetricDataInput{
+			MetricDataQueries: queries,
+			StartTime:         aws.Time(endTime.Add(-window)),
+			EndTime:           aws.Time(endTime),
+		}
+		err = conf.Cloudwatch.GetMetricDataPages(input, func(p *cloudwatch.GetMetricDataOutput, lastPage bool) bool {
+			for _, result := range p.MetricDataResults {
+				id := aws.StringValue(result.Id)
+				i, ok := indexes[id]
+				if !ok {
+					continue
+				}
+				for _, value := range result.Values {
+					if v := aws.Float64Value(value) * factors[id]; v > ret[i] {
+						ret[i] = v
+					}
+				}
+			}
+			return true // continue paging
+		})
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (c UsageMetricChecker) GetUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	quotas := c.getUsageMetricQuotas()
+	values, err := getUsageMetricValues(quotas)
+	if err != nil {
+		fmt.Printf("failed to retrieve usage metrics for service %s, %v", c.ServiceCode, err)
+		return
+	}
+
+	for i, quotaInfo := range quotas {
+		quotaInfo.Region = c.Region
+		quotaInfo.UsageValue = values[i]
+		ret = append(ret, quotaInfo)
+	}
+	return
+}
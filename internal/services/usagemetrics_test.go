@@ -0,0 +1,190 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func NewQuotaWithUsageMetric(svcName string, quotaName string, quotaValue float64, metricName string) *servicequotas.ServiceQuota {
+	quota := NewQuota(svcName, quotaName, quotaValue, false)
+	quota.UsageMetric = &servicequotas.MetricInfo{
+		MetricNamespace: aws.String("AWS/Usage"),
+		MetricName:      aws.String(metricName),
+		MetricDimensions: map[string]*string{
+			"Service":  aws.String(svcName),
+			"Resource": aws.String(quotaName),
+		},
+		MetricStatisticRecommendation: aws.String("Maximum"),
+	}
+	return quota
+}
+
+func TestNewUsageMetricCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewUsageMetricChecker("ec2", nil, nil))
+}
+
+func TestUsageMetricCheckerGetUsage(t *testing.T) {
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{
+			NewQuotaWithUsageMetric("ec2", "Running On-Demand Standard instances", float64(640), "ResourceCount"),
+			NewQuotaWithUsageMetric("ec2", "EC2-VPC Elastic IPs", float64(5), "ResourceCount"),
+			NewQuotaWithUsageMetric("ec2", "Transit gateways per account", float64(5), "ResourceCount"),
+			NewQuota("ec2", "AMIs", float64(50000), false),
+		},
+		nil)
+	// results are identified by the position of the quota, sorted by name
+	conf.Cloudwatch = mockedCloudwatchClient{
+		GetMetricDataPagesResp: cloudwatch.GetMetricDataOutput{
+			MetricDataResults: []*cloudwatch.MetricDataResult{
+				{Id: aws.String("q0"), Values: []*float64{aws.Float64(2), aws.Float64(3)}},
+				{Id: aws.String("q1"), Values: []*float64{aws.Float64(128)}},
+			},
+		},
+	}
+
+	usageMetricChecker := NewUsageMetricChecker("ec2", []string{"Transit gateways per account"}, nil)
+	usage := usageMetricChecker.GetUsage()
+
+	// quotas without usage metric, or handled by a hand-written checker, are skipped
+	assert.Len(t, usage, 2)
+	assert.Equal(t, "EC2-VPC Elastic IPs", usage[0].QuotaName)
+	assert.Equal(t, float64(5), usage[0].QuotaValue)
+	assert.Equal(t, float64(3), usage[0].UsageValue)
+	assert.Equal(t, "Running On-Demand Standard instances", usage[1].QuotaName)
+	assert.Equal(t, float64(640), usage[1].QuotaValue)
+	assert.Equal(t, float64(128), usage[1].UsageValue)
+}
+
+func TestUsageMetricCheckerGetUsageFiltered(t *testing.T) {
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{
+			NewQuotaWithUsageMetric("ec2", "Running On-Demand Standard instances", float64(640), "ResourceCount"),
+			NewQuotaWithUsageMetric("ec2", "EC2-VPC Elastic IPs", float64(5), "ResourceCount"),
+		},
+		nil)
+	conf.Cloudwatch = mockedCloudwatchClient{
+		GetMetricDataPagesResp: cloudwatch.GetMetricDataOutput{
+			MetricDataResults: []*cloudwatch.MetricDataResult{{Id: aws.String("q0"), Values: []*float64{aws.Float64(128)}}},
+		},
+	}
+
+	usageMetricChecker := NewUsageMetricChecker("ec2", nil, func(quotaName string) bool {
+		return quotaName == "Running On-Demand Standard instances"
+	})
+	usage := usageMetricChecker.GetUsage()
+
+	// quotas not selected are not queried, the selected one is the first query
+	assert.Len(t, usage, 1)
+	assert.Equal(t, "Running On-Demand Standard instances", usage[0].QuotaName)
+	assert.Equal(t, float64(128), usage[0].UsageValue)
+}
+
+func TestUsageMetricCheckerGetUsageError(t *testing.T) {
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas(
+		[]*servicequotas.ServiceQuota{NewQuotaWithUsageMetric("ec2", "EC2-VPC Elastic IPs", float64(5), "ResourceCount")},
+		nil)
+	conf.Cloudwatch = mockedCloudwatchClient{
+		GetMetricDataPagesError: errors.New("test error"),
+	}
+
+	usageMetricChecker := NewUsageMetricChecker("ec2", nil, nil)
+	assert.Equal(t, []AWSQuotaInfo{}, usageMetricChecker.GetUsage())
+}
+
+func TestUsageMetricCheckerGetRequiredPermissions(t *testing.T) {
+	usageMetricChecker := NewUsageMetricChecker("ec2", nil, nil)
+	assert.Contains(t, usageMetricChecker.GetRequiredPermissions(), "cloudwatch:GetMetricData")
+	assert.Len(t, usageMetricChecker.GetRequiredPermissions(), 3) // servicequotas permissions are not duplicated
+}
+
+func TestUsageMetricQuery(t *testing.T) {
+	query := usageMetricQuery("q0", &AWSQuotaUsageMetric{
+		Namespace:  "AWS/Usage",
+		Name:       "ResourceCount",
+		Dimensions: map[string]string{"Type": "Resource", "Class": "None", "Service": "EC2"},
+	}, time.Minute)
+
+	assert.Equal(t, "q0", aws.StringValue(query.Id))
+	assert.Equal(t, "AWS/Usage", aws.StringValue(query.MetricStat.Metric.Namespace))
+	assert.Equal(t, "ResourceCount", aws.StringValue(query.MetricStat.Metric.MetricName))
+	assert.Equal(t, cloudwatch.StatisticMaximum, aws.StringValue(query.MetricStat.Stat))
+	assert.Equal(t, int64(60), aws.Int64Value(query.MetricStat.Period))
+	assert.Len(t, query.MetricStat.Metric.Dimensions, 3)
+	assert.Equal(t, "Class", aws.StringValue(query.MetricStat.Metric.Dimensions[0].Name))
+	assert.Equal(t, "Type", aws.StringValue(query.MetricStat.Metric.Dimensions[2].Name))
+}
+
+func TestGetUsageMetricValuesBatches(t *testing.T) {
+	quotas := []AWSQuotaInfo{}
+	for i := 0; i < usageMetricsMaxQueries+1; i++ {
+		quotas = append(quotas, AWSQuotaInfo{UsageMetric: &AWSQuotaUsageMetric{Namespace: "AWS/Usage", Name: fmt.Sprintf("metric%d", i)}})
+	}
+	// the mock returns the same page for every batch, results of other batches
+	// are ignored
+	conf.Cloudwatch = mockedCloudwatchClient{
+		GetMetricDataPagesResp: cloudwatch.GetMetricDataOutput{
+			MetricDataResults: []*cloudwatch.MetricDataResult{
+				{Id: aws.String("q0"), Values: []*float64{aws.Float64(1)}},
+				{Id: aws.String(fmt.Sprintf("q%d", usageMetricsMaxQueries)), Values: []*float64{aws.Float64(7)}},
+			},
+		},
+	}
+
+	values, err := getUsageMetricValues(quotas)
+	assert.Nil(t, err)
+	assert.Len(t, values, usageMetricsMaxQueries+1)
+	assert.Equal(t, float64(1), values[0])
+	assert.Equal(t, float64(0), values[1])
+	assert.Equal(t, float64(7), values[usageMetricsMaxQueries])
+}
+
+func TestUsageMetricPeriod(t *testing.T) {
+	sumMetric := &AWSQuotaUsageMetric{Statistic: cloudwatch.StatisticSum}
+	maxMetric := &AWSQuotaUsageMetric{Statistic: cloudwatch.StatisticMaximum}
+
+	period, factor := usageMetricPeriod(AWSQuotaInfo{UsageMetric: maxMetric})
+	assert.Equal(t, time.Minute, period)
+	assert.Equal(t, float64(1), factor)
+
+	// sums of rate quotas shorter than a minute are normalised to the quota period
+	period, factor = usageMetricPeriod(AWSQuotaInfo{UsageMetric: sumMetric, Period: time.Second})
+	assert.Equal(t, time.Minute, period)
+	assert.Equal(t, float64(1)/60, factor)
+
+	// and queried over the quota period otherwise
+	period, factor = usageMetricPeriod(AWSQuotaInfo{UsageMetric: sumMetric, Period: time.Hour})
+	assert.Equal(t, time.Hour, period)
+	assert.Equal(t, float64(1), factor)
+}
+
+func TestUsageMetricCheckerGetUsageRateQuota(t *testing.T) {
+	quota := NewQuotaWithUsageMetric("sts", "Rate of AssumeRole requests", float64(600), "CallCount")
+	quota.UsageMetric.MetricStatisticRecommendation = aws.String(cloudwatch.StatisticSum)
+	quota.Period = &servicequotas.QuotaPeriod{PeriodUnit: aws.String(servicequotas.PeriodUnitSecond), PeriodValue: aws.Int64(1)}
+	conf.ServiceQuotas = NewSvcQuotaMockListServiceQuotas([]*servicequotas.ServiceQuota{quota}, nil)
+	inputs := []*cloudwatch.GetMetricDataInput{}
+	conf.Cloudwatch = mockedCloudwatchClient{
+		GetMetricDataPagesResp: cloudwatch.GetMetricDataOutput{
+			MetricDataResults: []*cloudwatch.MetricDataResult{{Id: aws.String("q0"), Values: []*float64{aws.Float64(1200), aws.Float64(6000)}}},
+		},
+		GetMetricDataInputs: &inputs,
+	}
+
+	usage := NewUsageMetricChecker("sts", nil, nil).GetUsage()
+
+	// 6000 calls in the busiest minute are 100 calls per second
+	assert.Len(t, usage, 1)
+	assert.Equal(t, time.Second, usage[0].Period)
+	assert.Equal(t, float64(100), usage[0].UsageValue)
+	assert.Len(t, inputs, 1)
+	assert.Equal(t, int64(60), aws.Int64Value(inputs[0].MetricDataQueries[0].MetricStat.Period))
+	assert.Equal(t, time.Hour, inputs[0].EndTime.Sub(*inputs[0].StartTime))
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+var vpnDefaultQuotas = map[string]quotaDefault{
+	"Site-to-Site VPN connections per Region": {Value: 50},
+	"Customer gateways per Region":            {Value: 50},
+	"Virtual private gateways per Region":     {Value: 5},
+}
+
+func NewVpnChecker() Svcquota {
+	// site-to-site vpn quotas are reported by servicequotas under ec2
+	serviceCode := "ec2"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Site-to-Site VPN connections per Region": ServiceChecker.getVpnConnectionsUsage,
+		"Customer gateways per Region":            ServiceChecker.getVpnCustomerGatewaysUsage,
+		"Virtual private gateways per Region":     ServiceChecker.getVpnGatewaysUsage,
+	}
+	requiredPermissions := []string{
+		"ec2:DescribeVpnConnections",
+		"ec2:DescribeCustomerGateways",
+		"ec2:DescribeVpnGateways",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+func (c ServiceChecker) getVpnConnectionsUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	result, err := conf.Ec2.DescribeVpnConnections(&ec2.DescribeVpnConnectionsInput{})
+	if err != nil {
+		fmt.Printf("failed to retrieve vpn connections, %v", err)
+		return
+	}
+
+	connections := 0
+	for _, v := range result.VpnConnections {
+		// deleted connections remain visible for a while
+		if aws.StringValue(v.State) != ec2.VpnStateDeleted {
+			connections++
+		}
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Site-to-Site VPN connections per Region", vpnDefaultQuotas["Site-to-Site VPN connections per Region"])
+	quotaInfo.UsageValue = float64(connections)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getVpnCustomerGatewaysUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	result, err := conf.Ec2.DescribeCustomerGateways(&ec2.DescribeCustomerGatewaysInput{})
+	if err != nil {
+		fmt.Printf("failed to retrieve customer gateways, %v", err)
+		return
+	}
+
+	gateways := 0
+	for _, g := range result.CustomerGateways {
+		if aws.StringValue(g.State) != ec2.VpnStateDeleted {
+			gateways++
+		}
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Customer gateways per Region", vpnDefaultQuotas["Customer gateways per Region"])
+	quotaInfo.UsageValue = float64(gateways)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func (c ServiceChecker) getVpnGatewaysUsage() (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	result, err := conf.Ec2.DescribeVpnGateways(&ec2.DescribeVpnGatewaysInput{})
+	if err != nil {
+		fmt.Printf("failed to retrieve virtual private gateways, %v", err)
+		return
+	}
+
+	gateways := 0
+	for _, g := range result.VpnGateways {
+		if aws.StringValue(g.State) != ec2.VpnStateDeleted {
+			gateways++
+		}
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault("Virtual private gateways per Region", vpnDefaultQuotas["Virtual private gateways per Region"])
+	quotaInfo.UsageValue = float64(gateways)
+	ret = append(ret, quotaInfo)
+	return
+}
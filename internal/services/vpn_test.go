@@ -0,0 +1,68 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVpnCheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewVpnChecker())
+}
+
+func TestGetVpnUsage(t *testing.T) {
+	conf.Ec2 = mockedEc2Client{
+		DescribeVpnConnectionsResp: ec2.DescribeVpnConnectionsOutput{
+			VpnConnections: []*ec2.VpnConnection{
+				{State: aws.String(ec2.VpnStateAvailable)},
+				{State: aws.String(ec2.VpnStateDeleted)},
+			},
+		},
+		DescribeCustomerGatewaysResp: ec2.DescribeCustomerGatewaysOutput{
+			CustomerGateways: []*ec2.CustomerGateway{
+				{State: aws.String(ec2.VpnStateAvailable)},
+				{State: aws.String(ec2.VpnStatePending)},
+			},
+		},
+		DescribeVpnGatewaysResp: ec2.DescribeVpnGatewaysOutput{
+			VpnGateways: []*ec2.VpnGateway{
+				{State: aws.String(ec2.VpnStateDeleted)},
+			},
+		},
+	}
+	svcChecker := newTestServiceChecker(NewVpnChecker, NewQuota("ec2", "Site-to-Site VPN connections per Region", float64(100), false))
+
+	connections := svcChecker.getVpnConnectionsUsage()
+	assert.Len(t, connections, 1)
+	assert.Equal(t, "ec2", connections[0].Service)
+	assert.Equal(t, float64(100), connections[0].QuotaValue)
+	assert.Equal(t, float64(1), connections[0].UsageValue)
+
+	customerGateways := svcChecker.getVpnCustomerGatewaysUsage()
+	assert.Len(t, customerGateways, 1)
+	assert.Equal(t, float64(50), customerGateways[0].QuotaValue)
+	assert.Equal(t, float64(2), customerGateways[0].UsageValue)
+
+	vpnGateways := svcChecker.getVpnGatewaysUsage()
+	assert.Len(t, vpnGateways, 1)
+	assert.Equal(t, float64(5), vpnGateways[0].QuotaValue)
+	assert.Equal(t, float64(0), vpnGateways[0].UsageValue)
+}
+
+func TestGetVpnUsageError(t *testing.T) {
+	conf.Ec2 = mockedEc2Client{
+		DescribeVpnConnectionsError:   errors.New("test error"),
+		DescribeCustomerGatewaysError: errors.New("test error"),
+		DescribeVpnGatewaysError:      errors.New("test error"),
+	}
+	svcChecker := newTestServiceChecker(NewVpnChecker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getVpnConnectionsUsage())
+	assert.Equal(t, expected, svcChecker.getVpnCustomerGatewaysUsage())
+	assert.Equal(t, expected, svcChecker.getVpnGatewaysUsage())
+}
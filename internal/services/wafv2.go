@@ -0,0 +1,177 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+)
+
+type Wafv2ClientInterface interface {
+	ListWebACLs(input *wafv2.ListWebACLsInput) (*wafv2.ListWebACLsOutput, error)
+	ListRuleGroups(input *wafv2.ListRuleGroupsInput) (*wafv2.ListRuleGroupsOutput, error)
+	ListIPSets(input *wafv2.ListIPSetsInput) (*wafv2.ListIPSetsOutput, error)
+	ListRegexPatternSets(input *wafv2.ListRegexPatternSetsInput) (*wafv2.ListRegexPatternSetsOutput, error)
+}
+
+var wafv2DefaultQuotas = map[string]quotaDefault{
+	"Maximum web ACLs per account in WAF for CloudFront":           {Value: 100},
+	"Maximum web ACLs per account in WAF for regional":             {Value: 100},
+	"Maximum rule groups per account in WAF for CloudFront":        {Value: 100},
+	"Maximum rule groups per account in WAF for regional":          {Value: 100},
+	"Maximum IP set per account in WAF for CloudFront":             {Value: 100},
+	"Maximum IP set per account in WAF for regional":               {Value: 100},
+	"Maximum regex pattern sets per account in WAF for CloudFront": {Value: 10},
+	"Maximum regex pattern sets per account in WAF for regional":   {Value: 10},
+}
+
+// maximum page size accepted by the wafv2 list apis
+const wafv2ListLimit = 100
+
+func NewWafv2Checker() Svcquota {
+	serviceCode := "wafv2"
+	supportedQuotas := map[string]func(ServiceChecker) (ret []AWSQuotaInfo){
+		"Maximum web ACLs per account in WAF for CloudFront":           ServiceChecker.getWafv2CloudfrontWebAclsUsage,
+		"Maximum web ACLs per account in WAF for regional":             ServiceChecker.getWafv2RegionalWebAclsUsage,
+		"Maximum rule groups per account in WAF for CloudFront":        ServiceChecker.getWafv2CloudfrontRuleGroupsUsage,
+		"Maximum rule groups per account in WAF for regional":          ServiceChecker.getWafv2RegionalRuleGroupsUsage,
+		"Maximum IP set per account in WAF for CloudFront":             ServiceChecker.getWafv2CloudfrontIpSetsUsage,
+		"Maximum IP set per account in WAF for regional":               ServiceChecker.getWafv2RegionalIpSetsUsage,
+		"Maximum regex pattern sets per account in WAF for CloudFront": ServiceChecker.getWafv2CloudfrontRegexPatternSetsUsage,
+		"Maximum regex pattern sets per account in WAF for regional":   ServiceChecker.getWafv2RegionalRegexPatternSetsUsage,
+	}
+	requiredPermissions := []string{
+		"wafv2:ListWebACLs",
+		"wafv2:ListRuleGroups",
+		"wafv2:ListIPSets",
+		"wafv2:ListRegexPatternSets",
+	}
+
+	return NewServiceChecker(serviceCode, supportedQuotas, requiredPermissions)
+}
+
+// getWafv2Client returns the client to use for the given scope. CloudFront
+// resources are global and can only be listed through us-east-1
+func getWafv2Client(scope string) Wafv2ClientInterface {
+	if scope == wafv2.ScopeCloudfront {
+		return conf.Wafv2Cloudfront
+	}
+	return conf.Wafv2
+}
+
+// countWafv2Resources follows the NextMarker of a wafv2 list api. list returns
+// the number of resources of a page and the marker of the next one
+func countWafv2Resources(list func(marker *string) (int, *string, error)) (ret int, err error) {
+	var marker *string
+	for {
+		count, next, errList := list(marker)
+		if errList != nil {
+			return 0, errList
+		}
+		ret += count
+		if next == nil {
+			break
+		}
+		marker = next
+	}
+	return
+}
+
+// getWafv2ScopeUsage builds the quota info for a resource count of the given
+// scope. Resources of the CLOUDFRONT scope are global
+func (c ServiceChecker) getWafv2ScopeUsage(quotaName string, scope string, count func(client Wafv2ClientInterface) (int, error)) (ret []AWSQuotaInfo) {
+	ret = []AWSQuotaInfo{}
+	usage, err := count(getWafv2Client(scope))
+	if err != nil {
+		fmt.Printf("failed to retrieve usage for %s, %v", quotaName, err)
+		return
+	}
+
+	quotaInfo := c.getAppliedQuotaOrDefault(quotaName, wafv2DefaultQuotas[quotaName])
+	if scope == wafv2.ScopeCloudfront {
+		quotaInfo.Global = true
+	}
+	quotaInfo.UsageValue = float64(usage)
+	ret = append(ret, quotaInfo)
+	return
+}
+
+func countWafv2WebAcls(scope string) func(client Wafv2ClientInterface) (int, error) {
+	return func(client Wafv2ClientInterface) (int, error) {
+		return countWafv2Resources(func(marker *string) (int, *string, error) {
+			result, err := client.ListWebACLs(&wafv2.ListWebACLsInput{Scope: aws.String(scope), NextMarker: marker, Limit: aws.Int64(wafv2ListLimit)})
+			if err != nil {
+				return 0, nil, err
+			}
+			return len(result.WebACLs), result.NextMarker, nil
+		})
+	}
+}
+
+func countWafv2RuleGroups(scope string) func(client Wafv2ClientInterface) (int, error) {
+	return func(client Wafv2ClientInterface) (int, error) {
+		return countWafv2Resources(func(marker *string) (int, *string, error) {
+			result, err := client.ListRuleGroups(&wafv2.ListRuleGroupsInput{Scope: aws.String(scope), NextMarker: marker, Limit: aws.Int64(wafv2ListLimit)})
+			if err != nil {
+				return 0, nil, err
+			}
+			return len(result.RuleGroups), result.NextMarker, nil
+		})
+	}
+}
+
+func countWafv2IpSets(scope string) func(client Wafv2ClientInterface) (int, error) {
+	return func(client Wafv2ClientInterface) (int, error) {
+		return countWafv2Resources(func(marker *string) (int, *string, error) {
+			result, err := client.ListIPSets(&wafv2.ListIPSetsInput{Scope: aws.String(scope), NextMarker: marker, Limit: aws.Int64(wafv2ListLimit)})
+			if err != nil {
+				return 0, nil, err
+			}
+			return len(result.IPSets), result.NextMarker, nil
+		})
+	}
+}
+
+func countWafv2RegexPatternSets(scope string) func(client Wafv2ClientInterface) (int, error) {
+	return func(client Wafv2ClientInterface) (int, error) {
+		return countWafv2Resources(func(marker *string) (int, *string, error) {
+			result, err := client.ListRegexPatternSets(&wafv2.ListRegexPatternSetsInput{Scope: aws.String(scope), NextMarker: marker, Limit: aws.Int64(wafv2ListLimit)})
+			if err != nil {
+				return 0, nil, err
+			}
+			return len(result.RegexPatternSets), result.NextMarker, nil
+		})
+	}
+}
+
+func (c ServiceChecker) getWafv2CloudfrontWebAclsUsage() (ret []AWSQuotaInfo) {
+	return c.getWafv2ScopeUsage("Maximum web ACLs per account in WAF for CloudFront", wafv2.ScopeCloudfront, countWafv2WebAcls(wafv2.ScopeCloudfront))
+}
+
+func (c ServiceChecker) getWafv2RegionalWebAclsUsage() (ret []AWSQuotaInfo) {
+	return c.getWafv2ScopeUsage("Maximum web ACLs per account in WAF for regional", wafv2.ScopeRegional, countWafv2WebAcls(wafv2.ScopeRegional))
+}
+
+func (c ServiceChecker) getWafv2CloudfrontRuleGroupsUsage() (ret []AWSQuotaInfo) {
+	return c.getWafv2ScopeUsage("Maximum rule groups per account in WAF for CloudFront", wafv2.ScopeCloudfront, countWafv2RuleGroups(wafv2.ScopeCloudfront))
+}
+
+func (c ServiceChecker) getWafv2RegionalRuleGroupsUsage() (ret []AWSQuotaInfo) {
+	return c.getWafv2ScopeUsage("Maximum rule groups per account in WAF for regional", wafv2.ScopeRegional, countWafv2RuleGroups(wafv2.ScopeRegional))
+}
+
+func (c ServiceChecker) getWafv2CloudfrontIpSetsUsage() (ret []AWSQuotaInfo) {
+	return c.getWafv2ScopeUsage("Maximum IP set per account in WAF for CloudFront", wafv2.ScopeCloudfront, countWafv2IpSets(wafv2.ScopeCloudfront))
+}
+
+func (c ServiceChecker) getWafv2RegionalIpSetsUsage() (ret []AWSQuotaInfo) {
+	return c.getWafv2ScopeUsage("Maximum IP set per account in WAF for regional", wafv2.ScopeRegional, countWafv2IpSets(wafv2.ScopeRegional))
+}
+
+func (c ServiceChecker) getWafv2CloudfrontRegexPatternSetsUsage() (ret []AWSQuotaInfo) {
+	return c.getWafv2ScopeUsage("Maximum regex pattern sets per account in WAF for CloudFront", wafv2.ScopeCloudfront, countWafv2RegexPatternSets(wafv2.ScopeCloudfront))
+}
+
+func (c ServiceChecker) getWafv2RegionalRegexPatternSetsUsage() (ret []AWSQuotaInfo) {
+	return c.getWafv2ScopeUsage("Maximum regex pattern sets per account in WAF for regional", wafv2.ScopeRegional, countWafv2RegexPatternSets(wafv2.ScopeRegional))
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockedWafv2Client struct {
+	Wafv2ClientInterface
+	ListWebACLsResp           wafv2.ListWebACLsOutput
+	ListWebACLsError          error
+	ListRuleGroupsResp        wafv2.ListRuleGroupsOutput
+	ListRuleGroupsError       error
+	ListIPSetsResp            wafv2.ListIPSetsOutput
+	ListIPSetsError           error
+	ListRegexPatternSetsResp  wafv2.ListRegexPatternSetsOutput
+	ListRegexPatternSetsError error
+}
+
+func (m mockedWafv2Client) ListWebACLs(input *wafv2.ListWebACLsInput) (*wafv2.ListWebACLsOutput, error) {
+	return &m.ListWebACLsResp, m.ListWebACLsError
+}
+
+func (m mockedWafv2Client) ListRuleGroups(input *wafv2.ListRuleGroupsInput) (*wafv2.ListRuleGroupsOutput, error) {
+	return &m.ListRuleGroupsResp, m.ListRuleGroupsError
+}
+
+func (m mockedWafv2Client) ListIPSets(input *wafv2.ListIPSetsInput) (*wafv2.ListIPSetsOutput, error) {
+	return &m.ListIPSetsResp, m.ListIPSetsError
+}
+
+func (m mockedWafv2Client) ListRegexPatternSets(input *wafv2.ListRegexPatternSetsInput) (*wafv2.ListRegexPatternSetsOutput, error) {
+	return &m.ListRegexPatternSetsResp, m.ListRegexPatternSetsError
+}
+
+func TestNewWafv2CheckerImpl(t *testing.T) {
+	require.Implements(t, (*Svcquota)(nil), NewWafv2Checker())
+}
+
+func TestGetWafv2Usage(t *testing.T) {
+	conf.Wafv2 = mockedWafv2Client{
+		ListWebACLsResp:          wafv2.ListWebACLsOutput{WebACLs: []*wafv2.WebACLSummary{{}, {}, {}}},
+		ListRuleGroupsResp:       wafv2.ListRuleGroupsOutput{RuleGroups: []*wafv2.RuleGroupSummary{{}}},
+		ListIPSetsResp:           wafv2.ListIPSetsOutput{IPSets: []*wafv2.IPSetSummary{{}, {}}},
+		ListRegexPatternSetsResp: wafv2.ListRegexPatternSetsOutput{RegexPatternSets: []*wafv2.RegexPatternSetSummary{}},
+	}
+	conf.Wafv2Cloudfront = mockedWafv2Client{
+		ListWebACLsResp:          wafv2.ListWebACLsOutput{WebACLs: []*wafv2.WebACLSummary{{}}},
+		ListRuleGroupsResp:       wafv2.ListRuleGroupsOutput{RuleGroups: []*wafv2.RuleGroupSummary{{}, {}}},
+		ListIPSetsResp:           wafv2.ListIPSetsOutput{IPSets: []*wafv2.IPSetSummary{}},
+		ListRegexPatternSetsResp: wafv2.ListRegexPatternSetsOutput{RegexPatternSets: []*wafv2.RegexPatternSetSummary{{}}},
+	}
+	svcChecker := newTestServiceChecker(NewWafv2Checker, NewQuota("wafv2", "Maximum web ACLs per account in WAF for regional", float64(200), false))
+
+	regionalWebAcls := svcChecker.getWafv2RegionalWebAclsUsage()
+	assert.Len(t, regionalWebAcls, 1)
+	assert.Equal(t, "wafv2", regionalWebAcls[0].Service)
+	assert.Equal(t, float64(200), regionalWebAcls[0].QuotaValue)
+	assert.Equal(t, float64(3), regionalWebAcls[0].UsageValue)
+	assert.False(t, regionalWebAcls[0].Global)
+
+	cloudfrontWebAcls := svcChecker.getWafv2CloudfrontWebAclsUsage()
+	assert.Len(t, cloudfrontWebAcls, 1)
+	assert.Equal(t, float64(100), cloudfrontWebAcls[0].QuotaValue)
+	assert.Equal(t, float64(1), cloudfrontWebAcls[0].UsageValue)
+	assert.True(t, cloudfrontWebAcls[0].Global)
+
+	assert.Equal(t, float64(1), svcChecker.getWafv2RegionalRuleGroupsUsage()[0].UsageValue)
+	assert.Equal(t, float64(2), svcChecker.getWafv2CloudfrontRuleGroupsUsage()[0].UsageValue)
+	assert.Equal(t, float64(2), svcChecker.getWafv2RegionalIpSetsUsage()[0].UsageValue)
+	assert.Equal(t, float64(0), svcChecker.getWafv2CloudfrontIpSetsUsage()[0].UsageValue)
+
+	regexPatternSets := svcChecker.getWafv2CloudfrontRegexPatternSetsUsage()
+	assert.Len(t, regexPatternSets, 1)
+	assert.Equal(t, float64(10), regexPatternSets[0].QuotaValue)
+	assert.Equal(t, float64(1), regexPatternSets[0].UsageValue)
+	assert.Equal(t, float64(0), svcChecker.getWafv2RegionalRegexPatternSetsUsage()[0].UsageValue)
+}
+
+func TestGetWafv2UsageError(t *testing.T) {
+	mock := mockedWafv2Client{
+		ListWebACLsError:          errors.New("test error"),
+		ListRuleGroupsError:       errors.New("test error"),
+		ListIPSetsError:           errors.New("test error"),
+		ListRegexPatternSetsError: errors.New("test error"),
+	}
+	conf.Wafv2 = mock
+	conf.Wafv2Cloudfront = mock
+	svcChecker := newTestServiceChecker(NewWafv2Checker)
+
+	expected := []AWSQuotaInfo{}
+	assert.Equal(t, expected, svcChecker.getWafv2RegionalWebAclsUsage())
+	assert.Equal(t, expected, svcChecker.getWafv2CloudfrontWebAclsUsage())
+	assert.Equal(t, expected, svcChecker.getWafv2RegionalRuleGroupsUsage())
+	assert.Equal(t, expected, svcChecker.getWafv2CloudfrontRuleGroupsUsage())
+	assert.Equal(t, expected, svcChecker.getWafv2RegionalIpSetsUsage())
+	assert.Equal(t, expected, svcChecker.getWafv2CloudfrontIpSetsUsage())
+	assert.Equal(t, expected, svcChecker.getWafv2RegionalRegexPatternSetsUsage())
+	assert.Equal(t, expected, svcChecker.getWafv2CloudfrontRegexPatternSetsUsage())
+}
+
+func TestCountWafv2ResourcesPaging(t *testing.T) {
+	pages := []struct {
+		count int
+		next  *string
+	}{{100, &[]string{"page2"}[0]}, {42, nil}}
+	calls := 0
+	count, err := countWafv2Resources(func(marker *string) (int, *string, error) {
+		page := pages[calls]
+		calls++
+		return page.count, page.next, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 142, count)
+	assert.Equal(t, 2, calls)
+}
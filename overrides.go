@@ -0,0 +1,180 @@
+package awslimitchecker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sebasrp/awslimitchecker/internal/services"
+	"github.com/spf13/viper"
+)
+
+var accountIdRegexp = regexp.MustCompile(`^[0-9]{12}$`)
+
+// LoadQuotaOverrides reads the overrides defined in the given yaml or json file,
+// as a list under the `overrides` key. The legacy json format, mapping service
+// codes to quota names and values, is still supported
+func LoadQuotaOverrides(overridesFile string) (ret []services.AWSQuotaOverride, err error) {
+	v := viper.New()
+	v.SetConfigFile(overridesFile)
+	if err = v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("unable to read overrides file %s, %v", overridesFile, err)
+	}
+	if v.IsSet("overrides") {
+		if ret, err = UnmarshalQuotaOverrides(v, "overrides"); err != nil {
+			return nil, fmt.Errorf("invalid overrides in %s, %v", overridesFile, err)
+		}
+		return
+	}
+
+	// viper lower cases keys, so the legacy format, keyed by quota names, is
+	// read as is
+	if strings.ToLower(filepath.Ext(overridesFile)) != ".json" {
+		return nil, fmt.Errorf("no overrides defined in %s", overridesFile)
+	}
+	return loadLegacyQuotaOverrides(overridesFile)
+}
+
+// UnmarshalQuotaOverrides reads the list of overrides under the given key of the
+// configuration. Every override must set its value, so that a misspelled value
+// key is not taken for a quota of 0
+func UnmarshalQuotaOverrides(v *viper.Viper, key string) (ret []services.AWSQuotaOverride, err error) {
+	if err = v.UnmarshalKey(key, &ret); err != nil {
+		return nil, err
+	}
+	items, _ := v.Get(key).([]interface{})
+	errs := []string{}
+	for i, item := range items {
+		if !hasOverrideValue(item) {
+			errs = append(errs, fmt.Sprintf("override #%d: value is required", i+1))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "\n"))
+	}
+	return
+}
+
+// hasOverrideValue tells whether a raw override sets the value key, whatever the
+// map type of the configuration format
+func hasOverrideValue(item interface{}) bool {
+	names := []string{}
+	switch override := item.(type) {
+	case map[string]interface{}:
+		for name := range override {
+			names = append(names, name)
+		}
+	case map[interface{}]interface{}:
+		for name := range override {
+			names = append(names, fmt.Sprint(name))
+		}
+	}
+	for _, name := range names {
+		if strings.EqualFold(name, "value") {
+			return true
+		}
+	}
+	return false
+}
+
+func loadLegacyQuotaOverrides(overridesFile string) (ret []services.AWSQuotaOverride, err error) {
+	content, err := os.ReadFile(overridesFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read overrides file %s, %v", overridesFile, err)
+	}
+	var payload map[string]map[string]float64
+	if err = json.Unmarshal(content, &payload); err != nil {
+		return nil, fmt.Errorf("invalid overrides in %s, %v", overridesFile, err)
+	}
+	for svcName, svc := range payload {
+		for quotaName, quota := range svc {
+			ret = append(ret, services.AWSQuotaOverride{Service: svcName, QuotaName: quotaName, QuotaValue: quota})
+		}
+	}
+	return
+}
+
+// ValidateQuotaOverrides checks the given overrides, and returns them with
+// supported service names (e.g. msk) replaced by their service code (e.g.
+// kafka). Unless anyService is set, overrides must target a supported service.
+// Quota names are checked once the quotas of servicequotas can be retrieved,
+// see checkOverrideQuotaNames
+func ValidateQuotaOverrides(overrides []services.AWSQuotaOverride, anyService bool) (ret []services.AWSQuotaOverride, err error) {
+	serviceCodes := map[string]string{}
+	for name, checker := range SupportedAwsServices {
+		if svcChecker, ok := checker().(*services.ServiceChecker); ok {
+			serviceCodes[name] = svcChecker.ServiceCode
+			serviceCodes[svcChecker.ServiceCode] = svcChecker.ServiceCode
+		}
+	}
+
+	errs := []string{}
+	for i, override := range overrides {
+		invalid := func(format string, a ...interface{}) {
+			errs = append(errs, fmt.Sprintf("override #%d: %s", i+1, fmt.Sprintf(format, a...)))
+		}
+		if serviceCode, ok := serviceCodes[override.Service]; ok {
+			override.Service = serviceCode
+		} else if override.Service == "" {
+			invalid("service is required")
+		} else if !anyService {
+			invalid("unknown service %q", override.Service)
+		}
+		if override.QuotaName == "" && override.QuotaCode == "" {
+			invalid("quotaName or quotaCode is required")
+		}
+		if override.Account != "" && !accountIdRegexp.MatchString(override.Account) {
+			invalid("invalid account id %q", override.Account)
+		}
+		if _, errMatch := path.Match(override.ResourceId, ""); errMatch != nil {
+			invalid("invalid resourceId pattern %q, %v", override.ResourceId, errMatch)
+		}
+		if override.QuotaValue < 0 {
+			invalid("negative value %g", override.QuotaValue)
+		}
+		ret = append(ret, override)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid quota overrides:\n%s", strings.Join(errs, "\n"))
+	}
+	return
+}
+
+// checkOverrideQuotaNames checks the quota names of the overrides of supported
+// services. Names the checkers do not compute the usage of are looked up in the
+// applied and default quotas of servicequotas, returned by knownQuotas for a
+// service code. Names are not rejected when servicequotas returned no quota
+func checkOverrideQuotaNames(overrides []services.AWSQuotaOverride, knownQuotas func(serviceCode string) map[string]services.AWSQuotaInfo) error {
+	handledQuotas := map[string]map[string]bool{}
+	for serviceCode, quotaNames := range getHandledQuotas() {
+		handledQuotas[serviceCode] = map[string]bool{}
+		for _, quotaName := range quotaNames {
+			handledQuotas[serviceCode][quotaName] = true
+		}
+	}
+
+	serviceQuotas := map[string]map[string]services.AWSQuotaInfo{}
+	errs := []string{}
+	for i, override := range overrides {
+		quotas, ok := handledQuotas[override.Service]
+		if !ok || override.QuotaName == "" || quotas[override.QuotaName] {
+			continue
+		}
+		if _, ok := serviceQuotas[override.Service]; !ok {
+			serviceQuotas[override.Service] = knownQuotas(override.Service)
+		}
+		if _, ok := serviceQuotas[override.Service][override.QuotaName]; ok || len(serviceQuotas[override.Service]) == 0 {
+			continue
+		}
+		errs = append(errs, fmt.Sprintf("override #%d: unknown quota %q for service %s", i+1, override.QuotaName, override.Service))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid quota overrides:\n%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package awslimitchecker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sebasrp/awslimitchecker/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeOverridesFile(t *testing.T, name string, content string) string {
+	overridesFile := filepath.Join(t.TempDir(), name)
+	require.Nil(t, os.WriteFile(overridesFile, []byte(content), 0600))
+	return overridesFile
+}
+
+func TestLoadQuotaOverridesYaml(t *testing.T) {
+	overridesFile := writeOverridesFile(t, "overrides.yaml", `
+overrides:
+  - service: eks
+    quotaName: Nodes per managed node group
+    region: eu-west-1
+    account: "123456789012"
+    resourceId: "AWS::EKS::Nodegroup::prod/*"
+    value: 50
+  - service: ec2
+    quotaCode: L-0EA8095F
+    value: 100
+`)
+	actual, err := LoadQuotaOverrides(overridesFile)
+	require.Nil(t, err)
+	assert.Equal(t, []services.AWSQuotaOverride{
+		{Service: "eks", QuotaName: "Nodes per managed node group", Region: "eu-west-1", Account: "123456789012", ResourceId: "AWS::EKS::Nodegroup::prod/*", QuotaValue: 50},
+		{Service: "ec2", QuotaCode: "L-0EA8095F", QuotaValue: 100},
+	}, actual)
+}
+
+func TestLoadQuotaOverridesJson(t *testing.T) {
+	overridesFile := writeOverridesFile(t, "overrides.json", `{"overrides": [{"service": "kinesis", "quotaName": "Shards per Region", "value": 123}]}`)
+	actual, err := LoadQuotaOverrides(overridesFile)
+	require.Nil(t, err)
+	assert.Equal(t, []services.AWSQuotaOverride{{Service: "kinesis", QuotaName: "Shards per Region", QuotaValue: 123}}, actual)
+}
+
+func TestLoadQuotaOverridesLegacyJson(t *testing.T) {
+	overridesFile := writeOverridesFile(t, "overrides.json", `{"kinesis": {"Shards per Region": 123}}`)
+	actual, err := LoadQuotaOverrides(overridesFile)
+	require.Nil(t, err)
+	assert.Equal(t, []services.AWSQuotaOverride{{Service: "kinesis", QuotaName: "Shards per Region", QuotaValue: 123}}, actual)
+}
+
+func TestLoadQuotaOverridesValueRequired(t *testing.T) {
+	_, err := LoadQuotaOverrides(writeOverridesFile(t, "overrides.yaml", `
+overrides:
+  - service: kinesis
+    quotaName: Shards per Region
+    value: 0
+  - service: kinesis
+    quotaName: Shards per Region
+    quotaValue: 10
+`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "override #2: value is required")
+	assert.NotContains(t, err.Error(), "override #1")
+
+	_, err = LoadQuotaOverrides(writeOverridesFile(t, "overrides.json", `{"overrides": [{"service": "kinesis", "quotaName": "Shards per Region"}]}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "override #1: value is required")
+}
+
+func TestLoadQuotaOverridesError(t *testing.T) {
+	_, err := LoadQuotaOverrides(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+
+	_, err = LoadQuotaOverrides(writeOverridesFile(t, "overrides.yaml", "kinesis: 1\n"))
+	assert.Error(t, err)
+
+	_, err = LoadQuotaOverrides(writeOverridesFile(t, "overrides.json", `{"kinesis": 1}`))
+	assert.Error(t, err)
+}
+
+func TestValidateQuotaOverrides(t *testing.T) {
+	SupportedAwsServices = map[string]func() services.Svcquota{
+		"kinesis": services.NewKinesisChecker,
+		"msk":     services.NewMskChecker,
+	}
+
+	actual, err := ValidateQuotaOverrides([]services.AWSQuotaOverride{
+		{Service: "kinesis", QuotaName: "Shards per Region", QuotaValue: 123},
+		{Service: "msk", QuotaName: "Brokers per cluster", ResourceId: "AWS::MSK::Cluster::prod-*", QuotaValue: 60},
+		{Service: "kafka", QuotaCode: "L-1234", Account: "123456789012", QuotaValue: 10},
+	}, false)
+	require.Nil(t, err)
+	assert.Equal(t, "kinesis", actual[0].Service)
+	assert.Equal(t, "kafka", actual[1].Service) // supported service names are resolved to their code
+	assert.Equal(t, "kafka", actual[2].Service)
+
+	_, err = ValidateQuotaOverrides([]services.AWSQuotaOverride{
+		{QuotaName: "Shards per Region"},
+		{Service: "lambda", QuotaName: "Concurrent executions"},
+		{Service: "kinesis"},
+		{Service: "kinesis", QuotaCode: "L-1234", Account: "1234"},
+		{Service: "kinesis", QuotaCode: "L-1234", ResourceId: "["},
+		{Service: "kinesis", QuotaCode: "L-1234", QuotaValue: -1},
+	}, false)
+	require.Error(t, err)
+	for _, expected := range []string{
+		"override #1: service is required",
+		`override #2: unknown service "lambda"`,
+		"override #3: quotaName or quotaCode is required",
+		`override #4: invalid account id "1234"`,
+		`override #5: invalid resourceId pattern "["`,
+		"override #6: negative value -1",
+	} {
+		assert.Contains(t, err.Error(), expected)
+	}
+
+	// with usage metrics, any servicequotas service code can be overridden
+	_, err = ValidateQuotaOverrides([]services.AWSQuotaOverride{{Service: "lambda", QuotaName: "Concurrent executions"}}, true)
+	assert.Nil(t, err)
+}
+
+func TestCheckOverrideQuotaNames(t *testing.T) {
+	SupportedAwsServices = map[string]func() services.Svcquota{
+		"kinesis": services.NewKinesisChecker,
+	}
+	lookups := 0
+	knownQuotas := func(serviceCode string) map[string]services.AWSQuotaInfo {
+		lookups++
+		return map[string]services.AWSQuotaInfo{"Records per second per shard": {Service: serviceCode, QuotaName: "Records per second per shard"}}
+	}
+
+	err := checkOverrideQuotaNames([]services.AWSQuotaOverride{
+		{Service: "kinesis", QuotaName: "Shards per Region"},
+		{Service: "kinesis", QuotaName: "Records per second per shard"},
+		{Service: "kinesis", QuotaName: "Unknown quota"},
+		{Service: "lambda", QuotaName: "Concurrent executions"},
+	}, knownQuotas)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `override #3: unknown quota "Unknown quota" for service kinesis`)
+	assert.NotContains(t, err.Error(), "override #2")
+	assert.NotContains(t, err.Error(), "override #4")
+	assert.Equal(t, 1, lookups) // servicequotas is queried once per service
+
+	// names cannot be checked when servicequotas returned nothing
+	err = checkOverrideQuotaNames([]services.AWSQuotaOverride{{Service: "kinesis", QuotaName: "Unknown quota"}},
+		func(string) map[string]services.AWSQuotaInfo { return map[string]services.AWSQuotaInfo{} })
+	assert.Nil(t, err)
+}
@@ -0,0 +1,110 @@
+package awslimitchecker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sebasrp/awslimitchecker/internal/services"
+)
+
+// QuotaDescription describes a quota of a service, as known by servicequotas
+type QuotaDescription struct {
+	Quota          services.AWSQuotaInfo // the applied quota, or the default one when listing defaults
+	DefaultValue   float64               // the aws default value of the quota
+	HasDefault     bool                  // whether servicequotas returned a default value for the quota
+	UsageSupported bool                  // whether the usage of the quota can be computed
+}
+
+type QuotasOptions struct {
+	Defaults       bool   // list the default quotas instead of the applied ones
+	AdjustableOnly bool   // only list the quotas that can be increased
+	Search         string // only list the quotas whose name or code contains this text (case insensitive)
+}
+
+// GetQuotas lists all the quotas servicequotas knows for the given service,
+// sorted by name. awsService is either a supported service or a servicequotas
+// service code. Supported services sharing their service code with others
+// only list the quotas they check
+func GetQuotas(awsService string, awsprofile string, region string, options QuotasOptions) (ret []QuotaDescription) {
+	_, err := services.InitializeConfig(awsprofile, region)
+	if err != nil {
+		fmt.Printf("Unable to create AWS session, %v", err)
+		return
+	}
+
+	serviceCode := awsService
+	var ownQuotas map[string]bool
+	if checker, ok := SupportedAwsServices[awsService]; ok {
+		if svcChecker, ok := checker().(*services.ServiceChecker); ok {
+			serviceCode = svcChecker.ServiceCode
+			ownQuotas = getOwnQuotas(awsService, svcChecker)
+		}
+	}
+	handledQuotas := map[string]bool{}
+	for _, quotaName := range getHandledQuotas()[serviceCode] {
+		handledQuotas[quotaName] = true
+	}
+
+	service := services.NewServiceChecker(serviceCode, nil, nil)
+	defaultQuotas := service.GetAllDefaultQuotas()
+	quotas := defaultQuotas
+	if !options.Defaults {
+		quotas = service.GetAllAppliedQuotas()
+	}
+
+	for name, quota := range quotas {
+		if (ownQuotas != nil && !ownQuotas[name]) || !matchesQuotasOptions(quota, options) {
+			continue
+		}
+		defaultQuota, hasDefault := defaultQuotas[name]
+		ret = append(ret, QuotaDescription{
+			Quota:          quota,
+			DefaultValue:   defaultQuota.QuotaValue,
+			HasDefault:     hasDefault,
+			UsageSupported: handledQuotas[name] || quota.UsageMetric != nil,
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Quota.QuotaName < ret[j].Quota.QuotaName
+	})
+	return
+}
+
+// getOwnQuotas returns the quotas the given supported service checks when it
+// shares its service code with other supported services (e.g. ebs and vpn for
+// ec2), nil when the service code is its own
+func getOwnQuotas(awsService string, svcChecker *services.ServiceChecker) map[string]bool {
+	if awsService == svcChecker.ServiceCode {
+		return nil
+	}
+	shared := false
+	for name, checker := range SupportedAwsServices {
+		if other, ok := checker().(*services.ServiceChecker); ok && name != awsService && other.ServiceCode == svcChecker.ServiceCode {
+			shared = true
+			break
+		}
+	}
+	if !shared {
+		return nil
+	}
+
+	ret := map[string]bool{}
+	for quotaName := range svcChecker.SupportedQuotas {
+		ret[quotaName] = true
+	}
+	return ret
+}
+
+func matchesQuotasOptions(quota services.AWSQuotaInfo, options QuotasOptions) bool {
+	if options.AdjustableOnly && !quota.Adjustable {
+		return false
+	}
+	if options.Search != "" {
+		search := strings.ToLower(options.Search)
+		if !strings.Contains(strings.ToLower(quota.QuotaName), search) && !strings.Contains(strings.ToLower(quota.Quotacode), search) {
+			return false
+		}
+	}
+	return true
+}